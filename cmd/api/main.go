@@ -14,6 +14,11 @@ import (
 	"github.com/joho/godotenv"
 
 	"lesmotsdatche/internal/api"
+	"lesmotsdatche/internal/config"
+	"lesmotsdatche/internal/generator"
+	"lesmotsdatche/internal/generator/fill"
+	"lesmotsdatche/internal/generator/languagepack"
+	"lesmotsdatche/internal/generator/llm"
 	"lesmotsdatche/internal/store"
 )
 
@@ -21,15 +26,29 @@ func main() {
 	// Load .env file if present
 	_ = godotenv.Load()
 
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
+
 	var (
-		addr   = flag.String("addr", envOr("PORT", ":8080"), "HTTP server address")
-		dbPath = flag.String("db", envOr("DATABASE_PATH", "puzzles.db"), "SQLite database path")
+		addr            = flag.String("addr", cfg.Addr, "HTTP server address")
+		dbPath          = flag.String("db", cfg.DatabasePath, "SQLite database path")
+		publishInterval = flag.Duration("publish-interval", cfg.PublishInterval, "How often to publish due scheduled puzzles")
 	)
 	flag.Parse()
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
+	// The flag overrides the validated config, and time.NewTicker panics on a
+	// non-positive interval
+	if *publishInterval <= 0 {
+		logger.Error("invalid configuration", "error", "-publish-interval must be positive", "publish_interval", publishInterval.String())
+		os.Exit(1)
+	}
 
 	// Initialize database
 	db, err := store.NewSQLiteStore(*dbPath)
@@ -45,10 +64,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Enable LLM-backed endpoints when an API key is available
+	var orch *generator.Orchestrator
+	if cfg.APIKey != "" {
+		llmConfig := llm.DefaultConfig()
+		llmConfig.TransportRetries = 2 // Connection resets and 5xx are usually transient
+		client := llm.NewValidatingClient(llm.NewOpenAIClient(llm.OpenAIConfig{
+			APIKey:  cfg.APIKey,
+			Model:   cfg.Model,
+			Timeout: cfg.LLMTimeout,
+		}), llmConfig)
+		genConfig := generator.DefaultConfig()
+		genConfig.Timeout = cfg.GenerationTimeout
+		genConfig.AnswerRepeatWindowDays = cfg.AnswerRepeatDays
+		genConfig.AttemptBackoff = time.Second
+		genConfig.AttemptBackoffJitter = 500 * time.Millisecond
+		orch = generator.NewOrchestrator(client, languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), genConfig).
+			WithLogger(logger).
+			WithAnswerHistory(db.Puzzles())
+	} else {
+		logger.Info("OPENAI_API_KEY not set, clue generation disabled")
+	}
+
 	// Create router
 	router := api.NewRouter(api.Config{
 		Store:  db,
 		Logger: logger,
+		Lexicons: map[string]*fill.MemoryLexicon{
+			"fr": fill.SampleFrenchLexicon(),
+		},
+		Orchestrator: orch,
+		AdminToken:   cfg.AdminToken,
+		CORSOrigins:  cfg.CORSOrigins,
+		ServePlayer:  cfg.ServePlayer,
+		Models:       cfg.AllowedModels,
 	})
 
 	// Create server
@@ -60,6 +109,11 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Publish scheduled puzzles once their date arrives
+	reconcileCtx, stopReconciler := context.WithCancel(context.Background())
+	defer stopReconciler()
+	go runPublishReconciler(reconcileCtx, db, *publishInterval, logger)
+
 	// Graceful shutdown
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
@@ -85,9 +139,23 @@ func main() {
 	logger.Info("server stopped")
 }
 
-func envOr(key, fallback string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
+// runPublishReconciler periodically promotes scheduled puzzles whose date has arrived.
+func runPublishReconciler(ctx context.Context, s store.Store, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := api.PublishDuePuzzles(ctx, s, time.Now())
+		if err != nil {
+			logger.Error("failed to publish scheduled puzzles", "error", err)
+		} else if n > 0 {
+			logger.Info("published scheduled puzzles", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
-	return fallback
 }
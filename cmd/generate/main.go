@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"time"
 
 	"github.com/joho/godotenv"
 
+	"lesmotsdatche/internal/config"
 	"lesmotsdatche/internal/generator"
 	"lesmotsdatche/internal/generator/fill"
 	"lesmotsdatche/internal/generator/languagepack"
@@ -22,6 +24,12 @@ func main() {
 	// Load .env file if present (silently ignore if not found)
 	_ = godotenv.Load()
 
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Parse flags
 	date := flag.String("date", time.Now().Format("2006-01-02"), "Target date (YYYY-MM-DD)")
 	language := flag.String("lang", "fr", "Language code (fr, en)")
@@ -29,17 +37,26 @@ func main() {
 	maxSize := flag.Int("max-size", 12, "Max grid dimension (grid built around words)")
 	output := flag.String("output", "", "Output file (default: stdout)")
 	apiKey := flag.String("api-key", "", "OpenAI API key (or set OPENAI_API_KEY env)")
-	model := flag.String("model", "gpt-4o", "LLM model to use")
-	timeout := flag.Duration("timeout", 5*time.Minute, "Generation timeout")
+	model := flag.String("model", cfg.Model, "LLM model to use")
+	timeout := flag.Duration("timeout", cfg.GenerationTimeout, "Generation timeout")
 	maxAttempts := flag.Int("max-attempts", 3, "Maximum generation attempts")
 	verbose := flag.Bool("verbose", false, "Verbose output")
+	author := flag.String("author", generator.DefaultAuthor, "Puzzle author (byline)")
+	templateName := flag.String("template", "", fmt.Sprintf("Named grid template to fill instead of building word-first %v", fill.TemplateNames()))
 
 	flag.Parse()
 
+	// Resolve the template before spending any API calls
+	genReq, err := buildRequest(*date, *language, *difficulty, *maxSize, *templateName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Get API key
 	key := *apiKey
 	if key == "" {
-		key = os.Getenv("OPENAI_API_KEY")
+		key = cfg.APIKey
 	}
 	if key == "" {
 		fmt.Fprintln(os.Stderr, "Error: OpenAI API key required (use -api-key or set OPENAI_API_KEY)")
@@ -68,7 +85,9 @@ func main() {
 		Model:   *model,
 		Timeout: *timeout,
 	})
-	validatingClient := llm.NewValidatingClient(openaiClient, llm.DefaultConfig())
+	llmConfig := llm.DefaultConfig()
+	llmConfig.TransportRetries = 2 // Connection resets and 5xx are usually transient
+	validatingClient := llm.NewValidatingClient(openaiClient, llmConfig)
 
 	// Create base lexicon
 	baseLexicon := fill.SampleFrenchLexicon()
@@ -79,8 +98,14 @@ func main() {
 	config.Timeout = *timeout
 	config.TargetDifficulty = *difficulty
 	config.GridSize = [2]int{*maxSize, *maxSize} // Max bounds for word-first construction
+	config.Author = *author
+	config.AttemptBackoff = time.Second
+	config.AttemptBackoffJitter = 500 * time.Millisecond
 
 	orch := generator.NewOrchestrator(validatingClient, langPack, baseLexicon, config)
+	if *verbose {
+		orch.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	}
 
 	// Generate puzzle
 	ctx := context.Background()
@@ -95,22 +120,14 @@ func main() {
 	}
 
 	start := time.Now()
-	result, err := orch.Generate(ctx, generator.GenerateRequest{
-		Date:     *date,
-		Language: *language,
-		GridRows: *maxSize, // Max bounds, actual size determined by words
-		GridCols: *maxSize,
-		Constraints: theme.ThemeConstraints{
-			Difficulty: *difficulty,
-		},
-	})
+	result, err := orch.Generate(ctx, genReq)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: Generation failed: %v\n", err)
 		// Print traces for debugging
 		if *verbose {
 			fmt.Fprintln(os.Stderr, "\nLLM Traces:")
-			for i, trace := range validatingClient.Traces() {
-				fmt.Fprintf(os.Stderr, "  [%d] Attempt %d:\n", i+1, trace.Attempt)
+			for _, trace := range validatingClient.Traces() {
+				fmt.Fprintf(os.Stderr, "  [%d] Attempt %d:\n", trace.CallIndex, trace.Attempt)
 				fmt.Fprintf(os.Stderr, "      Prompt: %s\n", truncate(trace.Request.Prompt, 100))
 				fmt.Fprintf(os.Stderr, "      Response: %s\n", truncate(trace.Response.Content, 200))
 				if trace.Error != "" {
@@ -151,6 +168,33 @@ func main() {
 	}
 }
 
+// buildRequest turns command-line options into a generation request. A named
+// template is filled by the solver; otherwise the grid is built around words
+// within maxSize bounds.
+func buildRequest(date, language string, difficulty, maxSize int, templateName string) (generator.GenerateRequest, error) {
+	req := generator.GenerateRequest{
+		Date:     date,
+		Language: language,
+		GridRows: maxSize, // Max bounds, actual size determined by words
+		GridCols: maxSize,
+		Constraints: theme.ThemeConstraints{
+			Difficulty: difficulty,
+		},
+	}
+
+	if templateName != "" {
+		template, err := fill.NamedTemplate(templateName)
+		if err != nil {
+			return generator.GenerateRequest{}, err
+		}
+		req.Template = template
+		req.GridRows = len(template)
+		req.GridCols = len(template[0])
+	}
+
+	return req, nil
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
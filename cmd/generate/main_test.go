@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"lesmotsdatche/internal/generator/fill"
+)
+
+func TestBuildRequest_Template(t *testing.T) {
+	req, err := buildRequest("2026-01-15", "fr", 3, 12, "cross-5x5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(req.Template) != 5 || len(req.Template[0]) != 5 {
+		t.Fatalf("expected 5x5 template, got %d rows", len(req.Template))
+	}
+	if req.GridRows != 5 || req.GridCols != 5 {
+		t.Errorf("expected grid size 5x5, got %dx%d", req.GridRows, req.GridCols)
+	}
+
+	req, err = buildRequest("2026-01-15", "fr", 3, 12, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Template != nil {
+		t.Error("expected no template without -template")
+	}
+	if req.GridRows != 12 || req.Constraints.Difficulty != 3 {
+		t.Errorf("unexpected request: %+v", req)
+	}
+
+	if _, err := buildRequest("2026-01-15", "fr", 3, 12, "no-such-template"); !errors.Is(err, fill.ErrUnknownTemplate) {
+		t.Errorf("expected ErrUnknownTemplate, got %v", err)
+	}
+}
@@ -1,20 +1,40 @@
 package api
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"lesmotsdatche/internal/domain"
 	"lesmotsdatche/internal/generator"
+	"lesmotsdatche/internal/generator/fill"
+	"lesmotsdatche/internal/generator/languagepack"
+	"lesmotsdatche/internal/generator/qa"
 	"lesmotsdatche/internal/generator/theme"
 	"lesmotsdatche/internal/store"
+	"lesmotsdatche/internal/validate"
 )
 
 // AdminHandler holds dependencies for admin HTTP handlers.
 type AdminHandler struct {
 	store        store.Store
 	orchestrator *generator.Orchestrator
+	lexicons     map[string]*fill.MemoryLexicon // Base lexicons by language code
+	models       []string                       // LLM models a generate request may ask for
+	logger       *slog.Logger
 }
 
 // NewAdminHandler creates a new admin handler.
@@ -22,9 +42,32 @@ func NewAdminHandler(s store.Store, orch *generator.Orchestrator) *AdminHandler
 	return &AdminHandler{
 		store:        s,
 		orchestrator: orch,
+		logger:       slog.Default(),
 	}
 }
 
+// WithLogger sets the logger for failures that don't fail the request. A nil
+// logger keeps the default.
+func (h *AdminHandler) WithLogger(logger *slog.Logger) *AdminHandler {
+	if logger != nil {
+		h.logger = logger
+	}
+	return h
+}
+
+// WithLexicons sets the base lexicons (by language code) used for word lookups.
+func (h *AdminHandler) WithLexicons(lexicons map[string]*fill.MemoryLexicon) *AdminHandler {
+	h.lexicons = lexicons
+	return h
+}
+
+// WithModels sets the LLM models a generate request may select instead of the
+// client's configured model. Without any, model overrides are rejected.
+func (h *AdminHandler) WithModels(models []string) *AdminHandler {
+	h.models = models
+	return h
+}
+
 // GenerateRequest is the request body for puzzle generation.
 type GenerateRequest struct {
 	Date         string   `json:"date"`
@@ -34,24 +77,34 @@ type GenerateRequest struct {
 	GridCols     int      `json:"grid_cols,omitempty"`     // Grid columns (10-16, default: 13)
 	AvoidThemes  []string `json:"avoid_themes,omitempty"`
 	PreferTopics []string `json:"prefer_topics,omitempty"`
+	Model        string   `json:"model,omitempty"`  // LLM model override, from the allowed models
+	Author       string   `json:"author,omitempty"` // Puzzle author (default: the generator's configured author)
 }
 
-// GeneratePuzzle generates a new puzzle.
+// idempotencyTTL is how long a generate response is replayed for repeats of
+// its Idempotency-Key.
+const idempotencyTTL = 24 * time.Hour
+
+// GeneratePuzzle generates a new puzzle. With an Idempotency-Key header, a
+// successful result is saved and returned again for repeats of the key within
+// idempotencyTTL, instead of generating another puzzle; reusing a key for a
+// different request is rejected. A repeat sent while the first request is
+// still running generates again.
 // POST /admin/v1/generate
 func (h *AdminHandler) GeneratePuzzle(w http.ResponseWriter, r *http.Request) {
 	if h.orchestrator == nil {
-		writeError(w, http.StatusServiceUnavailable, "generator not configured")
+		writeError(w, r, http.StatusServiceUnavailable, "generator not configured")
 		return
 	}
 
 	var req GenerateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if req.Date == "" {
-		writeError(w, http.StatusBadRequest, "date is required")
+		writeError(w, r, http.StatusBadRequest, "date is required")
 		return
 	}
 	if req.Language == "" {
@@ -60,12 +113,18 @@ func (h *AdminHandler) GeneratePuzzle(w http.ResponseWriter, r *http.Request) {
 	if req.Difficulty < 1 || req.Difficulty > 5 {
 		req.Difficulty = 3
 	}
+	if req.Model != "" && !slices.Contains(h.models, req.Model) {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("model %q is not allowed", req.Model))
+		return
+	}
 
 	genReq := generator.GenerateRequest{
 		Date:     req.Date,
 		Language: req.Language,
 		GridRows: req.GridRows,
 		GridCols: req.GridCols,
+		Model:    req.Model,
+		Author:   req.Author,
 		Constraints: theme.ThemeConstraints{
 			AvoidThemes:  req.AvoidThemes,
 			PreferTopics: req.PreferTopics,
@@ -73,13 +132,240 @@ func (h *AdminHandler) GeneratePuzzle(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	key := r.Header.Get("Idempotency-Key")
+	var requestHash string
+	if key != "" {
+		normalized, _ := json.Marshal(req)
+		sum := sha256.Sum256(normalized)
+		requestHash = hex.EncodeToString(sum[:])
+
+		rec, err := h.store.Idempotency().Get(r.Context(), key)
+		switch {
+		case err == nil && rec.RequestHash != requestHash:
+			writeError(w, r, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			return
+		case err == nil:
+			w.Header().Set("Idempotent-Replayed", "true")
+			writeJSON(w, r, http.StatusOK, json.RawMessage(rec.Response))
+			return
+		case !errors.Is(err, store.ErrNotFound):
+			writeError(w, r, http.StatusInternalServerError, "failed to check idempotency key")
+			return
+		}
+	}
+
 	result, err := h.orchestrator.Generate(r.Context(), genReq)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if key != "" {
+		// A failure to save only costs a regeneration on retry, so the
+		// result is returned regardless
+		response, err := json.Marshal(result)
+		if err == nil {
+			err = h.store.Idempotency().Store(r.Context(), &store.IdempotencyRecord{
+				Key:         key,
+				RequestHash: requestHash,
+				Response:    response,
+				ExpiresAt:   time.Now().Add(idempotencyTTL),
+			})
+		}
+		if err != nil {
+			h.logger.Error("failed to save idempotency record", "error", err, "key", key)
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, result)
+}
+
+// ClueRequest is the request body for single-answer clue generation.
+type ClueRequest struct {
+	Answer     string `json:"answer"`
+	Difficulty int    `json:"difficulty"`
+	Theme      string `json:"theme,omitempty"`
+}
+
+// GenerateClues generates clue candidates for one answer.
+// POST /admin/v1/clues
+func (h *AdminHandler) GenerateClues(w http.ResponseWriter, r *http.Request) {
+	if h.orchestrator == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "generator not configured")
+		return
+	}
+
+	var req ClueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Answer == "" {
+		writeError(w, r, http.StatusBadRequest, "answer is required")
+		return
+	}
+
+	var thm *theme.Theme
+	if req.Theme != "" {
+		thm = &theme.Theme{Title: req.Theme}
+	}
+
+	clues, err := h.orchestrator.GenerateClues(r.Context(), req.Answer, thm, req.Difficulty)
+	if err != nil {
+		var genErr *generator.GenerationError
+		if errors.As(err, &genErr) && genErr.Phase == "validate" {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, clues)
+}
+
+// RethemeRequest is the request body for regenerating a puzzle's theme.
+type RethemeRequest struct {
+	Difficulty   int      `json:"difficulty,omitempty"`
+	AvoidThemes  []string `json:"avoid_themes,omitempty"`
+	PreferTopics []string `json:"prefer_topics,omitempty"`
+}
+
+// RethemePuzzle regenerates a puzzle's theme (title, tags, description) without touching the grid.
+// POST /admin/v1/puzzles/{id}/retheme
+func (h *AdminHandler) RethemePuzzle(w http.ResponseWriter, r *http.Request) {
+	if h.orchestrator == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "generator not configured")
+		return
+	}
+
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	var req RethemeRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			writeError(w, r, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
+	if err == store.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
+		return
+	}
+
+	updated, err := h.orchestrator.RethemeOnly(r.Context(), puzzle, theme.ThemeConstraints{
+		AvoidThemes:  append(req.AvoidThemes, puzzle.Title),
+		PreferTopics: req.PreferTopics,
+		Difficulty:   req.Difficulty,
+	})
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.store.Puzzles().Store(r.Context(), updated); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to store puzzle")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, updated)
+}
+
+// RescorePuzzle runs QA scoring again on a stored puzzle, against the answers
+// of the puzzles dated within the scorer's freshness window before it, and
+// returns the fresh score. With ?update_report=true, the draft of the same ID
+// gets the fresh clue and freshness scores and flags; its fill score is kept,
+// as it depends on the solver run.
+// POST /admin/v1/puzzles/{id}/rescore
+func (h *AdminHandler) RescorePuzzle(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
+	if err == store.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
+		return
+	}
+
+	scorer := h.scorer(puzzle.Language)
+	if scorer == nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("unsupported language %q", puzzle.Language))
 		return
 	}
 
-	writeJSON(w, http.StatusOK, result)
+	var recent []string
+	if date, err := time.Parse("2006-01-02", puzzle.Date); err == nil && scorer.Config().FreshnessWindow > 0 {
+		from := date.AddDate(0, 0, -scorer.Config().FreshnessWindow).Format("2006-01-02")
+		to := date.AddDate(0, 0, -1).Format("2006-01-02")
+		recent, err = h.store.Puzzles().RecentAnswers(r.Context(), puzzle.Language, from, to)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "failed to fetch recent answers")
+			return
+		}
+	}
+
+	score := scorer.ScorePuzzle(qa.PuzzleInput{Puzzle: puzzle, RecentAnswers: recent})
+
+	if r.URL.Query().Get("update_report") == "true" {
+		draft, err := h.store.Drafts().Get(r.Context(), id)
+		if err != nil && err != store.ErrNotFound {
+			writeError(w, r, http.StatusInternalServerError, "failed to fetch draft")
+			return
+		}
+		if draft != nil {
+			if draft.Report == nil {
+				draft.Report = &domain.DraftReport{}
+			}
+			draft.Report.ClueScore = int(score.Components["clues"] * 100)
+			draft.Report.FreshnessScore = int(score.Components["freshness"] * 100)
+			// Flags raised during generation, like PLACEHOLDER_CLUE, are kept
+			for _, flag := range score.Flags {
+				if !slices.Contains(draft.Report.RiskFlags, flag.Code) {
+					draft.Report.RiskFlags = append(draft.Report.RiskFlags, flag.Code)
+				}
+			}
+			draft.UpdatedAt = time.Now()
+			if err := h.store.Drafts().Store(r.Context(), draft); err != nil {
+				writeError(w, r, http.StatusInternalServerError, "failed to store draft")
+				return
+			}
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, score)
+}
+
+// scorer returns a QA scorer for the language's pack, using the generator's
+// scoring config when there is a generator. It returns nil for languages
+// without a language pack.
+func (h *AdminHandler) scorer(language string) *qa.Scorer {
+	pack, ok := languagepack.DefaultRegistry().Get(language)
+	if !ok {
+		return nil
+	}
+	config := qa.DefaultScorerConfig()
+	if h.orchestrator != nil {
+		config = h.orchestrator.Scorer().Config()
+	}
+	return qa.NewScorer(pack, config)
 }
 
 // StorePuzzle stores a puzzle (create or update).
@@ -87,27 +373,36 @@ func (h *AdminHandler) GeneratePuzzle(w http.ResponseWriter, r *http.Request) {
 func (h *AdminHandler) StorePuzzle(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "failed to read request body")
+		writeError(w, r, http.StatusBadRequest, "failed to read request body")
 		return
 	}
 
 	var puzzle domain.Puzzle
 	if err := json.Unmarshal(body, &puzzle); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid puzzle JSON")
+		writeError(w, r, http.StatusBadRequest, "invalid puzzle JSON")
 		return
 	}
 
 	if puzzle.ID == "" {
-		writeError(w, http.StatusBadRequest, "puzzle ID is required")
+		writeError(w, r, http.StatusBadRequest, "puzzle ID is required")
+		return
+	}
+	if errs := validate.ValidateAlphabet(&puzzle, alphabet(puzzle.Language)); len(errs) > 0 {
+		writeError(w, r, http.StatusBadRequest, errs.Error())
 		return
 	}
 
 	if err := h.store.Puzzles().Store(r.Context(), &puzzle); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		if errors.Is(err, store.ErrDuplicateDate) {
+			writeError(w, r, http.StatusConflict,
+				"a puzzle already exists for language "+puzzle.Language+" on "+puzzle.Date)
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
+	writeJSON(w, r, http.StatusOK, map[string]string{
 		"id":     puzzle.ID,
 		"status": "stored",
 	})
@@ -118,7 +413,7 @@ func (h *AdminHandler) StorePuzzle(w http.ResponseWriter, r *http.Request) {
 func (h *AdminHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		writeError(w, http.StatusBadRequest, "missing puzzle id")
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
 		return
 	}
 
@@ -126,29 +421,144 @@ func (h *AdminHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		Status string `json:"status"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	status := domain.PuzzleStatus(req.Status)
 	switch status {
-	case domain.StatusDraft, domain.StatusPublished, domain.StatusArchived:
+	case domain.StatusDraft, domain.StatusPublished, domain.StatusArchived, domain.StatusScheduled:
 		// Valid
 	default:
-		writeError(w, http.StatusBadRequest, "invalid status")
+		writeError(w, r, http.StatusBadRequest, "invalid status")
 		return
 	}
 
 	if err := h.store.Puzzles().UpdateStatus(r.Context(), id, status); err != nil {
 		if err == store.ErrNotFound {
-			writeError(w, http.StatusNotFound, "puzzle not found")
+			writeError(w, r, http.StatusNotFound, "puzzle not found")
 			return
 		}
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]string{
+		"id":     id,
+		"status": string(status),
+	})
+}
+
+// patchableFields are the puzzle fields PatchPuzzle may update.
+var patchableFields = []string{"title", "author", "difficulty", "metadata"}
+
+// PatchPuzzle updates a puzzle's title, author, difficulty or metadata,
+// leaving fields absent from the body untouched; metadata fields are merged
+// the same way. Grid and clues can only be replaced by storing the full puzzle.
+// PATCH /admin/v1/puzzles/{id}
+func (h *AdminHandler) PatchPuzzle(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	var rejected []string
+	for field := range fields {
+		if !slices.Contains(patchableFields, field) {
+			rejected = append(rejected, field)
+		}
+	}
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		msg := fmt.Sprintf("cannot patch %s; patchable fields are %s",
+			strings.Join(rejected, ", "), strings.Join(patchableFields, ", "))
+		if slices.Contains(rejected, "grid") || slices.Contains(rejected, "clues") {
+			msg += " (store the full puzzle to change the grid or clues)"
+		}
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
+	if err == store.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
+	// Unmarshaling onto the stored puzzle only sets the fields present. Tag
+	// slices are copied first, as decoding reuses their backing arrays, which
+	// the memory store shares with the puzzle it returned.
+	puzzle.Metadata.ThemeTags = slices.Clone(puzzle.Metadata.ThemeTags)
+	puzzle.Metadata.ReferenceTags = slices.Clone(puzzle.Metadata.ReferenceTags)
+	if err := json.Unmarshal(body, puzzle); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if _, ok := fields["difficulty"]; ok && (puzzle.Difficulty < 1 || puzzle.Difficulty > 5) {
+		writeError(w, r, http.StatusBadRequest, "difficulty must be between 1 and 5")
+		return
+	}
+
+	if err := h.store.Puzzles().Store(r.Context(), puzzle); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to store puzzle")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, puzzle)
+}
+
+// PublishPuzzle publishes a puzzle, or schedules it if its date is in the future.
+// Scheduled puzzles are promoted to published by PublishDuePuzzles once their date arrives.
+// POST /admin/v1/puzzles/{id}/publish
+func (h *AdminHandler) PublishPuzzle(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
+	if err == store.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
+		return
+	}
+
+	if puzzle.Status == domain.StatusArchived {
+		writeError(w, r, http.StatusConflict, "archived puzzles cannot be published; set them back to draft first")
+		return
+	}
+
+	status := domain.StatusPublished
+	if puzzle.Date > time.Now().Format("2006-01-02") {
+		status = domain.StatusScheduled
+	}
+
+	if err := h.store.Puzzles().UpdateStatus(r.Context(), id, status); err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]string{
 		"id":     id,
 		"status": string(status),
 	})
@@ -159,21 +569,63 @@ func (h *AdminHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 func (h *AdminHandler) GetPuzzle(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		writeError(w, http.StatusBadRequest, "missing puzzle id")
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
+	if err == store.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, puzzle)
+}
+
+// GetAnswerKey returns a puzzle's answer key as CSV, one row per clue,
+// across clues first, each direction ordered by number.
+// GET /admin/v1/puzzles/{id}/answers.csv
+func (h *AdminHandler) GetAnswerKey(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
 		return
 	}
 
 	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
 	if err == store.ErrNotFound {
-		writeError(w, http.StatusNotFound, "puzzle not found")
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
 		return
 	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to fetch puzzle")
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, puzzle)
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+puzzle.ID+`-answers.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"number", "direction", "answer", "clue", "difficulty"})
+	for _, clues := range [][]domain.Clue{puzzle.Clues.Across, puzzle.Clues.Down} {
+		sorted := append([]domain.Clue(nil), clues...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+		for _, c := range sorted {
+			cw.Write([]string{
+				strconv.Itoa(c.Number),
+				string(c.Direction),
+				c.Answer,
+				c.Prompt,
+				strconv.Itoa(c.Difficulty),
+			})
+		}
+	}
+	cw.Flush()
 }
 
 // ListPuzzles returns all puzzles with optional filtering.
@@ -203,7 +655,7 @@ func (h *AdminHandler) ListPuzzles(w http.ResponseWriter, r *http.Request) {
 
 	puzzles, err := h.store.Puzzles().List(r.Context(), filter)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to list puzzles")
+		writeError(w, r, http.StatusInternalServerError, "failed to list puzzles")
 		return
 	}
 
@@ -211,41 +663,466 @@ func (h *AdminHandler) ListPuzzles(w http.ResponseWriter, r *http.Request) {
 		puzzles = []*store.PuzzleSummary{}
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	writeJSON(w, r, http.StatusOK, map[string]interface{}{
 		"puzzles": puzzles,
 		"count":   len(puzzles),
 	})
 }
 
+// exportPageSize is the number of puzzles listed per store query during export.
+const exportPageSize = 100
+
+// ExportPuzzles streams a ZIP archive with one <date>-<id>.json file per
+// puzzle matching the language and status filters. Puzzles are listed page by
+// page and written as they are fetched, so the archive is never held in memory.
+// GET /admin/v1/export?language=fr&status=published
+func (h *AdminHandler) ExportPuzzles(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := store.PuzzleFilter{
+		Language: q.Get("language"),
+		Status:   domain.PuzzleStatus(q.Get("status")),
+		Limit:    exportPageSize,
+	}
+
+	// Fetch the first page before committing to a ZIP response
+	page, err := h.store.Puzzles().List(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to list puzzles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="puzzles-export.zip"`)
+	w.WriteHeader(http.StatusOK)
+
+	// Errors past this point can only truncate the archive; the missing
+	// central directory makes the ZIP unreadable, which the client detects.
+	zw := zip.NewWriter(w)
+	for len(page) > 0 {
+		for _, summary := range page {
+			puzzle, err := h.store.Puzzles().Get(r.Context(), summary.ID)
+			if err != nil {
+				return
+			}
+			f, err := zw.Create(puzzle.Date + "-" + puzzle.ID + ".json")
+			if err != nil {
+				return
+			}
+			if err := json.NewEncoder(f).Encode(puzzle); err != nil {
+				return
+			}
+		}
+
+		if len(page) < exportPageSize {
+			break
+		}
+		filter.Offset += len(page)
+		if page, err = h.store.Puzzles().List(r.Context(), filter); err != nil {
+			return
+		}
+	}
+	zw.Close()
+}
+
+// ImportResult reports what happened to one file of an import archive.
+type ImportResult struct {
+	File   string `json:"file"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"` // imported, skipped, invalid
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportPuzzles restores puzzles from a ZIP archive in the export format.
+// Every .json file is checked against the puzzle schema first; if any is
+// invalid nothing is stored.
+// Puzzles whose ID already exists are skipped unless ?overwrite=true. The
+// remaining puzzles are stored in a single batch.
+// POST /admin/v1/import?overwrite=true
+func (h *AdminHandler) ImportPuzzles(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid ZIP archive")
+		return
+	}
+
+	overwrite := r.URL.Query().Get("overwrite") == "true"
+
+	var (
+		results []ImportResult
+		batch   []*domain.Puzzle
+		invalid bool
+		files   = make(map[string]string) // File of each puzzle ID read so far
+	)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.HasSuffix(f.Name, ".json") {
+			continue
+		}
+
+		result := ImportResult{File: f.Name}
+		puzzle, err := readImportFile(f)
+		if err != nil {
+			result.Status = "invalid"
+			result.Error = err.Error()
+			invalid = true
+			results = append(results, result)
+			continue
+		}
+		result.ID = puzzle.ID
+
+		if first, ok := files[puzzle.ID]; ok {
+			result.Status = "invalid"
+			result.Error = fmt.Sprintf("duplicate puzzle ID %q, also in %s", puzzle.ID, first)
+			invalid = true
+			results = append(results, result)
+			continue
+		}
+		files[puzzle.ID] = f.Name
+
+		if !overwrite {
+			_, err := h.store.Puzzles().Get(r.Context(), puzzle.ID)
+			if err == nil {
+				result.Status = "skipped"
+				results = append(results, result)
+				continue
+			}
+			if !errors.Is(err, store.ErrNotFound) {
+				writeError(w, r, http.StatusInternalServerError, "failed to check existing puzzles")
+				return
+			}
+		}
+
+		result.Status = "imported"
+		results = append(results, result)
+		batch = append(batch, puzzle)
+	}
+
+	if results == nil {
+		results = []ImportResult{}
+	}
+
+	if invalid {
+		writeJSON(w, r, http.StatusBadRequest, map[string]interface{}{
+			"error":   "archive contains invalid puzzles; nothing was imported",
+			"results": results,
+		})
+		return
+	}
+
+	if err := h.store.Puzzles().StoreBatch(r.Context(), batch); err != nil {
+		if errors.Is(err, store.ErrDuplicateDate) {
+			writeError(w, r, http.StatusConflict, err.Error())
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]interface{}{
+		"results":  results,
+		"imported": len(batch),
+	})
+}
+
+// readImportFile decodes and validates one puzzle file from an import archive.
+func readImportFile(f *zip.File) (*domain.Puzzle, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	// Schema and alphabet only: semantic checks such as the 10-16 grid size
+	// would reject trimmed puzzles the generator itself produces.
+	if errs := validate.ValidatePuzzleJSON(data); len(errs) > 0 {
+		return nil, errs
+	}
+
+	var puzzle domain.Puzzle
+	if err := json.Unmarshal(data, &puzzle); err != nil {
+		return nil, err
+	}
+	if errs := validate.ValidateAlphabet(&puzzle, alphabet(puzzle.Language)); len(errs) > 0 {
+		return nil, errs
+	}
+	return &puzzle, nil
+}
+
+// alphabet returns the letters the language's pack allows in solutions, or
+// nil (A-Z) for languages without a pack.
+func alphabet(language string) []rune {
+	if pack, ok := languagepack.DefaultRegistry().Get(language); ok {
+		return pack.Alphabet()
+	}
+	return nil
+}
+
 // DeletePuzzle deletes a puzzle by ID.
 // DELETE /admin/v1/puzzles/{id}
 func (h *AdminHandler) DeletePuzzle(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		writeError(w, http.StatusBadRequest, "missing puzzle id")
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
 		return
 	}
 
 	// First check if puzzle exists
 	_, err := h.store.Puzzles().Get(r.Context(), id)
 	if err == store.ErrNotFound {
-		writeError(w, http.StatusNotFound, "puzzle not found")
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
 		return
 	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to check puzzle")
+		writeError(w, r, http.StatusInternalServerError, "failed to check puzzle")
 		return
 	}
 
 	// Note: We don't actually have a Delete method in the store interface
 	// For now, we archive instead
 	if err := h.store.Puzzles().UpdateStatus(r.Context(), id, domain.StatusArchived); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
+	writeJSON(w, r, http.StatusOK, map[string]string{
 		"id":     id,
 		"status": "archived",
 	})
 }
+
+// MatchLexicon returns base lexicon words matching a pattern, most frequent first.
+// GET /admin/v1/lexicon/match?pattern=C.AT&lang=fr&limit=50
+func (h *AdminHandler) MatchLexicon(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	pattern := strings.ToUpper(q.Get("pattern"))
+	if pattern == "" {
+		writeError(w, r, http.StatusBadRequest, "pattern is required")
+		return
+	}
+	for _, c := range pattern {
+		if c != '.' && (c < 'A' || c > 'Z') {
+			writeError(w, r, http.StatusBadRequest, "pattern must contain only letters and dots")
+			return
+		}
+	}
+
+	lang := q.Get("lang")
+	if lang == "" {
+		lang = "fr"
+	}
+	lexicon, ok := h.lexicons[lang]
+	if !ok || lexicon == nil {
+		writeError(w, r, http.StatusNotFound, "no lexicon configured for language "+lang)
+		return
+	}
+
+	limit := 50
+	if l := q.Get("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 && n <= 500 {
+			limit = n
+		}
+	}
+
+	words := lexicon.Match(pattern)
+	sort.SliceStable(words, func(i, j int) bool {
+		ei, _ := lexicon.GetEntry(words[i])
+		ej, _ := lexicon.GetEntry(words[j])
+		if ei.Frequency != ej.Frequency {
+			return ei.Frequency > ej.Frequency
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > limit {
+		words = words[:limit]
+	}
+	if words == nil {
+		words = []string{}
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]interface{}{
+		"pattern": pattern,
+		"words":   words,
+		"count":   len(words),
+	})
+}
+
+// TemplateAnalyzeRequest is the request body for template analysis.
+type TemplateAnalyzeRequest struct {
+	Template []string `json:"template"` // One string per row: '.' letter cell, '#' block
+	Language string   `json:"language,omitempty"`
+}
+
+// TemplateAnalyzeResponse reports a template's bottlenecks and whether a quick
+// fill attempt succeeded.
+type TemplateAnalyzeResponse struct {
+	Analysis   *fill.TemplateAnalysis `json:"analysis"`
+	Solvable   bool                   `json:"solvable"`
+	Backtracks int                    `json:"backtracks"`
+}
+
+// templatePreviewBacktracks bounds the fill attempt of a template preview, so
+// a "not solvable" answer may only mean "not solved quickly".
+const templatePreviewBacktracks = 2000
+
+// maxTemplateSize is the largest template side accepted for analysis.
+const maxTemplateSize = 16
+
+// AnalyzeTemplate reports how fillable a grid template is with the base
+// lexicon: per-slot bottlenecks plus a quick, budgeted solver attempt.
+// POST /admin/v1/template/analyze
+func (h *AdminHandler) AnalyzeTemplate(w http.ResponseWriter, r *http.Request) {
+	var req TemplateAnalyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	template, err := fill.ParseTemplate(req.Template)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(template) > maxTemplateSize || len(template[0]) > maxTemplateSize {
+		writeError(w, r, http.StatusBadRequest, "template exceeds "+strconv.Itoa(maxTemplateSize)+" cells per side")
+		return
+	}
+
+	lang := req.Language
+	if lang == "" {
+		lang = "fr"
+	}
+	lexicon, ok := h.lexicons[lang]
+	if !ok || lexicon == nil {
+		writeError(w, r, http.StatusNotFound, "no lexicon configured for language "+lang)
+		return
+	}
+
+	resp := TemplateAnalyzeResponse{Analysis: fill.AnalyzeTemplate(template, lexicon)}
+	if resp.Analysis.Fillability > 0 { // A slot without candidates cannot be filled
+		solver := fill.NewSolver(fill.SolverConfig{
+			Lexicon:      lexicon,
+			Scorer:       fill.NewDefaultScorer(lexicon),
+			Seed:         1,
+			MaxBacktrack: templatePreviewBacktracks,
+			Preprocess:   true,
+		})
+		result, err := solver.SolveContext(r.Context(), template)
+		resp.Solvable = err == nil
+		if result != nil {
+			resp.Backtracks = result.Backtrack
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// SuggestRequest is the request body for word suggestions on a partly filled grid.
+type SuggestRequest struct {
+	Grid      []string         `json:"grid"` // One string per row: '.' empty cell, '#' block, A-Z placed letter
+	Start     domain.Position  `json:"start"`
+	Direction domain.Direction `json:"direction"`
+	Length    int              `json:"length"`
+	Language  string           `json:"language,omitempty"`
+	Limit     int              `json:"limit,omitempty"` // Maximum words returned (default 50, max 500)
+}
+
+// SuggestWords returns base lexicon words for one slot of a grid being filled
+// by hand: words matching the slot's pattern that leave every open crossing
+// with at least one possible word, most frequent first.
+// POST /admin/v1/suggest
+func (h *AdminHandler) SuggestWords(w http.ResponseWriter, r *http.Request) {
+	var req SuggestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Direction != domain.DirectionAcross && req.Direction != domain.DirectionDown {
+		writeError(w, r, http.StatusBadRequest, "direction must be across or down")
+		return
+	}
+	if req.Length < 2 {
+		writeError(w, r, http.StatusBadRequest, "length must be at least 2")
+		return
+	}
+
+	rows := make([]string, len(req.Grid))
+	for i, row := range req.Grid {
+		rows[i] = strings.ToUpper(row)
+	}
+	grid, err := fill.ParsePartialGrid(rows)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(grid) > maxTemplateSize || len(grid[0]) > maxTemplateSize {
+		writeError(w, r, http.StatusBadRequest, "grid exceeds "+strconv.Itoa(maxTemplateSize)+" cells per side")
+		return
+	}
+
+	lang := req.Language
+	if lang == "" {
+		lang = "fr"
+	}
+	lexicon, ok := h.lexicons[lang]
+	if !ok || lexicon == nil {
+		writeError(w, r, http.StatusNotFound, "no lexicon configured for language "+lang)
+		return
+	}
+
+	words, err := fill.Suggest(grid, req.Start, req.Direction, req.Length, lexicon)
+	if errors.Is(err, fill.ErrSlotNotFound) {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	limit := 50
+	if req.Limit > 0 && req.Limit <= 500 {
+		limit = req.Limit
+	}
+	if len(words) > limit {
+		words = words[:limit]
+	}
+	if words == nil {
+		words = []string{}
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]interface{}{
+		"words": words,
+		"count": len(words),
+	})
+}
+
+// GetStats returns aggregate puzzle and draft statistics.
+// GET /admin/v1/stats
+func (h *AdminHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	puzzles, err := h.store.Puzzles().Stats(r.Context())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to compute puzzle stats")
+		return
+	}
+
+	drafts, err := h.store.Drafts().Stats(r.Context())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to compute draft stats")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]interface{}{
+		"puzzles": puzzles,
+		"drafts":  drafts,
+	})
+}
@@ -1,14 +1,29 @@
 package api
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/generator"
+	"lesmotsdatche/internal/generator/fill"
+	"lesmotsdatche/internal/generator/languagepack"
+	"lesmotsdatche/internal/generator/llm"
+	"lesmotsdatche/internal/generator/qa"
 	"lesmotsdatche/internal/store"
 )
 
@@ -64,6 +79,60 @@ func TestAdminHandler_StorePuzzle_MissingID(t *testing.T) {
 	}
 }
 
+func TestAdminHandler_StorePuzzle_Alphabet(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	puzzle := createTestPuzzle("accented", "2026-01-15", domain.StatusDraft)
+	puzzle.Grid[0][0].Solution = "É" // French grids use unaccented A-Z
+
+	body, _ := json.Marshal(puzzle)
+	rec := httptest.NewRecorder()
+	h.StorePuzzle(rec, httptest.NewRequest("POST", "/admin/v1/puzzles", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a solution outside the alphabet, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := s.Puzzles().Get(context.Background(), "accented"); err == nil {
+		t.Error("puzzle outside the alphabet should not be stored")
+	}
+}
+
+func TestAdminHandler_StorePuzzle_DuplicateDate(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	existing := &domain.Puzzle{
+		ID:       "puzzle-1",
+		Language: "fr",
+		Date:     "2026-01-15",
+		Status:   domain.StatusDraft,
+	}
+	if err := s.Puzzles().Store(context.Background(), existing); err != nil {
+		t.Fatalf("failed to store first puzzle: %v", err)
+	}
+
+	duplicate := &domain.Puzzle{
+		ID:       "puzzle-2",
+		Language: "fr",
+		Date:     "2026-01-15",
+		Status:   domain.StatusDraft,
+	}
+	if err := s.Puzzles().Store(context.Background(), duplicate); !errors.Is(err, store.ErrDuplicateDate) {
+		t.Errorf("expected ErrDuplicateDate from store, got %v", err)
+	}
+
+	body, _ := json.Marshal(duplicate)
+	req := httptest.NewRequest("POST", "/admin/v1/puzzles", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h.StorePuzzle(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestAdminHandler_UpdateStatus(t *testing.T) {
 	s := store.NewMemoryStore()
 	h := NewAdminHandler(s, nil)
@@ -94,6 +163,157 @@ func TestAdminHandler_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestAdminHandler_RescorePuzzle(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+	ctx := context.Background()
+
+	earlier := createTestPuzzle("test-0", "2026-01-10", domain.StatusPublished)
+	s.Puzzles().Store(ctx, earlier)
+	puzzle := createTestPuzzle("test-1", "2026-01-15", domain.StatusDraft)
+	puzzle.Clues.Across[0].Length = 2 // Crossed by no down entry
+	s.Puzzles().Store(ctx, puzzle)
+	s.Drafts().Store(ctx, &store.Draft{
+		ID:       "test-1",
+		Language: "fr",
+		Puzzle:   *puzzle,
+		Report:   &domain.DraftReport{FillScore: 90, ClueScore: 10, LLMTraceRef: "gen-1", RiskFlags: []string{"PLACEHOLDER_CLUE"}},
+		Status:   "draft",
+	})
+
+	req := httptest.NewRequest("POST", "/admin/v1/puzzles/test-1/rescore?update_report=true", nil)
+	req.SetPathValue("id", "test-1")
+	rec := httptest.NewRecorder()
+	h.RescorePuzzle(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var score qa.Score
+	if err := json.Unmarshal(rec.Body.Bytes(), &score); err != nil {
+		t.Fatalf("failed to decode score: %v", err)
+	}
+	for _, component := range []string{"fill", "clues", "freshness", "structure"} {
+		if _, ok := score.Components[component]; !ok {
+			t.Errorf("expected %s component, got %v", component, score.Components)
+		}
+	}
+	if score.Components["freshness"] >= 1 {
+		t.Errorf("expected AB repeated from test-0 to lower freshness, got %v", score.Components["freshness"])
+	}
+	codes := map[string]bool{}
+	for _, flag := range score.Flags {
+		codes[flag.Code] = true
+	}
+	if !codes["ORPHAN_ENTRY"] {
+		t.Errorf("expected ORPHAN_ENTRY flag, got %+v", score.Flags)
+	}
+
+	draft, _ := s.Drafts().Get(ctx, "test-1")
+	if draft.Report.ClueScore != int(score.Components["clues"]*100) || draft.Report.FillScore != 90 || draft.Report.LLMTraceRef != "gen-1" {
+		t.Errorf("expected clue score updated and fill score kept, got %+v", draft.Report)
+	}
+	if !slices.Contains(draft.Report.RiskFlags, "ORPHAN_ENTRY") {
+		t.Errorf("expected report flags updated, got %v", draft.Report.RiskFlags)
+	}
+	if !slices.Contains(draft.Report.RiskFlags, "PLACEHOLDER_CLUE") {
+		t.Errorf("expected generation flags kept, got %v", draft.Report.RiskFlags)
+	}
+
+	req = httptest.NewRequest("POST", "/admin/v1/puzzles/missing/rescore", nil)
+	req.SetPathValue("id", "missing")
+	rec = httptest.NewRecorder()
+	h.RescorePuzzle(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown puzzle, got %d", rec.Code)
+	}
+}
+
+func TestAdminHandler_RescorePuzzle_Language(t *testing.T) {
+	s := store.NewMemoryStore()
+	orch := generator.NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, generator.DefaultConfig())
+	h := NewAdminHandler(s, orch)
+
+	// Taboo in English only, so the French generator's scorer would miss it
+	puzzle := createTestPuzzle("en-1", "2026-01-15", domain.StatusDraft)
+	puzzle.Language = "en"
+	puzzle.Grid = [][]domain.Cell{{
+		{Type: domain.CellTypeLetter, Solution: "S"}, {Type: domain.CellTypeLetter, Solution: "H"},
+		{Type: domain.CellTypeLetter, Solution: "I"}, {Type: domain.CellTypeLetter, Solution: "T"},
+	}}
+	puzzle.Clues.Across = []domain.Clue{{Number: 1, Answer: "SHIT", Direction: domain.DirectionAcross, Length: 4}}
+	s.Puzzles().Store(context.Background(), puzzle)
+
+	req := httptest.NewRequest("POST", "/admin/v1/puzzles/en-1/rescore", nil)
+	req.SetPathValue("id", "en-1")
+	rec := httptest.NewRecorder()
+	h.RescorePuzzle(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var score qa.Score
+	json.Unmarshal(rec.Body.Bytes(), &score)
+	if !slices.ContainsFunc(score.Flags, func(f qa.Flag) bool { return f.Code == "TABOO_ANSWER" }) {
+		t.Errorf("expected the English pack to flag the answer, got %+v", score.Flags)
+	}
+}
+
+func TestAdminHandler_PatchPuzzle(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	puzzle := createTestPuzzle("test-1", "2026-01-15", domain.StatusDraft)
+	puzzle.Metadata = domain.Metadata{ThemeTags: []string{"mer"}, Notes: "Un thème marin"}
+	s.Puzzles().Store(context.Background(), puzzle)
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", "/admin/v1/puzzles/test-1", bytes.NewReader([]byte(body)))
+		req.SetPathValue("id", "test-1")
+		rec := httptest.NewRecorder()
+		h.PatchPuzzle(rec, req)
+		return rec
+	}
+
+	rec := patch(`{"title": "Nouveau titre", "metadata": {"theme_tags": ["ocean"]}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	updated, _ := s.Puzzles().Get(context.Background(), "test-1")
+	if updated.Title != "Nouveau titre" {
+		t.Errorf("expected title to change, got %q", updated.Title)
+	}
+	if len(updated.Metadata.ThemeTags) != 1 || updated.Metadata.ThemeTags[0] != "ocean" || updated.Metadata.Notes != "Un thème marin" {
+		t.Errorf("expected theme tags replaced and notes kept, got %+v", updated.Metadata)
+	}
+	if updated.Difficulty != puzzle.Difficulty || updated.Status != domain.StatusDraft {
+		t.Errorf("expected difficulty and status untouched, got %d and %q", updated.Difficulty, updated.Status)
+	}
+	gotGrid, _ := json.Marshal(updated.Grid)
+	wantGrid, _ := json.Marshal(puzzle.Grid)
+	if !bytes.Equal(gotGrid, wantGrid) {
+		t.Errorf("expected grid untouched, got %s", gotGrid)
+	}
+
+	for _, body := range []string{
+		`{"grid": []}`,
+		`{"title": "X", "clues": {"across": [], "down": []}}`,
+		`{"status": "published"}`,
+		`{"difficulty": 9, "metadata": {"theme_tags": ["vent"]}}`,
+	} {
+		if rec := patch(body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, rec.Code)
+		}
+	}
+	unchanged, _ := s.Puzzles().Get(context.Background(), "test-1")
+	if unchanged.Title != "Nouveau titre" || unchanged.Difficulty != puzzle.Difficulty || unchanged.Metadata.ThemeTags[0] != "ocean" {
+		t.Errorf("expected rejected patches to leave the puzzle unchanged, got %q, %d and %v",
+			unchanged.Title, unchanged.Difficulty, unchanged.Metadata.ThemeTags)
+	}
+}
+
 func TestAdminHandler_UpdateStatus_InvalidStatus(t *testing.T) {
 	s := store.NewMemoryStore()
 	h := NewAdminHandler(s, nil)
@@ -116,6 +336,85 @@ func TestAdminHandler_UpdateStatus_InvalidStatus(t *testing.T) {
 	}
 }
 
+func TestAdminHandler_PublishPuzzle_FutureDateSchedules(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	puzzle := &domain.Puzzle{
+		ID:       "future-1",
+		Language: "fr",
+		Date:     time.Now().AddDate(0, 0, 7).Format("2006-01-02"),
+		Status:   domain.StatusDraft,
+	}
+	s.Puzzles().Store(context.Background(), puzzle)
+
+	req := httptest.NewRequest("POST", "/admin/v1/puzzles/future-1/publish", nil)
+	req.SetPathValue("id", "future-1")
+	rec := httptest.NewRecorder()
+
+	h.PublishPuzzle(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	p, _ := s.Puzzles().Get(context.Background(), "future-1")
+	if p.Status != domain.StatusScheduled {
+		t.Errorf("expected status 'scheduled', got %q", p.Status)
+	}
+}
+
+func TestAdminHandler_PublishPuzzle_TodayPublishes(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	puzzle := &domain.Puzzle{
+		ID:       "today-1",
+		Language: "fr",
+		Date:     time.Now().Format("2006-01-02"),
+		Status:   domain.StatusDraft,
+	}
+	s.Puzzles().Store(context.Background(), puzzle)
+
+	req := httptest.NewRequest("POST", "/admin/v1/puzzles/today-1/publish", nil)
+	req.SetPathValue("id", "today-1")
+	rec := httptest.NewRecorder()
+
+	h.PublishPuzzle(rec, req)
+
+	p, _ := s.Puzzles().Get(context.Background(), "today-1")
+	if p.Status != domain.StatusPublished {
+		t.Errorf("expected status 'published', got %q", p.Status)
+	}
+}
+
+func TestAdminHandler_PublishPuzzle_Archived(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	puzzle := &domain.Puzzle{
+		ID:       "archived-1",
+		Language: "fr",
+		Date:     time.Now().Format("2006-01-02"),
+		Status:   domain.StatusArchived,
+	}
+	s.Puzzles().Store(context.Background(), puzzle)
+
+	req := httptest.NewRequest("POST", "/admin/v1/puzzles/archived-1/publish", nil)
+	req.SetPathValue("id", "archived-1")
+	rec := httptest.NewRecorder()
+
+	h.PublishPuzzle(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rec.Code)
+	}
+	p, _ := s.Puzzles().Get(context.Background(), "archived-1")
+	if p.Status != domain.StatusArchived {
+		t.Errorf("expected status 'archived', got %q", p.Status)
+	}
+}
+
 func TestAdminHandler_GetPuzzle(t *testing.T) {
 	s := store.NewMemoryStore()
 	h := NewAdminHandler(s, nil)
@@ -257,3 +556,752 @@ func TestAdminHandler_GeneratePuzzle_MissingDate(t *testing.T) {
 		t.Errorf("expected 503, got %d", rec.Code)
 	}
 }
+
+func TestAdminHandler_GeneratePuzzle_Model(t *testing.T) {
+	generate := func(model string) (*llm.MockClient, *httptest.ResponseRecorder) {
+		mock := llm.NewMockClient() // Every call fails; only the requested model matters
+		config := generator.DefaultConfig()
+		config.MaxAttempts = 1
+		orch := generator.NewOrchestrator(
+			llm.NewValidatingClient(mock, llm.DefaultConfig()),
+			languagepack.NewFrenchPack(), nil, config)
+		h := NewAdminHandler(store.NewMemoryStore(), orch).WithModels([]string{"gpt-4o-mini"})
+
+		body, _ := json.Marshal(GenerateRequest{Date: "2026-01-15", Language: "fr", Model: model})
+		rec := httptest.NewRecorder()
+		h.GeneratePuzzle(rec, httptest.NewRequest("POST", "/admin/v1/generate", bytes.NewReader(body)))
+		return mock, rec
+	}
+
+	mock, rec := generate("gpt-4o-mini")
+	if rec.Code == http.StatusBadRequest {
+		t.Fatalf("expected allowed model to be accepted, got 400: %s", rec.Body.String())
+	}
+	if len(mock.Calls) == 0 || mock.Calls[0].Model != "gpt-4o-mini" {
+		t.Errorf("expected LLM calls to use gpt-4o-mini, got %+v", mock.Calls)
+	}
+
+	mock, rec = generate("gpt-5-ultra")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a disallowed model, got %d", rec.Code)
+	}
+	if len(mock.Calls) != 0 {
+		t.Errorf("expected no LLM call for a disallowed model, got %d", len(mock.Calls))
+	}
+
+	mock, _ = generate("")
+	if len(mock.Calls) == 0 || mock.Calls[0].Model != "" {
+		t.Errorf("expected the client default model without override, got %+v", mock.Calls)
+	}
+}
+
+func TestAdminHandler_GeneratePuzzle_Route(t *testing.T) {
+	mock := llm.NewMockClient() // Every call fails; only the routing matters
+	config := generator.DefaultConfig()
+	config.MaxAttempts = 1
+	orch := generator.NewOrchestrator(
+		llm.NewValidatingClient(mock, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, config)
+	server := httptest.NewServer(NewRouter(Config{
+		Store:        store.NewMemoryStore(),
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Orchestrator: orch,
+		Models:       []string{"gpt-4o-mini"},
+	}))
+	defer server.Close()
+
+	post := func(req GenerateRequest) int {
+		body, _ := json.Marshal(req)
+		resp, err := http.Post(server.URL+"/admin/v1/generate", "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := post(GenerateRequest{Date: "2026-01-15", Model: "gpt-5-ultra"}); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a disallowed model, got %d", code)
+	}
+	post(GenerateRequest{Date: "2026-01-15", Model: "gpt-4o-mini"})
+	if len(mock.Calls) == 0 || mock.Calls[0].Model != "gpt-4o-mini" {
+		t.Errorf("expected the routed request to reach the generator with gpt-4o-mini, got %+v", mock.Calls)
+	}
+}
+
+func TestAdminHandler_GeneratePuzzle_IdempotencyKey(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "golden_10x10_llm_responses.json"))
+	if err != nil {
+		t.Fatalf("failed to read scripted responses: %v", err)
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to parse scripted responses: %v", err)
+	}
+	responses := make([]string, len(raw))
+	for i, r := range raw {
+		responses[i] = string(r)
+	}
+
+	config := generator.DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	config.Seed = 20260115
+	config.MaxAttempts = 1
+	mock := llm.NewMockClient(responses...)
+	orch := generator.NewOrchestrator(llm.NewValidatingClient(mock, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+	router := NewRouter(Config{
+		Store:        store.NewMemoryStore(),
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Orchestrator: orch,
+	})
+
+	generate := func(key string, req GenerateRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(req)
+		httpReq := httptest.NewRequest("POST", "/admin/v1/generate", bytes.NewReader(body))
+		httpReq.Header.Set("Idempotency-Key", key)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httpReq)
+		return rec
+	}
+
+	req := GenerateRequest{Date: "2026-01-15", Language: "fr"}
+	first := generate("retry-1", req)
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", first.Code, first.Body.String())
+	}
+	calls := mock.CallCount()
+
+	second := generate("retry-1", req)
+	if second.Code != http.StatusOK {
+		t.Fatalf("expected 200 on replay, got %d: %s", second.Code, second.Body.String())
+	}
+	if mock.CallCount() != calls {
+		t.Errorf("expected no new LLM calls on replay, got %d more", mock.CallCount()-calls)
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("expected Idempotent-Replayed header on replay")
+	}
+	var a, b generator.GenerateResult
+	json.Unmarshal(first.Body.Bytes(), &a)
+	json.Unmarshal(second.Body.Bytes(), &b)
+	if a.GenerationID == "" || a.GenerationID != b.GenerationID {
+		t.Errorf("expected the first result replayed, got generation %q then %q", a.GenerationID, b.GenerationID)
+	}
+
+	req.Date = "2026-01-16"
+	if rec := generate("retry-1", req); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a key reused with another request, got %d", rec.Code)
+	}
+	if mock.CallCount() != calls {
+		t.Errorf("expected no LLM call for a reused key, got %d more", mock.CallCount()-calls)
+	}
+}
+
+func TestAdminHandler_MatchLexicon(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil).WithLexicons(map[string]*fill.MemoryLexicon{
+		"fr": fill.SampleFrenchLexicon(),
+	})
+
+	req := httptest.NewRequest("GET", "/admin/v1/lexicon/match?pattern=C.AT&lang=fr", nil)
+	rec := httptest.NewRecorder()
+
+	h.MatchLexicon(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result struct {
+		Words []string `json:"words"`
+		Count int      `json:"count"`
+	}
+	json.NewDecoder(rec.Body).Decode(&result)
+
+	found := false
+	for _, w := range result.Words {
+		if w == "CHAT" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected CHAT in results, got %v", result.Words)
+	}
+}
+
+func TestAdminHandler_AnalyzeTemplate(t *testing.T) {
+	h := NewAdminHandler(store.NewMemoryStore(), nil).WithLexicons(map[string]*fill.MemoryLexicon{
+		"fr": fill.SampleFrenchLexicon(),
+	})
+
+	analyze := func(rows []string) TemplateAnalyzeResponse {
+		t.Helper()
+		body, _ := json.Marshal(TemplateAnalyzeRequest{Template: rows, Language: "fr"})
+		req := httptest.NewRequest("POST", "/admin/v1/template/analyze", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		h.AnalyzeTemplate(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp TemplateAnalyzeResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		return resp
+	}
+
+	cross := analyze([]string{"..#..", ".....", "#...#", ".....", "..#.."})
+	if !cross.Solvable {
+		t.Errorf("expected cross-5x5 to be solvable, got %+v", cross)
+	}
+	if cross.Analysis.Fillability == 0 {
+		t.Errorf("expected positive fillability, got %+v", cross.Analysis)
+	}
+
+	// No sample word has 14 letters
+	long := analyze([]string{"..............", "#............."})
+	if long.Solvable {
+		t.Error("expected 14-letter template to be unsolvable")
+	}
+	if long.Analysis.Fillability != 0 {
+		t.Errorf("expected zero fillability, got %.2f", long.Analysis.Fillability)
+	}
+	if len(long.Analysis.Bottlenecks) == 0 {
+		t.Fatal("expected bottleneck slots to be listed")
+	}
+	first := long.Analysis.Bottlenecks[0]
+	if first.Candidates != 0 || first.Length != 14 || first.Direction != domain.DirectionAcross {
+		t.Errorf("expected the empty 14-letter across slot first, got %+v", first)
+	}
+
+	req := httptest.NewRequest("POST", "/admin/v1/template/analyze", bytes.NewReader([]byte(`{"template":["..#","...."]}`)))
+	rec := httptest.NewRecorder()
+	h.AnalyzeTemplate(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for ragged template, got %d", rec.Code)
+	}
+}
+
+func TestAdminHandler_SuggestWords(t *testing.T) {
+	lexicon := fill.NewMemoryLexicon()
+	for word, freq := range map[string]float64{
+		"CAT": 0.5, "COT": 0.9, "CAR": 1.0, "CUP": 0.8, // Candidates for C..
+		"ARE": 0.5, "OAK": 0.5, "TEN": 0.5, "URN": 0.5, // Down words; none start with R or P
+	} {
+		lexicon.Add(word, freq, nil)
+	}
+	h := NewAdminHandler(store.NewMemoryStore(), nil).WithLexicons(map[string]*fill.MemoryLexicon{"fr": lexicon})
+
+	suggest := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/admin/v1/suggest", bytes.NewReader([]byte(body)))
+		rec := httptest.NewRecorder()
+		h.SuggestWords(rec, req)
+		return rec
+	}
+
+	rec := suggest(`{"grid": ["c..", "...", "..."], "start": {"row": 0, "col": 0}, "direction": "across", "length": 3}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Words []string `json:"words"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+
+	// CAR and CUP match C.. but leave no down word at their last letter
+	if strings.Join(resp.Words, ",") != "COT,CAT" {
+		t.Errorf("expected COT then CAT by frequency, got %v", resp.Words)
+	}
+	for _, word := range resp.Words {
+		if word[0] != 'C' {
+			t.Errorf("%s does not fit pattern C..", word)
+		}
+		for col := 1; col < 3; col++ {
+			if len(lexicon.Match(string(word[col])+"..")) == 0 {
+				t.Errorf("%s leaves no down word in column %d", word, col)
+			}
+		}
+	}
+
+	rec = suggest(`{"grid": ["c..", "...", "..."], "start": {"row": 0, "col": 1}, "direction": "across", "length": 3}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a position starting no slot, got %d", rec.Code)
+	}
+	rec = suggest(`{"grid": ["c.1", "...", "..."], "start": {"row": 0, "col": 0}, "direction": "across", "length": 3}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid grid, got %d", rec.Code)
+	}
+}
+
+func TestAdminHandler_MatchLexicon_Limit(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil).WithLexicons(map[string]*fill.MemoryLexicon{
+		"fr": fill.SampleFrenchLexicon(),
+	})
+
+	req := httptest.NewRequest("GET", "/admin/v1/lexicon/match?pattern=....&lang=fr&limit=3", nil)
+	rec := httptest.NewRecorder()
+
+	h.MatchLexicon(rec, req)
+
+	var result struct {
+		Words []string `json:"words"`
+		Count int      `json:"count"`
+	}
+	json.NewDecoder(rec.Body).Decode(&result)
+
+	if result.Count != 3 || len(result.Words) != 3 {
+		t.Errorf("expected 3 results, got %d (%v)", result.Count, result.Words)
+	}
+}
+
+func TestAdminHandler_MatchLexicon_UnknownLanguage(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	req := httptest.NewRequest("GET", "/admin/v1/lexicon/match?pattern=C.AT&lang=de", nil)
+	rec := httptest.NewRecorder()
+
+	h.MatchLexicon(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestAdminHandler_GenerateClues(t *testing.T) {
+	mock := llm.NewMockClient(`{
+		"clues": [
+			{"prompt": "Félin domestique", "style": "definition", "difficulty": 1, "notes": ""},
+			{"prompt": "Il miaule", "style": "definition", "difficulty": 2, "notes": ""}
+		]
+	}`)
+	orch := generator.NewOrchestrator(
+		llm.NewValidatingClient(mock, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, generator.DefaultConfig())
+	h := NewAdminHandler(store.NewMemoryStore(), orch)
+
+	body, _ := json.Marshal(ClueRequest{Answer: "chat", Difficulty: 2, Theme: "Animaux"})
+	req := httptest.NewRequest("POST", "/admin/v1/clues", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h.GenerateClues(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result struct {
+		Answer     string `json:"answer"`
+		Candidates []struct {
+			Prompt string `json:"prompt"`
+		} `json:"candidates"`
+	}
+	json.NewDecoder(rec.Body).Decode(&result)
+
+	if result.Answer != "CHAT" {
+		t.Errorf("expected answer 'CHAT', got %q", result.Answer)
+	}
+	if len(result.Candidates) != 2 || result.Candidates[0].Prompt != "Félin domestique" {
+		t.Errorf("unexpected candidates: %+v", result.Candidates)
+	}
+}
+
+func TestAdminHandler_GenerateClues_NoOrchestrator(t *testing.T) {
+	h := NewAdminHandler(store.NewMemoryStore(), nil)
+
+	body, _ := json.Marshal(ClueRequest{Answer: "CHAT"})
+	req := httptest.NewRequest("POST", "/admin/v1/clues", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h.GenerateClues(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without orchestrator, got %d", rec.Code)
+	}
+}
+
+func TestAdminHandler_RethemePuzzle(t *testing.T) {
+	mock := llm.NewMockClient(`{
+		"title": "La Mer",
+		"description": "Un thème marin",
+		"keywords": ["océan", "vagues", "plage"],
+		"seed_words": ["OCEAN", "VAGUE", "PLAGE", "SABLE", "POISSON"],
+		"difficulty": 3
+	}`)
+	orch := generator.NewOrchestrator(
+		llm.NewValidatingClient(mock, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, generator.DefaultConfig())
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, orch)
+
+	original := &domain.Puzzle{
+		ID:       "retheme-1",
+		Language: "fr",
+		Date:     "2026-01-15",
+		Title:    "Ancien titre",
+		Status:   domain.StatusDraft,
+		Grid: [][]domain.Cell{
+			{{Type: domain.CellTypeLetter, Solution: "A"}, {Type: domain.CellTypeLetter, Solution: "B"}},
+		},
+		Clues: domain.Clues{
+			Across: []domain.Clue{{Number: 1, Answer: "AB", Prompt: "Début", Direction: domain.DirectionAcross}},
+		},
+		Metadata: domain.Metadata{ThemeTags: []string{"ANCIEN"}},
+	}
+	s.Puzzles().Store(context.Background(), original)
+
+	req := httptest.NewRequest("POST", "/admin/v1/puzzles/retheme-1/retheme", nil)
+	req.SetPathValue("id", "retheme-1")
+	rec := httptest.NewRecorder()
+
+	h.RethemePuzzle(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	p, _ := s.Puzzles().Get(context.Background(), "retheme-1")
+	if p.Title != "La Mer" {
+		t.Errorf("expected title 'La Mer', got %q", p.Title)
+	}
+	if len(p.Metadata.ThemeTags) != 3 || p.Metadata.ThemeTags[0] != "OCEAN" {
+		t.Errorf("expected new theme tags, got %v", p.Metadata.ThemeTags)
+	}
+	if p.Grid[0][0].Solution != "A" || p.Grid[0][1].Solution != "B" {
+		t.Errorf("grid changed: %+v", p.Grid)
+	}
+	if len(p.Clues.Across) != 1 || p.Clues.Across[0].Prompt != "Début" {
+		t.Errorf("clues changed: %+v", p.Clues)
+	}
+}
+
+func TestAdminHandler_GetStats(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	s.Puzzles().Store(context.Background(), &domain.Puzzle{ID: "p1", Language: "fr", Difficulty: 2, Status: domain.StatusPublished})
+	s.Puzzles().Store(context.Background(), &domain.Puzzle{ID: "p2", Language: "fr", Difficulty: 3, Status: domain.StatusDraft})
+
+	req := httptest.NewRequest("GET", "/admin/v1/stats", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetStats(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result struct {
+		Puzzles store.PuzzleStats `json:"puzzles"`
+		Drafts  store.DraftStats  `json:"drafts"`
+	}
+	json.NewDecoder(rec.Body).Decode(&result)
+
+	if result.Puzzles.Total != 2 || result.Puzzles.ByStatus["published"] != 1 || result.Puzzles.ByDifficulty[3] != 1 {
+		t.Errorf("unexpected puzzle stats: %+v", result.Puzzles)
+	}
+}
+
+func TestAdminHandler_GetAnswerKey(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	p := createTestPuzzle("key-1", "2026-01-15", domain.StatusDraft)
+	p.Clues = domain.Clues{
+		Across: []domain.Clue{
+			{Number: 3, Direction: domain.DirectionAcross, Answer: "CHAT", Prompt: "Félin, \"minou\"", Difficulty: 2},
+			{Number: 1, Direction: domain.DirectionAcross, Answer: "AB", Prompt: "Début d'alphabet", Difficulty: 1},
+		},
+		Down: []domain.Clue{
+			{Number: 2, Direction: domain.DirectionDown, Answer: "BA", Prompt: "Baccalauréat", Difficulty: 3},
+		},
+	}
+	s.Puzzles().Store(context.Background(), p)
+
+	req := httptest.NewRequest("GET", "/admin/v1/puzzles/key-1/answers.csv", nil)
+	req.SetPathValue("id", "key-1")
+	rec := httptest.NewRecorder()
+	h.GetAnswerKey(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("expected text/csv, got %q", ct)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	want := [][]string{
+		{"number", "direction", "answer", "clue", "difficulty"},
+		{"1", "across", "AB", "Début d'alphabet", "1"},
+		{"3", "across", "CHAT", "Félin, \"minou\"", "2"},
+		{"2", "down", "BA", "Baccalauréat", "3"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %v", len(want), len(records), records)
+	}
+	for i, row := range want {
+		for j, field := range row {
+			if records[i][j] != field {
+				t.Errorf("row %d column %d: expected %q, got %q", i, j, field, records[i][j])
+			}
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/admin/v1/puzzles/missing/answers.csv", nil)
+	req.SetPathValue("id", "missing")
+	rec = httptest.NewRecorder()
+	h.GetAnswerKey(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown puzzle, got %d", rec.Code)
+	}
+}
+
+func TestAdminHandler_ExportPuzzles(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+	ctx := context.Background()
+
+	want := map[string]bool{}
+	for i, date := range []string{"2026-01-15", "2026-01-16", "2026-01-17"} {
+		p := createTestPuzzle("export-"+string(rune('a'+i)), date, domain.StatusPublished)
+		s.Puzzles().Store(ctx, p)
+		want[p.Date+"-"+p.ID+".json"] = true
+	}
+	s.Puzzles().Store(ctx, createTestPuzzle("export-draft", "2026-01-18", domain.StatusDraft))
+
+	req := httptest.NewRequest("GET", "/admin/v1/export?language=fr&status=published", nil)
+	rec := httptest.NewRecorder()
+	h.ExportPuzzles(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/zip" {
+		t.Errorf("expected application/zip, got %q", ct)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	if len(zr.File) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(zr.File))
+	}
+	for _, f := range zr.File {
+		if !want[f.Name] {
+			t.Errorf("unexpected entry %q", f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		var p domain.Puzzle
+		if err := json.NewDecoder(rc).Decode(&p); err != nil {
+			t.Errorf("%s: invalid JSON: %v", f.Name, err)
+		}
+		rc.Close()
+		if p.Status != domain.StatusPublished {
+			t.Errorf("%s: expected published puzzle, got %s", f.Name, p.Status)
+		}
+	}
+}
+
+func TestAdminHandler_ImportPuzzles(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/golden_10x10_puzzle.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	source := store.NewMemoryStore()
+	ctx := context.Background()
+	ids := []string{"import-a", "import-b", "import-c"}
+	for i, id := range ids {
+		var p domain.Puzzle
+		if err := json.Unmarshal(data, &p); err != nil {
+			t.Fatalf("failed to parse fixture: %v", err)
+		}
+		p.ID = id
+		p.Date = "2026-01-1" + string(rune('5'+i))
+		p.Status = domain.StatusPublished
+		if err := source.Puzzles().Store(ctx, &p); err != nil {
+			t.Fatalf("failed to store: %v", err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	NewAdminHandler(source, nil).ExportPuzzles(rec, httptest.NewRequest("GET", "/admin/v1/export", nil))
+	archive := rec.Body.Bytes()
+
+	target := store.NewMemoryStore()
+	h := NewAdminHandler(target, nil)
+
+	importArchive := func(query string) map[string]interface{} {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ImportPuzzles(rec, httptest.NewRequest("POST", "/admin/v1/import"+query, bytes.NewReader(archive)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		return resp
+	}
+
+	resp := importArchive("")
+	if resp["imported"] != float64(len(ids)) {
+		t.Errorf("expected %d imported, got %v", len(ids), resp["imported"])
+	}
+	for _, id := range ids {
+		want, _ := source.Puzzles().Get(ctx, id)
+		got, err := target.Puzzles().Get(ctx, id)
+		if err != nil {
+			t.Fatalf("puzzle %s not restored: %v", id, err)
+		}
+		if got.Date != want.Date || got.Title != want.Title || len(got.Clues.Across) != len(want.Clues.Across) {
+			t.Errorf("puzzle %s restored with different content", id)
+		}
+	}
+
+	// Existing IDs are skipped by default and replaced with overwrite
+	resp = importArchive("")
+	if resp["imported"] != float64(0) {
+		t.Errorf("expected 0 imported on re-import, got %v", resp["imported"])
+	}
+	for _, r := range resp["results"].([]interface{}) {
+		if status := r.(map[string]interface{})["status"]; status != "skipped" {
+			t.Errorf("expected skipped, got %v", status)
+		}
+	}
+	resp = importArchive("?overwrite=true")
+	if resp["imported"] != float64(len(ids)) {
+		t.Errorf("expected %d imported with overwrite, got %v", len(ids), resp["imported"])
+	}
+}
+
+func TestAdminHandler_ImportPuzzles_InvalidFile(t *testing.T) {
+	s := store.NewMemoryStore()
+	h := NewAdminHandler(s, nil)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("2026-01-15-bad.json")
+	f.Write([]byte(`{"id":"bad"}`))
+	zw.Close()
+
+	rec := httptest.NewRecorder()
+	h.ImportPuzzles(rec, httptest.NewRequest("POST", "/admin/v1/import", &buf))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := s.Puzzles().Get(context.Background(), "bad"); err == nil {
+		t.Error("invalid puzzle should not be stored")
+	}
+}
+
+func TestAdminHandler_ImportPuzzles_Alphabet(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/golden_10x10_puzzle.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var puzzle domain.Puzzle
+	if err := json.Unmarshal(data, &puzzle); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+	for i, row := range puzzle.Grid {
+		for j, cell := range row {
+			if cell.IsLetter() {
+				puzzle.Grid[i][j].Solution = "Σ" // Uppercase, so the schema accepts it
+			}
+		}
+	}
+	data, _ = json.Marshal(puzzle)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("2026-01-15-sigma.json")
+	f.Write(data)
+	zw.Close()
+
+	s := store.NewMemoryStore()
+	rec := httptest.NewRecorder()
+	NewAdminHandler(s, nil).ImportPuzzles(rec, httptest.NewRequest("POST", "/admin/v1/import", &buf))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for solutions outside the alphabet, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if list, _ := s.Puzzles().List(context.Background(), store.PuzzleFilter{}); len(list) != 0 {
+		t.Errorf("expected nothing imported, got %d puzzles", len(list))
+	}
+}
+
+func TestAdminHandler_ImportPuzzles_DuplicateID(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/golden_10x10_puzzle.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"2026-01-15-a.json", "2026-01-16-a.json"} {
+		f, _ := zw.Create(name)
+		f.Write(data)
+	}
+	zw.Close()
+
+	s := store.NewMemoryStore()
+	rec := httptest.NewRecorder()
+	NewAdminHandler(s, nil).ImportPuzzles(rec, httptest.NewRequest("POST", "/admin/v1/import", &buf))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a duplicate ID, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "duplicate puzzle ID") {
+		t.Errorf("expected the duplicate reported, got %s", rec.Body.String())
+	}
+	if list, _ := s.Puzzles().List(context.Background(), store.PuzzleFilter{}); len(list) != 0 {
+		t.Errorf("expected nothing imported, got %d puzzles", len(list))
+	}
+}
+
+// failingGetStore is a store whose puzzle lookups fail.
+type failingGetStore struct{ store.Store }
+
+func (s failingGetStore) Puzzles() store.PuzzleRepository {
+	return failingGetRepository{s.Store.Puzzles()}
+}
+
+type failingGetRepository struct{ store.PuzzleRepository }
+
+func (failingGetRepository) Get(ctx context.Context, id string) (*domain.Puzzle, error) {
+	return nil, errors.New("database is locked")
+}
+
+func TestAdminHandler_ImportPuzzles_StoreError(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/golden_10x10_puzzle.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("2026-01-15-a.json")
+	f.Write(data)
+	zw.Close()
+
+	rec := httptest.NewRecorder()
+	NewAdminHandler(failingGetStore{store.NewMemoryStore()}, nil).
+		ImportPuzzles(rec, httptest.NewRequest("POST", "/admin/v1/import", &buf))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the store fails, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
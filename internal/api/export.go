@@ -0,0 +1,153 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/export"
+	"lesmotsdatche/internal/store"
+)
+
+// exportFormat is a puzzle serialization the export endpoint can negotiate.
+type exportFormat struct {
+	name         string   // Value of ?format=
+	contentTypes []string // Media types that negotiate the format, the first one by default
+	write        func(w io.Writer, p *domain.Puzzle) error
+}
+
+// exportFormats lists the negotiable exports. The first one answers */* and
+// requests without an Accept header. iPUZ is JSON, so it answers
+// application/json; there is no export of the API's own puzzle JSON, which
+// GET /v1/puzzles/{id} serves without solutions.
+var exportFormats = []exportFormat{
+	{name: "ipuz", contentTypes: []string{"application/json", "application/x-ipuz"}, write: export.WriteIPUZ},
+	{name: "puz", contentTypes: []string{"application/x-crossword"}, write: export.WritePUZ},
+}
+
+// ExportPuzzle returns a published puzzle in the format negotiated from the
+// Accept header, or the one named by ?format=, which takes precedence.
+// Unsupported formats get 406 Not Acceptable.
+// GET /v1/puzzles/{id}/export?format=ipuz
+func (h *Handler) ExportPuzzle(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	var (
+		format      *exportFormat
+		contentType string
+	)
+	if name := r.URL.Query().Get("format"); name != "" {
+		format = exportFormatByName(name)
+		if format != nil {
+			contentType = format.contentTypes[0]
+		}
+	} else {
+		format, contentType = negotiateExportFormat(r.Header.Get("Accept"))
+	}
+	if format == nil {
+		writeError(w, r, http.StatusNotAcceptable, "unsupported export format; available: "+availableExportFormats())
+		return
+	}
+
+	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
+	if err == store.ErrNotFound || (err == nil && puzzle.Status != domain.StatusPublished) {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Vary", "Accept")
+	// Exporters validate the whole puzzle before writing, so errors can still
+	// be reported
+	if err := format.write(w, puzzle); err != nil {
+		if errors.Is(err, export.ErrUnsupportedGrid) || errors.Is(err, export.ErrMissingClue) {
+			writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, "failed to export puzzle")
+	}
+}
+
+// exportFormatByName returns the export format with the given ?format= name,
+// or nil.
+func exportFormatByName(name string) *exportFormat {
+	for i := range exportFormats {
+		if strings.EqualFold(exportFormats[i].name, name) {
+			return &exportFormats[i]
+		}
+	}
+	return nil
+}
+
+// negotiateExportFormat picks the export format an Accept header prefers and
+// the media type to answer with: the highest q value wins, and earlier media
+// ranges break ties. It returns nil when no acceptable format is available.
+func negotiateExportFormat(accept string) (*exportFormat, string) {
+	if strings.TrimSpace(accept) == "" {
+		return &exportFormats[0], exportFormats[0].contentTypes[0]
+	}
+
+	var (
+		best        *exportFormat
+		contentType string
+	)
+	bestQ := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, _ := strings.Cut(part, ";")
+		mediaRange = strings.ToLower(strings.TrimSpace(mediaRange))
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.TrimSpace(key) == "q" {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+
+	formats:
+		for i := range exportFormats {
+			for _, ct := range exportFormats[i].contentTypes {
+				if mediaRangeMatches(mediaRange, ct) {
+					best, contentType, bestQ = &exportFormats[i], ct, q
+					break formats
+				}
+			}
+		}
+	}
+	return best, contentType
+}
+
+// mediaRangeMatches reports whether an Accept media range ("type/subtype",
+// "type/*" or "*/*") covers a content type.
+func mediaRangeMatches(mediaRange, contentType string) bool {
+	if mediaRange == "*/*" || mediaRange == contentType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+	return ok && strings.HasPrefix(contentType, prefix+"/")
+}
+
+// availableExportFormats describes the export formats for error messages.
+func availableExportFormats() string {
+	names := make([]string, len(exportFormats))
+	for i, f := range exportFormats {
+		names[i] = fmt.Sprintf("%s (%s)", f.name, strings.Join(f.contentTypes, ", "))
+	}
+	return strings.Join(names, ", ")
+}
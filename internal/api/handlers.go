@@ -5,24 +5,38 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/generator/languagepack"
 	"lesmotsdatche/internal/store"
 )
 
 // Handler holds dependencies for HTTP handlers.
 type Handler struct {
-	store store.Store
+	store     store.Store
+	langPacks *languagepack.Registry
+	logger    *slog.Logger
 }
 
 // NewHandler creates a new Handler with the given store.
 func NewHandler(s store.Store) *Handler {
-	return &Handler{store: s}
+	return &Handler{store: s, langPacks: languagepack.DefaultRegistry(), logger: slog.Default()}
 }
 
-// GetDaily returns the daily puzzle for a language.
+// WithLogger sets the logger for errors that happen after a response has
+// started. A nil logger keeps the default.
+func (h *Handler) WithLogger(logger *slog.Logger) *Handler {
+	if logger != nil {
+		h.logger = logger
+	}
+	return h
+}
+
+// GetDaily returns the daily puzzle for a language, without its solutions.
 // GET /v1/puzzles/daily?language=fr
 func (h *Handler) GetDaily(w http.ResponseWriter, r *http.Request) {
 	language := r.URL.Query().Get("language")
@@ -33,47 +47,99 @@ func (h *Handler) GetDaily(w http.ResponseWriter, r *http.Request) {
 	date := time.Now().Format("2006-01-02")
 	puzzle, err := h.store.Puzzles().GetByDate(r.Context(), language, date)
 	if err == store.ErrNotFound {
-		writeError(w, http.StatusNotFound, "no daily puzzle available")
+		writeError(w, r, http.StatusNotFound, "no daily puzzle available")
 		return
 	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to fetch puzzle")
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
 		return
 	}
 
 	if puzzle.Status != domain.StatusPublished {
-		writeError(w, http.StatusNotFound, "no daily puzzle available")
+		writeError(w, r, http.StatusNotFound, "no daily puzzle available")
+		return
+	}
+
+	writeJSONWithETag(w, r, puzzle.PlayView())
+}
+
+// GetLatest returns the most recently dated published puzzle for a language,
+// without its solutions.
+// GET /v1/puzzles/latest?language=fr
+func (h *Handler) GetLatest(w http.ResponseWriter, r *http.Request) {
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = "fr" // Default to French
+	}
+
+	puzzle, err := h.store.Puzzles().Latest(r.Context(), language)
+	if err == store.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, "no published puzzle available")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
+		return
+	}
+
+	writeJSONWithETag(w, r, puzzle.PlayView())
+}
+
+// GetDates returns the dates that have a published puzzle, for calendar views.
+// GET /v1/puzzles/dates?language=fr&from=2025-01-01&to=2025-12-31
+func (h *Handler) GetDates(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	language := q.Get("language")
+	if language == "" {
+		language = "fr" // Default to French
+	}
+
+	from, to := q.Get("from"), q.Get("to")
+	for _, param := range []struct{ name, value string }{{"from", from}, {"to", to}} {
+		if _, err := time.Parse("2006-01-02", param.value); param.value != "" && err != nil {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid %s date %q, want YYYY-MM-DD", param.name, param.value))
+			return
+		}
+	}
+
+	dates, err := h.store.Puzzles().PublishedDates(r.Context(), language, from, to)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to list puzzle dates")
 		return
 	}
 
-	writeJSONWithETag(w, puzzle)
+	if dates == nil {
+		dates = []string{}
+	}
+
+	writeJSON(w, r, http.StatusOK, dates)
 }
 
-// GetPuzzle returns a specific puzzle by ID.
+// GetPuzzle returns a specific puzzle by ID, without its solutions.
 // GET /v1/puzzles/{id}
 func (h *Handler) GetPuzzle(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
-		writeError(w, http.StatusBadRequest, "missing puzzle id")
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
 		return
 	}
 
 	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
 	if err == store.ErrNotFound {
-		writeError(w, http.StatusNotFound, "puzzle not found")
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
 		return
 	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to fetch puzzle")
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
 		return
 	}
 
 	if puzzle.Status != domain.StatusPublished {
-		writeError(w, http.StatusNotFound, "puzzle not found")
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
 		return
 	}
 
-	writeJSONWithETag(w, puzzle)
+	writeJSONWithETag(w, r, puzzle.PlayView())
 }
 
 // ListPuzzles returns a list of puzzles matching the filter.
@@ -107,7 +173,7 @@ func (h *Handler) ListPuzzles(w http.ResponseWriter, r *http.Request) {
 
 	puzzles, err := h.store.Puzzles().List(r.Context(), filter)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to list puzzles")
+		writeError(w, r, http.StatusInternalServerError, "failed to list puzzles")
 		return
 	}
 
@@ -115,16 +181,149 @@ func (h *Handler) ListPuzzles(w http.ResponseWriter, r *http.Request) {
 		puzzles = []*store.PuzzleSummary{}
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	writeJSON(w, r, http.StatusOK, map[string]interface{}{
 		"puzzles": puzzles,
 		"count":   len(puzzles),
 	})
 }
 
+// CheckRequest is the request body for answer checking.
+type CheckRequest struct {
+	Entries []CheckEntry `json:"entries"`
+}
+
+// CheckEntry is one solver-entered answer, identified by its clue.
+type CheckEntry struct {
+	Number    int              `json:"number"`
+	Direction domain.Direction `json:"direction"`
+	Answer    string           `json:"answer"`
+}
+
+// CheckResult reports whether one entry matches the solution.
+type CheckResult struct {
+	Number    int              `json:"number"`
+	Direction domain.Direction `json:"direction"`
+	Correct   bool             `json:"correct"`
+}
+
+// CheckAnswers checks solver entries against a published puzzle's answers.
+// Entries are normalized with the puzzle's language pack, so "café" matches
+// CAFE; ?strict=true requires the exact stored letters. Given cells always match.
+// POST /v1/puzzles/{id}/check?strict=true
+func (h *Handler) CheckAnswers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	var req CheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	puzzle, err := h.store.Puzzles().Get(r.Context(), id)
+	if err == store.ErrNotFound {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "failed to fetch puzzle")
+		return
+	}
+	if puzzle.Status != domain.StatusPublished {
+		writeError(w, r, http.StatusNotFound, "puzzle not found")
+		return
+	}
+
+	strict := r.URL.Query().Get("strict") == "true"
+	normalize := func(s string) string { return domain.Normalize(s, puzzle.Language) }
+	if pack, ok := h.langPacks.Get(puzzle.Language); ok {
+		normalize = pack.Normalize
+	}
+
+	results := make([]CheckResult, 0, len(req.Entries))
+	correct := 0
+	for _, entry := range req.Entries {
+		clue := findClue(puzzle, entry.Number, entry.Direction)
+		if clue == nil {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("unknown entry %d %s", entry.Number, entry.Direction))
+			return
+		}
+
+		submitted := entry.Answer
+		if !strict {
+			submitted = normalize(submitted)
+		}
+		ok := answerMatches(clue.Answer, submitted, givenPositions(puzzle, clue))
+		if ok {
+			correct++
+		}
+		results = append(results, CheckResult{Number: entry.Number, Direction: entry.Direction, Correct: ok})
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]interface{}{
+		"results": results,
+		"correct": correct,
+		"total":   len(results),
+	})
+}
+
+// findClue returns the clue with the given number and direction, or nil.
+func findClue(p *domain.Puzzle, number int, dir domain.Direction) *domain.Clue {
+	clues := p.Clues.Across
+	if dir == domain.DirectionDown {
+		clues = p.Clues.Down
+	} else if dir != domain.DirectionAcross {
+		return nil
+	}
+	for i := range clues {
+		if clues[i].Number == number {
+			return &clues[i]
+		}
+	}
+	return nil
+}
+
+// givenPositions reports, for each letter of a clue's answer, whether its cell
+// is pre-revealed.
+func givenPositions(p *domain.Puzzle, clue *domain.Clue) []bool {
+	given := make([]bool, clue.Length)
+	for i := range given {
+		row, col := clue.Start.Row, clue.Start.Col+i
+		if clue.Direction == domain.DirectionDown {
+			row, col = clue.Start.Row+i, clue.Start.Col
+		}
+		if row < len(p.Grid) && col < len(p.Grid[row]) {
+			given[i] = p.Grid[row][col].Given
+		}
+	}
+	return given
+}
+
+// answerMatches compares a submitted answer letter by letter, accepting any
+// letter at given positions.
+func answerMatches(expected, submitted string, given []bool) bool {
+	want, got := []rune(expected), []rune(submitted)
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if i < len(given) && given[i] {
+			continue
+		}
+		if want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // HealthCheck returns server health status.
 // GET /health
 func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string]string{
+	writeJSON(w, r, http.StatusOK, map[string]string{
 		"status": "ok",
 		"time":   time.Now().UTC().Format(time.RFC3339),
 	})
@@ -136,20 +335,30 @@ type APIError struct {
 	Message string `json:"message,omitempty"`
 }
 
-func writeError(w http.ResponseWriter, status int, message string) {
-	writeJSON(w, status, APIError{Error: http.StatusText(status), Message: message})
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeJSON(w, r, status, APIError{Error: http.StatusText(status), Message: message})
 }
 
-func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	enc := json.NewEncoder(w)
+	if wantsPrettyJSON(r) {
+		enc.SetIndent("", "  ")
+	}
+	enc.Encode(data)
 }
 
-func writeJSONWithETag(w http.ResponseWriter, data interface{}) {
-	body, err := json.Marshal(data)
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, data interface{}) {
+	var body []byte
+	var err error
+	if wantsPrettyJSON(r) {
+		body, err = json.MarshalIndent(data, "", "  ")
+	} else {
+		body, err = json.Marshal(data)
+	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to encode response")
+		writeError(w, r, http.StatusInternalServerError, "failed to encode response")
 		return
 	}
 
@@ -1,12 +1,15 @@
 package api
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -145,6 +148,126 @@ func TestGetDaily_DraftNotReturned(t *testing.T) {
 	}
 }
 
+func TestGetLatest(t *testing.T) {
+	server, db := setupTestServer(t)
+	ctx := context.Background()
+
+	resp, err := http.Get(server.URL + "/v1/puzzles/latest?language=fr")
+	if err != nil {
+		t.Fatalf("failed to get latest: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404 with no puzzles, got %d", resp.StatusCode)
+	}
+
+	db.Puzzles().Store(ctx, createTestPuzzle("older", "2024-01-10", domain.StatusPublished))
+	db.Puzzles().Store(ctx, createTestPuzzle("newer", "2024-01-15", domain.StatusPublished))
+	db.Puzzles().Store(ctx, createTestPuzzle("upcoming", "2099-01-01", domain.StatusScheduled))
+
+	resp, err = http.Get(server.URL + "/v1/puzzles/latest?language=fr")
+	if err != nil {
+		t.Fatalf("failed to get latest: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var result domain.Puzzle
+	json.NewDecoder(resp.Body).Decode(&result)
+	if result.ID != "newer" {
+		t.Errorf("expected puzzle ID newer, got %s", result.ID)
+	}
+}
+
+func TestGetDates(t *testing.T) {
+	server, db := setupTestServer(t)
+	ctx := context.Background()
+
+	db.Puzzles().Store(ctx, createTestPuzzle("jan", "2025-01-10", domain.StatusPublished))
+	db.Puzzles().Store(ctx, createTestPuzzle("mar", "2025-03-05", domain.StatusPublished))
+	db.Puzzles().Store(ctx, createTestPuzzle("feb", "2025-02-20", domain.StatusPublished))
+	db.Puzzles().Store(ctx, createTestPuzzle("old", "2024-06-01", domain.StatusPublished))
+	db.Puzzles().Store(ctx, createTestPuzzle("draft", "2025-04-01", domain.StatusDraft))
+
+	resp, err := http.Get(server.URL + "/v1/puzzles/dates?language=fr&from=2025-01-01&to=2025-12-31")
+	if err != nil {
+		t.Fatalf("failed to get dates: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var dates []string
+	json.NewDecoder(resp.Body).Decode(&dates)
+	want := []string{"2025-01-10", "2025-02-20", "2025-03-05"}
+	if len(dates) != len(want) {
+		t.Fatalf("expected dates %v, got %v", want, dates)
+	}
+	for i := range want {
+		if dates[i] != want[i] {
+			t.Errorf("expected dates %v, got %v", want, dates)
+			break
+		}
+	}
+}
+
+func TestGetDates_InvalidDate(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	for _, query := range []string{"from=2025-1-1", "to=yesterday", "from=2025-01-01&to=2025-13-01"} {
+		resp, err := http.Get(server.URL + "/v1/puzzles/dates?" + query)
+		if err != nil {
+			t.Fatalf("failed to get dates: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	db, err := store.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer db.Close()
+
+	for _, serve := range []bool{false, true} {
+		server := httptest.NewServer(NewRouter(Config{Store: db, Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), ServePlayer: serve}))
+		resp, err := http.Get(server.URL + "/play/fr-2025-01-15")
+		if err != nil {
+			server.Close()
+			t.Fatalf("failed to get player: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		server.Close()
+
+		if !serve {
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("expected 404 with the player disabled, got %d", resp.StatusCode)
+			}
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", resp.StatusCode)
+		}
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("expected text/html, got %q", ct)
+		}
+		if !strings.Contains(string(body), "/v1/puzzles/fr-2025-01-15") {
+			t.Error("expected the page to reference the puzzle JSON endpoint")
+		}
+	}
+}
+
 func TestGetPuzzle(t *testing.T) {
 	server, db := setupTestServer(t)
 	ctx := context.Background()
@@ -170,6 +293,105 @@ func TestGetPuzzle(t *testing.T) {
 	}
 }
 
+func TestGetPuzzle_RedactsSolutions(t *testing.T) {
+	server, db := setupTestServer(t)
+	ctx := context.Background()
+
+	today := time.Now().Format("2006-01-02")
+	db.Puzzles().Store(ctx, createTestPuzzle("redacted", today, domain.StatusPublished))
+
+	for _, path := range []string{
+		"/v1/puzzles/redacted",
+		"/v1/puzzles/daily?language=fr",
+		"/v1/puzzles/latest?language=fr",
+	} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d", path, resp.StatusCode)
+		}
+		if strings.Contains(string(body), `"solution"`) || strings.Contains(string(body), `"AB"`) {
+			t.Errorf("GET %s: response leaks the solution: %s", path, body)
+		}
+
+		var result domain.Puzzle
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("GET %s: invalid JSON: %v", path, err)
+		}
+		for _, c := range result.Clues.Across {
+			if c.Answer != "" {
+				t.Errorf("GET %s: clue %d leaks answer %q", path, c.Number, c.Answer)
+			}
+		}
+	}
+}
+
+// getPlayView stores a mots fléchés puzzle (a clue cell followed by the
+// entry "AB") and fetches it through the public endpoint.
+func getPlayView(t *testing.T) domain.Puzzle {
+	t.Helper()
+	server, db := setupTestServer(t)
+
+	puzzle := createTestPuzzle("fleches", "2024-01-15", domain.StatusPublished)
+	puzzle.Grid = [][]domain.Cell{{
+		{Type: domain.CellTypeClue, ClueAcross: "Deux lettres"},
+		{Type: domain.CellTypeLetter, Solution: "A"},
+		{Type: domain.CellTypeLetter, Solution: "B"},
+	}}
+	puzzle.Clues.Across = []domain.Clue{{
+		Number: 1, Prompt: "Deux lettres", Answer: "AB", Direction: domain.DirectionAcross,
+		Start: domain.Position{Row: 0, Col: 1}, Length: 2,
+	}}
+	db.Puzzles().Store(context.Background(), puzzle)
+
+	resp, err := http.Get(server.URL + "/v1/puzzles/fleches")
+	if err != nil {
+		t.Fatalf("failed to get puzzle: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var result domain.Puzzle
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode puzzle: %v", err)
+	}
+	return result
+}
+
+func TestGetPuzzle_ClueLayout(t *testing.T) {
+	result := getPlayView(t)
+
+	want := domain.ClueCell{Position: domain.Position{Row: 0, Col: 0}, Direction: domain.DirectionAcross, Text: "Deux lettres"}
+	if len(result.ClueLayout) != 1 || result.ClueLayout[0] != want {
+		t.Errorf("expected clue layout [%+v], got %+v", want, result.ClueLayout)
+	}
+}
+
+func TestGetPuzzle_ClueCells(t *testing.T) {
+	result := getPlayView(t)
+
+	if len(result.Clues.Across) != 1 {
+		t.Fatalf("expected 1 across clue, got %d", len(result.Clues.Across))
+	}
+	clue := result.Clues.Across[0]
+	want := domain.GetCellsForClue(clue)
+	if len(clue.Cells) != len(want) {
+		t.Fatalf("expected cells %v, got %v", want, clue.Cells)
+	}
+	for i := range want {
+		if clue.Cells[i] != want[i] {
+			t.Errorf("cell %d: expected %v, got %v", i, want[i], clue.Cells[i])
+		}
+	}
+}
+
 func TestGetPuzzle_NotFound(t *testing.T) {
 	server, _ := setupTestServer(t)
 
@@ -184,6 +406,80 @@ func TestGetPuzzle_NotFound(t *testing.T) {
 	}
 }
 
+func TestExportPuzzle(t *testing.T) {
+	server, db := setupTestServer(t)
+	ctx := context.Background()
+
+	db.Puzzles().Store(ctx, createTestPuzzle("export-1", "2024-01-15", domain.StatusPublished))
+	db.Puzzles().Store(ctx, createTestPuzzle("export-draft", "2024-01-16", domain.StatusDraft))
+
+	const (
+		jsonType   = "application/json"
+		puzType    = "application/x-crossword"
+		ipuzType   = "application/x-ipuz"
+		ipuzMarker = "http://ipuz.org/v2"
+		puzMarker  = "ACROSS&DOWN"
+	)
+	tests := []struct {
+		name       string
+		path       string
+		accept     string
+		wantStatus int
+		wantType   string
+		wantMarker string // Expected in the body of the export
+	}{
+		{"json is ipuz", "/v1/puzzles/export-1/export", "application/json", http.StatusOK, jsonType, ipuzMarker},
+		{"no accept header", "/v1/puzzles/export-1/export", "", http.StatusOK, jsonType, ipuzMarker},
+		{"wildcard", "/v1/puzzles/export-1/export", "*/*", http.StatusOK, jsonType, ipuzMarker},
+		{"puz", "/v1/puzzles/export-1/export", "application/x-crossword", http.StatusOK, puzType, puzMarker},
+		{"ipuz", "/v1/puzzles/export-1/export", "application/x-ipuz", http.StatusOK, ipuzType, ipuzMarker},
+		{"ipuz media type preferred", "/v1/puzzles/export-1/export", "application/json;q=0.8, application/x-ipuz", http.StatusOK, ipuzType, ipuzMarker},
+		{"svg falls back to ipuz", "/v1/puzzles/export-1/export", "image/svg+xml, application/x-ipuz;q=0.5", http.StatusOK, ipuzType, ipuzMarker},
+		{"preferred type unsupported", "/v1/puzzles/export-1/export", "image/svg+xml, application/json;q=0.5", http.StatusOK, jsonType, ipuzMarker},
+		{"higher q wins", "/v1/puzzles/export-1/export", "application/json;q=0.5, application/x-crossword", http.StatusOK, puzType, puzMarker},
+		{"svg", "/v1/puzzles/export-1/export", "image/svg+xml", http.StatusNotAcceptable, "", ""},
+		{"json refused", "/v1/puzzles/export-1/export", "application/json;q=0", http.StatusNotAcceptable, "", ""},
+		{"format overrides accept", "/v1/puzzles/export-1/export?format=ipuz", "image/svg+xml", http.StatusOK, jsonType, ipuzMarker},
+		{"puz format", "/v1/puzzles/export-1/export?format=puz", "application/json", http.StatusOK, puzType, puzMarker},
+		{"no native json format", "/v1/puzzles/export-1/export?format=json", "", http.StatusNotAcceptable, "", ""},
+		{"unknown format", "/v1/puzzles/export-1/export?format=pdf", "application/json", http.StatusNotAcceptable, "", ""},
+		{"unpublished", "/v1/puzzles/export-draft/export", "application/json", http.StatusNotFound, "", ""},
+		{"missing", "/v1/puzzles/nonexistent/export", "application/json", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to export puzzle: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != tt.wantType {
+				t.Fatalf("expected Content-Type %s, got %q", tt.wantType, ct)
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			if !strings.Contains(string(body), tt.wantMarker) {
+				t.Errorf("expected %q in the export, got %q", tt.wantMarker, body)
+			}
+			if strings.Contains(string(body), `"original_answer"`) || strings.Contains(string(body), `"clues":{"across"`) {
+				t.Errorf("expected no internal puzzle JSON in the export, got %q", body)
+			}
+		})
+	}
+}
+
 func TestListPuzzles(t *testing.T) {
 	server, db := setupTestServer(t)
 	ctx := context.Background()
@@ -268,6 +564,34 @@ func TestCORSHeaders(t *testing.T) {
 	if resp.Header.Get("Access-Control-Allow-Origin") != "*" {
 		t.Error("expected CORS header")
 	}
+	// Browsers must be able to send the admin token
+	if headers := resp.Header.Get("Access-Control-Allow-Headers"); !strings.Contains(headers, "Authorization") {
+		t.Errorf("expected Authorization in allowed headers, got %q", headers)
+	}
+}
+
+func TestCORSPreflight_CheckAnswers(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	req, _ := http.NewRequest("OPTIONS", server.URL+"/v1/puzzles/some-puzzle/check", nil)
+	req.Header.Set("Origin", "https://player.example")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("preflight failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", resp.StatusCode)
+	}
+	if methods := resp.Header.Get("Access-Control-Allow-Methods"); !strings.Contains(methods, "POST") {
+		t.Errorf("expected POST in allowed methods, got %q", methods)
+	}
+	if headers := resp.Header.Get("Access-Control-Allow-Headers"); !strings.Contains(headers, "Content-Type") {
+		t.Errorf("expected Content-Type in allowed headers, got %q", headers)
+	}
 }
 
 func TestGzipCompression(t *testing.T) {
@@ -290,3 +614,202 @@ func TestGzipCompression(t *testing.T) {
 		t.Error("expected gzip content encoding")
 	}
 }
+
+func TestGetPuzzle_PrettyJSON(t *testing.T) {
+	server, db := setupTestServer(t)
+	ctx := context.Background()
+
+	puzzle := createTestPuzzle("pretty-test", "2024-01-15", domain.StatusPublished)
+	db.Puzzles().Store(ctx, puzzle)
+
+	// Default output is compact
+	resp, err := http.Get(server.URL + "/v1/puzzles/pretty-test")
+	if err != nil {
+		t.Fatalf("failed to get puzzle: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if strings.Contains(string(body), "\n  ") {
+		t.Errorf("expected compact JSON, got %s", body)
+	}
+
+	// pretty=true indents, also through gzip
+	req, _ := http.NewRequest("GET", server.URL+"/v1/puzzles/pretty-test?pretty=true", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to get puzzle: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatal("expected gzip content encoding")
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to open gzip body: %v", err)
+	}
+	body, _ = io.ReadAll(gz)
+
+	if !strings.Contains(string(body), "\n  \"id\": \"pretty-test\"") {
+		t.Errorf("expected indented JSON, got %s", body)
+	}
+	var result domain.Puzzle
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Errorf("pretty output is not valid JSON: %v", err)
+	}
+}
+
+func TestPrettyJSON_WrappedWriter(t *testing.T) {
+	// Gzip wraps the writer inside PrettyJSON here, which must not lose the flag
+	h := PrettyJSON(Gzip(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, http.StatusOK, map[string]string{"id": "x"})
+	})))
+
+	req := httptest.NewRequest("GET", "/?pretty=true", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to open gzip body: %v", err)
+	}
+	body, _ := io.ReadAll(gz)
+	if !strings.Contains(string(body), "\n  \"id\": \"x\"") {
+		t.Errorf("expected indented JSON, got %s", body)
+	}
+}
+
+func TestAdminAuth(t *testing.T) {
+	db := store.NewMemoryStore()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	server := httptest.NewServer(NewRouter(Config{Store: db, Logger: logger, AdminToken: "secret"}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/admin/v1/puzzles")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", resp.StatusCode)
+	}
+
+	// The Bearer scheme is required
+	req, _ := http.NewRequest("GET", server.URL+"/admin/v1/puzzles", nil)
+	req.Header.Set("Authorization", "secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without Bearer scheme, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("GET", server.URL+"/admin/v1/puzzles", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 with token, got %d", resp.StatusCode)
+	}
+
+	// Public routes stay open
+	resp, err = http.Get(server.URL + "/health")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 on public route, got %d", resp.StatusCode)
+	}
+}
+
+func TestCORSHeaders_AllowedOrigins(t *testing.T) {
+	db := store.NewMemoryStore()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	server := httptest.NewServer(NewRouter(Config{Store: db, Logger: logger, CORSOrigins: []string{"https://ok.example"}}))
+	defer server.Close()
+
+	for origin, want := range map[string]string{
+		"https://ok.example":  "https://ok.example",
+		"https://bad.example": "",
+	} {
+		req, _ := http.NewRequest("GET", server.URL+"/health", nil)
+		req.Header.Set("Origin", origin)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if got := resp.Header.Get("Access-Control-Allow-Origin"); got != want {
+			t.Errorf("origin %s: expected allow-origin %q, got %q", origin, want, got)
+		}
+	}
+}
+
+func TestCheckAnswers(t *testing.T) {
+	server, db := setupTestServer(t)
+	ctx := context.Background()
+
+	puzzle := createTestPuzzle("check-puzzle", "2026-01-15", domain.StatusPublished)
+	puzzle.Grid = [][]domain.Cell{{
+		{Type: domain.CellTypeLetter, Solution: "C"},
+		{Type: domain.CellTypeLetter, Solution: "A"},
+		{Type: domain.CellTypeLetter, Solution: "F"},
+		{Type: domain.CellTypeLetter, Solution: "E"},
+	}}
+	puzzle.Clues.Across = []domain.Clue{{Number: 1, Answer: "CAFE", Direction: domain.DirectionAcross, Length: 4}}
+	db.Puzzles().Store(ctx, puzzle)
+
+	check := func(query, answer string) (int, map[string]interface{}) {
+		t.Helper()
+		body := `{"entries":[{"number":1,"direction":"across","answer":"` + answer + `"}]}`
+		resp, err := http.Post(server.URL+"/v1/puzzles/check-puzzle/check"+query, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to check: %v", err)
+		}
+		defer resp.Body.Close()
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	tests := []struct {
+		query  string
+		answer string
+		want   float64
+	}{
+		{"", "café", 1},
+		{"", "CAFE", 1},
+		{"", "cafe", 1},
+		{"?strict=true", "café", 0},
+		{"?strict=true", "CAFE", 1},
+		{"", "CAFT", 0},
+	}
+	for _, tt := range tests {
+		status, result := check(tt.query, tt.answer)
+		if status != http.StatusOK {
+			t.Fatalf("%s%s: expected status 200, got %d", tt.answer, tt.query, status)
+		}
+		if result["correct"] != tt.want {
+			t.Errorf("%s%s: correct = %v, want %v", tt.answer, tt.query, result["correct"], tt.want)
+		}
+	}
+
+	resp, err := http.Post(server.URL+"/v1/puzzles/check-puzzle/check", "application/json",
+		strings.NewReader(`{"entries":[{"number":9,"direction":"down","answer":"X"}]}`))
+	if err != nil {
+		t.Fatalf("failed to check: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown entry: expected status 400, got %d", resp.StatusCode)
+	}
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"compress/gzip"
+	"context"
+	"crypto/subtle"
 	"io"
 	"log/slog"
 	"net/http"
@@ -52,23 +54,71 @@ func Gzip(next http.Handler) http.Handler {
 	})
 }
 
-// CORS returns a middleware that adds CORS headers.
-func CORS(next http.Handler) http.Handler {
+// PrettyJSON returns a middleware that marks requests with ?pretty=true for
+// indented JSON responses. The mark lives in the request context, so it
+// survives any middleware that wraps the response writer.
+func PrettyJSON(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, If-None-Match")
-		w.Header().Set("Access-Control-Expose-Headers", "ETag")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusNoContent)
-			return
+		if r.URL.Query().Get("pretty") == "true" {
+			r = r.WithContext(context.WithValue(r.Context(), prettyKey{}, true))
 		}
-
 		next.ServeHTTP(w, r)
 	})
 }
 
+// CORS returns a middleware that adds CORS headers for the allowed origins.
+// An empty list or "*" allows any origin.
+func CORS(origins []string) func(http.Handler) http.Handler {
+	allowAll := len(origins) == 0
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		if origin == "*" {
+			allowAll = true
+		}
+		allowed[origin] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if allowAll {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Add("Vary", "Origin")
+				if origin := r.Header.Get("Origin"); allowed[origin] {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+				}
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, If-None-Match")
+			w.Header().Set("Access-Control-Expose-Headers", "ETag")
+
+			if r.Method == "OPTIONS" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AdminAuth returns a middleware requiring "Authorization: Bearer <token>" on
+// /admin/ routes. An empty token leaves admin routes open.
+func AdminAuth(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token != "" && strings.HasPrefix(r.URL.Path, "/admin/") {
+				got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+				if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+					writeError(w, r, http.StatusUnauthorized, "unauthorized")
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // Recover returns a middleware that recovers from panics.
 func Recover(logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -76,7 +126,7 @@ func Recover(logger *slog.Logger) func(http.Handler) http.Handler {
 			defer func() {
 				if err := recover(); err != nil {
 					logger.Error("panic recovered", "error", err, "path", r.URL.Path)
-					writeError(w, http.StatusInternalServerError, "internal server error")
+					writeError(w, r, http.StatusInternalServerError, "internal server error")
 				}
 			}()
 			next.ServeHTTP(w, r)
@@ -104,3 +154,12 @@ type gzipResponseWriter struct {
 func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 	return w.Writer.Write(b)
 }
+
+// prettyKey is the context key PrettyJSON sets for indented JSON output.
+type prettyKey struct{}
+
+// wantsPrettyJSON reports whether the response to r should be indented.
+func wantsPrettyJSON(r *http.Request) bool {
+	pretty, _ := r.Context().Value(prettyKey{}).(bool)
+	return pretty
+}
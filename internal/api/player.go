@@ -0,0 +1,31 @@
+package api
+
+import (
+	_ "embed"
+	"html/template"
+	"net/http"
+)
+
+//go:embed player.html
+var playerHTML string
+
+// playerPage renders a puzzle in the browser from the public puzzle and check
+// endpoints, for manual testing.
+var playerPage = template.Must(template.New("player").Parse(playerHTML))
+
+// Player serves the built-in HTML player for a puzzle. The page loads the
+// redacted play view from GET /v1/puzzles/{id}, so only published puzzles
+// display and answers never reach the browser before they are checked.
+// GET /play/{id}
+func (h *Handler) Player(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "missing puzzle id")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := playerPage.Execute(w, struct{ ID string }{id}); err != nil {
+		h.logger.Error("failed to render player", "error", err, "id", id)
+	}
+}
@@ -4,19 +4,27 @@ import (
 	"log/slog"
 	"net/http"
 
+	"lesmotsdatche/internal/generator"
+	"lesmotsdatche/internal/generator/fill"
 	"lesmotsdatche/internal/store"
 )
 
 // Config holds API server configuration.
 type Config struct {
-	Store  store.Store
-	Logger *slog.Logger
+	Store        store.Store
+	Logger       *slog.Logger
+	Lexicons     map[string]*fill.MemoryLexicon // Base lexicons by language code (optional)
+	Orchestrator *generator.Orchestrator        // LLM generator for clue suggestions (optional)
+	AdminToken   string                         // Bearer token required on /admin routes (empty = open)
+	CORSOrigins  []string                       // Allowed CORS origins (empty = "*")
+	ServePlayer  bool                           // Serve the built-in HTML player at /play/{id}
+	Models       []string                       // LLM models an admin generate request may select (empty = no override)
 }
 
 // NewRouter creates a new HTTP router with all routes configured.
 func NewRouter(cfg Config) http.Handler {
-	handler := NewHandler(cfg.Store)
-	adminHandler := NewAdminHandler(cfg.Store, nil)
+	handler := NewHandler(cfg.Store).WithLogger(cfg.Logger)
+	adminHandler := NewAdminHandler(cfg.Store, cfg.Orchestrator).WithLexicons(cfg.Lexicons).WithModels(cfg.Models).WithLogger(cfg.Logger)
 
 	mux := http.NewServeMux()
 
@@ -25,18 +33,42 @@ func NewRouter(cfg Config) http.Handler {
 
 	// Public puzzle endpoints
 	mux.HandleFunc("GET /v1/puzzles/daily", handler.GetDaily)
+	mux.HandleFunc("GET /v1/puzzles/latest", handler.GetLatest)
+	mux.HandleFunc("GET /v1/puzzles/dates", handler.GetDates)
 	mux.HandleFunc("GET /v1/puzzles/{id}", handler.GetPuzzle)
+	mux.HandleFunc("POST /v1/puzzles/{id}/check", handler.CheckAnswers)
+	mux.HandleFunc("GET /v1/puzzles/{id}/export", handler.ExportPuzzle)
 	mux.HandleFunc("GET /v1/puzzles", handler.ListPuzzles)
 
+	// Browser player for manual testing
+	if cfg.ServePlayer {
+		mux.HandleFunc("GET /play/{id}", handler.Player)
+	}
+
 	// Admin endpoints (for development/seeding)
+	mux.HandleFunc("POST /admin/v1/generate", adminHandler.GeneratePuzzle)
 	mux.HandleFunc("POST /admin/v1/puzzles", adminHandler.StorePuzzle)
+	mux.HandleFunc("PATCH /admin/v1/puzzles/{id}", adminHandler.PatchPuzzle)
 	mux.HandleFunc("PATCH /admin/v1/puzzles/{id}/status", adminHandler.UpdateStatus)
+	mux.HandleFunc("POST /admin/v1/puzzles/{id}/publish", adminHandler.PublishPuzzle)
+	mux.HandleFunc("POST /admin/v1/puzzles/{id}/retheme", adminHandler.RethemePuzzle)
+	mux.HandleFunc("POST /admin/v1/puzzles/{id}/rescore", adminHandler.RescorePuzzle)
 	mux.HandleFunc("GET /admin/v1/puzzles", adminHandler.ListPuzzles)
 	mux.HandleFunc("GET /admin/v1/puzzles/{id}", adminHandler.GetPuzzle)
+	mux.HandleFunc("GET /admin/v1/puzzles/{id}/answers.csv", adminHandler.GetAnswerKey)
+	mux.HandleFunc("GET /admin/v1/stats", adminHandler.GetStats)
+	mux.HandleFunc("GET /admin/v1/export", adminHandler.ExportPuzzles)
+	mux.HandleFunc("POST /admin/v1/import", adminHandler.ImportPuzzles)
+	mux.HandleFunc("GET /admin/v1/lexicon/match", adminHandler.MatchLexicon)
+	mux.HandleFunc("POST /admin/v1/template/analyze", adminHandler.AnalyzeTemplate)
+	mux.HandleFunc("POST /admin/v1/suggest", adminHandler.SuggestWords)
+	mux.HandleFunc("POST /admin/v1/clues", adminHandler.GenerateClues)
 
 	// Apply middleware stack
 	var h http.Handler = mux
-	h = CORS(h)
+	h = PrettyJSON(h)
+	h = AdminAuth(cfg.AdminToken)(h)
+	h = CORS(cfg.CORSOrigins)(h)
 	h = Gzip(h)
 	h = Logger(cfg.Logger)(h)
 	h = Recover(cfg.Logger)(h)
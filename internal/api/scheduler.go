@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/store"
+)
+
+// PublishDuePuzzles promotes scheduled puzzles whose date is on or before now to published.
+// Returns the number of puzzles promoted.
+func PublishDuePuzzles(ctx context.Context, s store.Store, now time.Time) (int, error) {
+	due, err := s.Puzzles().List(ctx, store.PuzzleFilter{
+		Status: domain.StatusScheduled,
+		ToDate: now.Format("2006-01-02"),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, p := range due {
+		if err := s.Puzzles().UpdateStatus(ctx, p.ID, domain.StatusPublished); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/store"
+)
+
+func TestPublishDuePuzzles(t *testing.T) {
+	s := store.NewMemoryStore()
+	ctx := context.Background()
+
+	s.Puzzles().Store(ctx, &domain.Puzzle{
+		ID:       "due",
+		Language: "fr",
+		Date:     "2026-03-01",
+		Status:   domain.StatusScheduled,
+	})
+	s.Puzzles().Store(ctx, &domain.Puzzle{
+		ID:       "later",
+		Language: "fr",
+		Date:     "2026-03-05",
+		Status:   domain.StatusScheduled,
+	})
+
+	// Before the date arrives nothing is promoted
+	n, err := PublishDuePuzzles(ctx, s, time.Date(2026, 2, 28, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected 0 puzzles published, got %d", n)
+	}
+
+	// Once the date is reached the puzzle is published
+	n, err = PublishDuePuzzles(ctx, s, time.Date(2026, 3, 1, 0, 5, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 puzzle published, got %d", n)
+	}
+
+	due, _ := s.Puzzles().Get(ctx, "due")
+	if due.Status != domain.StatusPublished {
+		t.Errorf("expected 'due' to be published, got %q", due.Status)
+	}
+	if due.PublishedAt == nil {
+		t.Error("expected PublishedAt to be set")
+	}
+
+	later, _ := s.Puzzles().Get(ctx, "later")
+	if later.Status != domain.StatusScheduled {
+		t.Errorf("expected 'later' to stay scheduled, got %q", later.Status)
+	}
+}
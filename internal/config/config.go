@@ -0,0 +1,147 @@
+// Package config loads application configuration from environment variables.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds settings shared by the API server and the generator CLI.
+type Config struct {
+	Addr              string        // HTTP listen address (PORT)
+	DatabasePath      string        // SQLite file (DATABASE_PATH)
+	APIKey            string        // LLM provider API key (OPENAI_API_KEY)
+	Model             string        // LLM model name (OPENAI_MODEL)
+	Provider          string        // LLM provider (LLM_PROVIDER)
+	LLMTimeout        time.Duration // Per-request LLM timeout (LLM_TIMEOUT)
+	GenerationTimeout time.Duration // Total puzzle generation timeout (GENERATION_TIMEOUT)
+	PublishInterval   time.Duration // Scheduled puzzle reconciler interval (PUBLISH_INTERVAL)
+	AdminToken        string        // Bearer token for /admin routes (ADMIN_TOKEN, empty = open)
+	CORSOrigins       []string      // Allowed CORS origins (CORS_ORIGINS, comma-separated)
+	AnswerRepeatDays  int           // Days of puzzles whose answers are not reused (ANSWER_REPEAT_WINDOW_DAYS, 0 = off)
+	ServePlayer       bool          // Serve the HTML player at /play/{id} (SERVE_PLAYER)
+	AllowedModels     []string      // Models admin generation may select instead of Model (LLM_ALLOWED_MODELS, comma-separated)
+}
+
+// Default returns the configuration used when no environment variables are set.
+func Default() Config {
+	return Config{
+		Addr:              ":8080",
+		DatabasePath:      "puzzles.db",
+		Model:             "gpt-4o",
+		Provider:          "openai",
+		LLMTimeout:        60 * time.Second,
+		GenerationTimeout: 5 * time.Minute,
+		PublishInterval:   time.Minute,
+		CORSOrigins:       []string{"*"},
+	}
+}
+
+// Load reads configuration from the environment, applying defaults for unset
+// variables and validating the result.
+func Load() (Config, error) {
+	cfg := Default()
+
+	if v := os.Getenv("PORT"); v != "" {
+		// Accept both "8080" and ":8080"
+		if !strings.Contains(v, ":") {
+			v = ":" + v
+		}
+		cfg.Addr = v
+	}
+	if v := os.Getenv("DATABASE_PATH"); v != "" {
+		cfg.DatabasePath = v
+	}
+	cfg.APIKey = os.Getenv("OPENAI_API_KEY")
+	if v := os.Getenv("OPENAI_MODEL"); v != "" {
+		cfg.Model = v
+	}
+	if v := os.Getenv("LLM_PROVIDER"); v != "" {
+		cfg.Provider = strings.ToLower(v)
+	}
+	cfg.AdminToken = os.Getenv("ADMIN_TOKEN")
+	if v := os.Getenv("CORS_ORIGINS"); v != "" {
+		cfg.CORSOrigins = splitList(v)
+	}
+	if v := os.Getenv("LLM_ALLOWED_MODELS"); v != "" {
+		cfg.AllowedModels = splitList(v)
+	}
+
+	if v := os.Getenv("ANSWER_REPEAT_WINDOW_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid ANSWER_REPEAT_WINDOW_DAYS %q: %w", v, err)
+		}
+		cfg.AnswerRepeatDays = days
+	}
+
+	if v := os.Getenv("SERVE_PLAYER"); v != "" {
+		serve, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid SERVE_PLAYER %q: %w", v, err)
+		}
+		cfg.ServePlayer = serve
+	}
+
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"LLM_TIMEOUT", &cfg.LLMTimeout},
+		{"GENERATION_TIMEOUT", &cfg.GenerationTimeout},
+		{"PUBLISH_INTERVAL", &cfg.PublishInterval},
+	}
+	for _, d := range durations {
+		v := os.Getenv(d.key)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s %q: %w", d.key, v, err)
+		}
+		*d.dst = parsed
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// splitList splits a comma-separated variable, dropping blank items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate checks that the configuration is usable.
+func (c Config) Validate() error {
+	if c.Provider != "openai" {
+		return fmt.Errorf("unsupported LLM_PROVIDER %q (supported: openai)", c.Provider)
+	}
+	if c.LLMTimeout <= 0 {
+		return fmt.Errorf("LLM_TIMEOUT must be positive, got %s", c.LLMTimeout)
+	}
+	if c.GenerationTimeout <= 0 {
+		return fmt.Errorf("GENERATION_TIMEOUT must be positive, got %s", c.GenerationTimeout)
+	}
+	if c.PublishInterval <= 0 {
+		return fmt.Errorf("PUBLISH_INTERVAL must be positive, got %s", c.PublishInterval)
+	}
+	if c.AnswerRepeatDays < 0 {
+		return fmt.Errorf("ANSWER_REPEAT_WINDOW_DAYS must not be negative, got %d", c.AnswerRepeatDays)
+	}
+	if len(c.CORSOrigins) == 0 {
+		return fmt.Errorf("CORS_ORIGINS must list at least one origin")
+	}
+	return nil
+}
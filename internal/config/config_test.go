@@ -0,0 +1,96 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+// clearEnv blanks every variable Load reads, so tests don't depend on the
+// ambient environment.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"PORT", "DATABASE_PATH", "OPENAI_API_KEY", "OPENAI_MODEL", "LLM_PROVIDER",
+		"ADMIN_TOKEN", "CORS_ORIGINS", "LLM_ALLOWED_MODELS", "ANSWER_REPEAT_WINDOW_DAYS",
+		"SERVE_PLAYER", "LLM_TIMEOUT", "GENERATION_TIMEOUT", "PUBLISH_INTERVAL",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("PORT", "9090")
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+	t.Setenv("LLM_TIMEOUT", "30s")
+	t.Setenv("CORS_ORIGINS", "https://a.example, https://b.example")
+	t.Setenv("ANSWER_REPEAT_WINDOW_DAYS", "14")
+	t.Setenv("SERVE_PLAYER", "true")
+	t.Setenv("LLM_ALLOWED_MODELS", "gpt-4o-mini,, gpt-4o ")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Addr != ":9090" {
+		t.Errorf("expected addr ':9090', got %q", cfg.Addr)
+	}
+	if cfg.APIKey != "sk-test" {
+		t.Errorf("expected API key 'sk-test', got %q", cfg.APIKey)
+	}
+	if cfg.LLMTimeout != 30*time.Second {
+		t.Errorf("expected LLM timeout 30s, got %s", cfg.LLMTimeout)
+	}
+	if len(cfg.CORSOrigins) != 2 || cfg.CORSOrigins[1] != "https://b.example" {
+		t.Errorf("unexpected CORS origins: %v", cfg.CORSOrigins)
+	}
+	if cfg.AnswerRepeatDays != 14 {
+		t.Errorf("expected answer repeat window 14, got %d", cfg.AnswerRepeatDays)
+	}
+	if !cfg.ServePlayer {
+		t.Error("expected player to be served")
+	}
+	if len(cfg.AllowedModels) != 2 || cfg.AllowedModels[1] != "gpt-4o" {
+		t.Errorf("unexpected allowed models: %v", cfg.AllowedModels)
+	}
+
+	// Unset variables keep their defaults
+	def := Default()
+	if cfg.DatabasePath != def.DatabasePath {
+		t.Errorf("expected default database path, got %q", cfg.DatabasePath)
+	}
+	if cfg.Model != def.Model {
+		t.Errorf("expected default model, got %q", cfg.Model)
+	}
+	if cfg.GenerationTimeout != def.GenerationTimeout {
+		t.Errorf("expected default generation timeout, got %s", cfg.GenerationTimeout)
+	}
+	if cfg.AdminToken != "" {
+		t.Errorf("expected empty admin token, got %q", cfg.AdminToken)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		val  string
+	}{
+		{"bad duration", "LLM_TIMEOUT", "soon"},
+		{"negative duration", "GENERATION_TIMEOUT", "-1m"},
+		{"unknown provider", "LLM_PROVIDER", "carrier-pigeon"},
+		{"negative repeat window", "ANSWER_REPEAT_WINDOW_DAYS", "-3"},
+		{"bad boolean", "SERVE_PLAYER", "sometimes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv(tt.key, tt.val)
+			if _, err := Load(); err == nil {
+				t.Errorf("expected error for %s=%q", tt.key, tt.val)
+			}
+		})
+	}
+}
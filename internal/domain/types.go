@@ -28,8 +28,40 @@ const (
 	StatusDraft     PuzzleStatus = "draft"
 	StatusPublished PuzzleStatus = "published"
 	StatusArchived  PuzzleStatus = "archived"
+	StatusScheduled PuzzleStatus = "scheduled" // Queued for automatic publishing on its date
 )
 
+// NumberingScheme describes how entries are numbered.
+type NumberingScheme string
+
+const (
+	NumberingAmerican NumberingScheme = "american" // Row-major numbers on start cells, clues keyed by number
+	NumberingNone     NumberingScheme = "none"     // Mots fléchés: clues live in arrow cells, numbers are only identifiers
+)
+
+// SymmetryMode describes how blocks mirror each other in a grid.
+type SymmetryMode string
+
+const (
+	SymmetryRotational SymmetryMode = "rotational" // 180° rotation around the center
+	SymmetryMirror     SymmetryMode = "mirror"     // Left-right reflection
+	SymmetryNone       SymmetryMode = "none"
+)
+
+// Partner returns the cell that must match (row, col) in a rows x cols grid
+// under the mode; ok is false for SymmetryNone. An empty mode is rotational.
+// A cell on the axis of symmetry is its own partner.
+func (m SymmetryMode) Partner(row, col, rows, cols int) (r, c int, ok bool) {
+	switch m {
+	case SymmetryNone:
+		return row, col, false
+	case SymmetryMirror:
+		return row, cols - 1 - col, true
+	default:
+		return rows - 1 - row, cols - 1 - col, true
+	}
+}
+
 // Position represents a row/column coordinate in the grid.
 type Position struct {
 	Row int `json:"row"`
@@ -45,22 +77,26 @@ type Cell struct {
 	Number     int      `json:"number,omitempty"`      // Clue number if this cell starts an entry
 	ClueAcross string   `json:"clue_across,omitempty"` // Definition for across direction (→)
 	ClueDown   string   `json:"clue_down,omitempty"`   // Definition for down direction (↓)
+	Given      bool     `json:"given,omitempty"`       // Letter is pre-revealed to the solver
 }
 
 // Clue represents a single clue with its answer and metadata.
 type Clue struct {
-	ID                 string    `json:"id"`
-	Direction          Direction `json:"direction"`
-	Number             int       `json:"number"`
-	Prompt             string    `json:"prompt"`
-	Answer             string    `json:"answer"`                    // Normalized A-Z
-	OriginalAnswer     string    `json:"original_answer,omitempty"` // Pre-normalized (with spaces, hyphens, accents)
-	Start              Position  `json:"start"`
-	Length             int       `json:"length"`
-	ReferenceTags      []string  `json:"reference_tags,omitempty"`
-	ReferenceYearRange [2]int    `json:"reference_year_range,omitempty"`
-	Difficulty         int       `json:"difficulty,omitempty"`
-	AmbiguityNotes     string    `json:"ambiguity_notes,omitempty"`
+	ID                 string     `json:"id"`
+	Direction          Direction  `json:"direction"`
+	Number             int        `json:"number"`
+	Prompt             string     `json:"prompt"`
+	Answer             string     `json:"answer"`                    // Normalized A-Z
+	OriginalAnswer     string     `json:"original_answer,omitempty"` // Pre-normalized (with spaces, hyphens, accents)
+	Start              Position   `json:"start"`
+	Length             int        `json:"length"`
+	ReferenceTags      []string   `json:"reference_tags,omitempty"`
+	ReferenceYearRange [2]int     `json:"reference_year_range,omitempty"`
+	Difficulty         int        `json:"difficulty,omitempty"`
+	Style              string     `json:"style,omitempty"` // Clue style, e.g. "definition" or "wordplay"
+	AmbiguityNotes     string     `json:"ambiguity_notes,omitempty"`
+	Highlighted        bool       `json:"highlighted,omitempty"` // Theme entry renderers should highlight
+	Cells              []Position `json:"cells,omitempty"`       // Entry cell positions, set in play views for clue navigation
 }
 
 // WordBreaks returns the cell indices AFTER which a dotted border should appear.
@@ -112,26 +148,40 @@ type Clues struct {
 
 // Metadata contains optional metadata about a puzzle.
 type Metadata struct {
-	ThemeTags      []string `json:"theme_tags,omitempty"`
-	ReferenceTags  []string `json:"reference_tags,omitempty"`
-	Notes          string   `json:"notes,omitempty"`
-	FreshnessScore int      `json:"freshness_score,omitempty"`
+	ThemeTags             []string `json:"theme_tags,omitempty"`
+	ReferenceTags         []string `json:"reference_tags,omitempty"`
+	Notes                 string   `json:"notes,omitempty"`
+	FreshnessScore        int      `json:"freshness_score,omitempty"`
+	EstimatedSolveSeconds int      `json:"estimated_solve_seconds,omitempty"` // Rough solve time for an average solver
+	DifficultyConfidence  float64  `json:"difficulty_confidence,omitempty"`   // 0-1 agreement of the signals behind an estimated difficulty
+	Model                 string   `json:"model,omitempty"`                   // LLM model a generated puzzle was made with
+	Provider              string   `json:"provider,omitempty"`                // LLM provider of Model
 }
 
 // Puzzle represents a complete crossword puzzle.
 type Puzzle struct {
-	ID          string       `json:"id"`
-	Date        string       `json:"date"`     // YYYY-MM-DD
-	Language    string       `json:"language"` // "fr" or "en"
-	Title       string       `json:"title"`
-	Author      string       `json:"author"`
-	Difficulty  int          `json:"difficulty"` // 1-5
-	Status      PuzzleStatus `json:"status"`
-	Grid        [][]Cell     `json:"grid"`
-	Clues       Clues        `json:"clues"`
-	Metadata    Metadata     `json:"metadata,omitempty"`
-	CreatedAt   time.Time    `json:"created_at"`
-	PublishedAt *time.Time   `json:"published_at,omitempty"`
+	ID              string          `json:"id"`
+	Date            string          `json:"date"`     // YYYY-MM-DD
+	Language        string          `json:"language"` // "fr" or "en"
+	Title           string          `json:"title"`
+	Author          string          `json:"author"`
+	Difficulty      int             `json:"difficulty"` // 1-5
+	Status          PuzzleStatus    `json:"status"`
+	Grid            [][]Cell        `json:"grid"`
+	Clues           Clues           `json:"clues"`
+	NumberingScheme NumberingScheme `json:"numbering_scheme,omitempty"` // Empty on legacy puzzles, whose numbers are not validated
+	Metadata        Metadata        `json:"metadata,omitempty"`
+	ClueLayout      []ClueCell      `json:"clue_layout,omitempty"` // Set by PlayView for mots fléchés renderers
+	CreatedAt       time.Time       `json:"created_at"`
+	PublishedAt     *time.Time      `json:"published_at,omitempty"`
+}
+
+// ClueCell is one definition written in a mots fléchés clue cell. The arrow
+// points from Position to the entry: right for across, down for down.
+type ClueCell struct {
+	Position  Position  `json:"position"`
+	Direction Direction `json:"direction"`
+	Text      string    `json:"text"`
 }
 
 // DraftReport contains QA scores and flags for a draft puzzle.
@@ -174,6 +224,65 @@ func (p *Puzzle) GridDimensions() (rows, cols int) {
 	return
 }
 
+// ClueCellLayout returns the definitions of all clue cells in row-major order,
+// the across definition first when a cell holds both.
+func (p *Puzzle) ClueCellLayout() []ClueCell {
+	var layout []ClueCell
+	for i, row := range p.Grid {
+		for j, cell := range row {
+			if !cell.IsClue() {
+				continue
+			}
+			pos := Position{Row: i, Col: j}
+			if cell.ClueAcross != "" {
+				layout = append(layout, ClueCell{Position: pos, Direction: DirectionAcross, Text: cell.ClueAcross})
+			}
+			if cell.ClueDown != "" {
+				layout = append(layout, ClueCell{Position: pos, Direction: DirectionDown, Text: cell.ClueDown})
+			}
+		}
+	}
+	return layout
+}
+
+// PlayView returns a copy of the puzzle safe to send to solvers: letter cell
+// solutions are removed except for given cells, and clue answers are cleared.
+// The clue cell layout is included for mots fléchés renderers, and each clue
+// lists its cell positions so clients can highlight the selected entry.
+func (p *Puzzle) PlayView() *Puzzle {
+	view := *p
+
+	view.Grid = make([][]Cell, len(p.Grid))
+	for i, row := range p.Grid {
+		view.Grid[i] = make([]Cell, len(row))
+		for j, cell := range row {
+			if cell.IsLetter() && !cell.Given {
+				cell.Solution = ""
+			}
+			view.Grid[i][j] = cell
+		}
+	}
+
+	view.Clues.Across = redactClues(p.Clues.Across)
+	view.Clues.Down = redactClues(p.Clues.Down)
+	view.ClueLayout = p.ClueCellLayout()
+	return &view
+}
+
+func redactClues(clues []Clue) []Clue {
+	if clues == nil {
+		return nil
+	}
+	redacted := make([]Clue, len(clues))
+	for i, c := range clues {
+		c.Answer = ""
+		c.OriginalAnswer = ""
+		c.Cells = GetCellsForClue(c)
+		redacted[i] = c
+	}
+	return redacted
+}
+
 // IsLetter returns true if the cell contains a letter.
 func (c *Cell) IsLetter() bool {
 	return c.Type == CellTypeLetter
@@ -98,6 +98,121 @@ func TestPuzzle_GridDimensions(t *testing.T) {
 	}
 }
 
+func TestPuzzle_PlayView(t *testing.T) {
+	p := &Puzzle{
+		Grid: [][]Cell{
+			{{Type: CellTypeLetter, Solution: "O", Given: true}, {Type: CellTypeLetter, Solution: "R"}},
+			{{Type: CellTypeClue, ClueAcross: "Métal précieux"}, {Type: CellTypeBlock}},
+		},
+		Clues: Clues{
+			Across: []Clue{{Number: 1, Answer: "OR", OriginalAnswer: "or", Prompt: "Métal précieux"}},
+		},
+	}
+
+	view := p.PlayView()
+
+	if view.Grid[0][0].Solution != "O" {
+		t.Errorf("expected given cell solution to stay visible, got %q", view.Grid[0][0].Solution)
+	}
+	if view.Grid[0][1].Solution != "" {
+		t.Errorf("expected solution to be stripped, got %q", view.Grid[0][1].Solution)
+	}
+	if view.Grid[1][0].ClueAcross != "Métal précieux" {
+		t.Error("expected clue cell text to be kept")
+	}
+	if view.Clues.Across[0].Answer != "" || view.Clues.Across[0].OriginalAnswer != "" {
+		t.Errorf("expected clue answers to be stripped, got %+v", view.Clues.Across[0])
+	}
+
+	// The original puzzle is untouched
+	if p.Grid[0][1].Solution != "R" || p.Clues.Across[0].Answer != "OR" {
+		t.Error("PlayView must not modify the original puzzle")
+	}
+}
+
+func TestPuzzle_PlayView_ClueCells(t *testing.T) {
+	p := &Puzzle{
+		Clues: Clues{
+			Across: []Clue{{Number: 1, Direction: DirectionAcross, Answer: "CHAT", Start: Position{Row: 1, Col: 2}, Length: 4}},
+			Down:   []Clue{{Number: 2, Direction: DirectionDown, Answer: "MER", Start: Position{Row: 0, Col: 3}, Length: 3}},
+		},
+	}
+
+	view := p.PlayView()
+
+	for _, c := range append(append([]Clue(nil), view.Clues.Across...), view.Clues.Down...) {
+		want := GetCellsForClue(c)
+		if len(c.Cells) != len(want) {
+			t.Fatalf("clue %d: expected %d cells, got %v", c.Number, len(want), c.Cells)
+		}
+		for i := range want {
+			if c.Cells[i] != want[i] {
+				t.Errorf("clue %d: expected cells %v, got %v", c.Number, want, c.Cells)
+				break
+			}
+		}
+	}
+	if got := view.Clues.Down[0].Cells; got[2] != (Position{Row: 2, Col: 3}) {
+		t.Errorf("expected down entry to end at (2,3), got %v", got)
+	}
+
+	if p.Clues.Across[0].Cells != nil {
+		t.Error("PlayView must not add cells to the original puzzle")
+	}
+}
+
+func TestPuzzle_ClueCellLayout(t *testing.T) {
+	letter := func(s string) Cell { return Cell{Type: CellTypeLetter, Solution: s} }
+	p := &Puzzle{
+		Grid: [][]Cell{
+			{{Type: CellTypeBlock}, {Type: CellTypeClue, ClueDown: "Mon au féminin"}, {Type: CellTypeClue, ClueDown: "Douze mois"}},
+			{{Type: CellTypeClue, ClueAcross: "Possessif"}, letter("M"), letter("A")},
+			{{Type: CellTypeClue, ClueAcross: "Année"}, letter("A"), letter("N")},
+		},
+		Clues: Clues{
+			Across: []Clue{
+				{Number: 1, Direction: DirectionAcross, Prompt: "Possessif", Answer: "MA", Start: Position{Row: 1, Col: 1}, Length: 2},
+				{Number: 2, Direction: DirectionAcross, Prompt: "Année", Answer: "AN", Start: Position{Row: 2, Col: 1}, Length: 2},
+			},
+			Down: []Clue{
+				{Number: 3, Direction: DirectionDown, Prompt: "Mon au féminin", Answer: "MA", Start: Position{Row: 1, Col: 1}, Length: 2},
+				{Number: 4, Direction: DirectionDown, Prompt: "Douze mois", Answer: "AN", Start: Position{Row: 1, Col: 2}, Length: 2},
+			},
+		},
+		NumberingScheme: NumberingNone,
+	}
+
+	layout := p.ClueCellLayout()
+	if len(layout) != 4 {
+		t.Fatalf("expected 4 clue cell entries, got %d", len(layout))
+	}
+
+	for _, c := range append(append([]Clue(nil), p.Clues.Across...), p.Clues.Down...) {
+		// Across definitions sit left of the entry, down definitions above it
+		want := Position{Row: c.Start.Row, Col: c.Start.Col - 1}
+		if c.Direction == DirectionDown {
+			want = Position{Row: c.Start.Row - 1, Col: c.Start.Col}
+		}
+		found := false
+		for _, cc := range layout {
+			if cc.Position == want && cc.Direction == c.Direction && cc.Text == c.Prompt {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no %s clue cell at %+v for entry %d", c.Direction, want, c.Number)
+		}
+	}
+
+	view := p.PlayView()
+	if len(view.ClueLayout) != len(layout) {
+		t.Errorf("expected play view to carry the clue layout, got %d entries", len(view.ClueLayout))
+	}
+	if p.ClueLayout != nil {
+		t.Error("PlayView must not set the layout on the original puzzle")
+	}
+}
+
 func TestClue_WordBreaks(t *testing.T) {
 	tests := []struct {
 		name           string
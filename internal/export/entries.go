@@ -0,0 +1,74 @@
+package export
+
+import (
+	"fmt"
+
+	"lesmotsdatche/internal/domain"
+)
+
+// numberedEntries numbers the puzzle grid American style from cell positions,
+// treating clue cells as blocks, whatever the Number fields of the puzzle. It
+// returns the numbered grid and its entries by number, across before down for
+// entries sharing a number, each with the prompt and highlight of the puzzle
+// clue that starts on the same cell in the same direction.
+func numberedEntries(p *domain.Puzzle) ([][]domain.Cell, []domain.Clue, error) {
+	starts := make(map[domain.Direction]map[domain.Position]domain.Clue, 2)
+	for dir, clues := range map[domain.Direction][]domain.Clue{
+		domain.DirectionAcross: p.Clues.Across,
+		domain.DirectionDown:   p.Clues.Down,
+	} {
+		starts[dir] = make(map[domain.Position]domain.Clue, len(clues))
+		for _, c := range clues {
+			starts[dir][c.Start] = c
+		}
+	}
+
+	blocked := make([][]domain.Cell, len(p.Grid))
+	for i, row := range p.Grid {
+		blocked[i] = make([]domain.Cell, len(row))
+		for j, cell := range row {
+			if !cell.IsLetter() {
+				cell = domain.Cell{Type: domain.CellTypeBlock}
+			}
+			blocked[i][j] = cell
+		}
+	}
+	numbered := domain.AssignNumbers(blocked)
+	slots := domain.ExtractSlots(numbered)
+
+	var entries []domain.Clue
+	across, down := slots.Across, slots.Down
+	for len(across) > 0 || len(down) > 0 {
+		if len(down) == 0 || (len(across) > 0 && across[0].Number <= down[0].Number) {
+			entries, across = append(entries, across[0]), across[1:]
+		} else {
+			entries, down = append(entries, down[0]), down[1:]
+		}
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		clue, ok := starts[e.Direction][e.Start]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %d %s at (%d,%d)", ErrMissingClue,
+				e.Number, e.Direction, e.Start.Row, e.Start.Col)
+		}
+		e.Prompt = clue.Prompt
+		e.Highlighted = clue.Highlighted
+	}
+	return numbered, entries, nil
+}
+
+// gridSize returns the size of a rectangular, non-empty grid.
+func gridSize(grid [][]domain.Cell) (rows, cols int, err error) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return 0, 0, fmt.Errorf("%w: empty grid", ErrUnsupportedGrid)
+	}
+	rows, cols = len(grid), len(grid[0])
+	for i, row := range grid {
+		if len(row) != cols {
+			return 0, 0, fmt.Errorf("%w: row %d has %d cells, want %d", ErrUnsupportedGrid, i, len(row), cols)
+		}
+	}
+	return rows, cols, nil
+}
@@ -0,0 +1,176 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"lesmotsdatche/internal/domain"
+)
+
+// ipuzBlock marks blocks in iPUZ grids.
+const ipuzBlock = "#"
+
+// ipuzPuzzle is an iPUZ v2 crossword. Fields are in the order the iPUZ
+// examples use, so exports diff cleanly.
+type ipuzPuzzle struct {
+	Version    string                `json:"version"`
+	Kind       []string              `json:"kind"`
+	UniqueID   string                `json:"uniqueid,omitempty"`
+	Title      string                `json:"title,omitempty"`
+	Author     string                `json:"author,omitempty"`
+	Date       string                `json:"date,omitempty"` // MM/DD/YYYY
+	Notes      string                `json:"notes,omitempty"`
+	Dimensions ipuzDimensions        `json:"dimensions"`
+	Block      string                `json:"block"`
+	Empty      string                `json:"empty"`
+	Puzzle     [][]interface{}       `json:"puzzle"`   // Entry number, 0, ipuzBlock or an ipuzCell
+	Solution   [][]string            `json:"solution"` // Letter or ipuzBlock
+	Clues      map[string][]ipuzClue `json:"clues"`
+}
+
+type ipuzDimensions struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// ipuzCell is a styled puzzle cell.
+type ipuzCell struct {
+	Cell  int       `json:"cell"` // Entry number or 0
+	Style ipuzStyle `json:"style"`
+}
+
+// ipuzStyle is an iPUZ StyleSpec.
+type ipuzStyle struct {
+	Highlight bool `json:"highlight,omitempty"`
+}
+
+// ipuzClue is a [number, "clue"] pair.
+type ipuzClue [2]interface{}
+
+// WriteIPUZ writes the puzzle as an iPUZ v2 crossword (http://ipuz.org).
+// Entries are numbered American style from cell positions, ignoring
+// Clue.Number, and clue cells of mots fléchés grids become blocks; each entry
+// takes its prompt from the clue that starts on the same cell. Cells of
+// highlighted theme entries get a highlight style.
+func WriteIPUZ(w io.Writer, p *domain.Puzzle) error {
+	rows, cols, err := gridSize(p.Grid)
+	if err != nil {
+		return err
+	}
+	numbered, entries, err := numberedEntries(p)
+	if err != nil {
+		return err
+	}
+
+	doc := ipuzPuzzle{
+		Version:    "http://ipuz.org/v2",
+		Kind:       []string{"http://ipuz.org/crossword#1"},
+		UniqueID:   p.ID,
+		Title:      p.Title,
+		Author:     p.Author,
+		Date:       ipuzDate(p.Date),
+		Notes:      p.Metadata.Notes,
+		Dimensions: ipuzDimensions{Width: cols, Height: rows},
+		Block:      ipuzBlock,
+		Empty:      "0",
+		Puzzle:     make([][]interface{}, rows),
+		Solution:   make([][]string, rows),
+		Clues:      map[string][]ipuzClue{"Across": {}, "Down": {}},
+	}
+	highlighted := make(map[domain.Position]bool)
+	for _, e := range entries {
+		if e.Highlighted {
+			for _, pos := range domain.GetCellsForClue(e) {
+				highlighted[pos] = true
+			}
+		}
+	}
+	for i, row := range numbered {
+		doc.Puzzle[i] = make([]interface{}, cols)
+		doc.Solution[i] = make([]string, cols)
+		for j, cell := range row {
+			if !cell.IsLetter() {
+				doc.Puzzle[i][j] = ipuzBlock
+				doc.Solution[i][j] = ipuzBlock
+				continue
+			}
+			doc.Puzzle[i][j] = cell.Number
+			if highlighted[domain.Position{Row: i, Col: j}] {
+				doc.Puzzle[i][j] = ipuzCell{Cell: cell.Number, Style: ipuzStyle{Highlight: true}}
+			}
+			doc.Solution[i][j] = cell.Solution
+		}
+	}
+	for _, e := range entries {
+		key := "Across"
+		if e.Direction == domain.DirectionDown {
+			key = "Down"
+		}
+		doc.Clues[key] = append(doc.Clues[key], ipuzClue{e.Number, e.Prompt})
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encode ipuz: %w", err)
+	}
+	_, err = w.Write(compactScalarArrays(buf.Bytes()))
+	return err
+}
+
+// flatLine matches one line of indented JSON that neither opens nor closes a
+// multi-line value; it may hold a value already put on one line. JSON strings
+// cannot span lines, so no match starts or ends in one.
+const flatLine = `[ ]*[^\[\]{}\n]*(?:\{[^\n]*\}[^\[\]{}\n]*)?\n`
+
+var (
+	flatObjectRe = regexp.MustCompile(`\{\n(?:` + flatLine + `)+[ ]*\}`)
+	flatArrayRe  = regexp.MustCompile(`\[\n(?:` + flatLine + `)+[ ]*\]`)
+)
+
+// compactScalarArrays puts each array of scalars or one-line objects of
+// indented JSON on one line, after putting objects of scalars, such as cell
+// styles, on one line, so grid rows and clues read as rows.
+func compactScalarArrays(indented []byte) []byte {
+	for {
+		compacted := flatObjectRe.ReplaceAllFunc(indented, func(object []byte) []byte {
+			return joinLines('{', object, '}')
+		})
+		if bytes.Equal(compacted, indented) {
+			break
+		}
+		indented = compacted
+	}
+	return flatArrayRe.ReplaceAllFunc(indented, func(array []byte) []byte {
+		return joinLines('[', array, ']')
+	})
+}
+
+// joinLines puts the lines of a multi-line JSON value, opening and closing
+// delimiters included, on one line.
+func joinLines(open byte, value []byte, close byte) []byte {
+	lines := bytes.Split(value[2:len(value)-1], []byte("\n"))
+	items := make([][]byte, 0, len(lines))
+	for _, line := range lines {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			items = append(items, line)
+		}
+	}
+	return append(append([]byte{open}, bytes.Join(items, []byte(" "))...), close)
+}
+
+// ipuzDate converts a YYYY-MM-DD date to the MM/DD/YYYY form of iPUZ, or
+// returns "" if it does not parse.
+func ipuzDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ""
+	}
+	return t.Format("01/02/2006")
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"lesmotsdatche/internal/domain"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden fixtures in testdata")
+
+// TestWriteIPUZ_Golden exports the generator's golden puzzle and compares it
+// to a checked-in fixture. Run with -update after an intended change.
+func TestWriteIPUZ_Golden(t *testing.T) {
+	puzzlePath := filepath.Join("..", "..", "testdata", "golden_10x10_puzzle.json")
+	goldenPath := filepath.Join("..", "..", "testdata", "golden_10x10_puzzle.ipuz")
+
+	data, err := os.ReadFile(puzzlePath)
+	if err != nil {
+		t.Fatalf("failed to read puzzle: %v", err)
+	}
+	var p domain.Puzzle
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("failed to parse puzzle: %v", err)
+	}
+	// Highlight the first across entry, as assembly does for theme seed words
+	p.Clues.Across[0].Highlighted = true
+
+	var buf bytes.Buffer
+	if err := WriteIPUZ(&buf, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.Bytes()
+
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("export does not match %s (run with -update if the change is intended)\ngot:\n%s", goldenPath, got)
+	}
+}
+
+func TestWriteIPUZ(t *testing.T) {
+	p := ringPuzzle()
+	p.Date = "2026-01-15"
+	// Stale numbers must not leak into the export
+	for i := range p.Clues.Across {
+		p.Clues.Across[i].Number = 7 + i
+	}
+	p.Grid[0][0].Number = 9
+	p.Clues.Across[0].Highlighted = true // CAT
+
+	var buf bytes.Buffer
+	if err := WriteIPUZ(&buf, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		Version    string   `json:"version"`
+		Kind       []string `json:"kind"`
+		Title      string   `json:"title"`
+		Date       string   `json:"date"`
+		Dimensions struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"dimensions"`
+		Puzzle   [][]interface{}             `json:"puzzle"`
+		Solution [][]string                  `json:"solution"`
+		Clues    map[string][][2]interface{} `json:"clues"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("export is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc.Version != "http://ipuz.org/v2" || !reflect.DeepEqual(doc.Kind, []string{"http://ipuz.org/crossword#1"}) {
+		t.Errorf("unexpected version %q and kind %v", doc.Version, doc.Kind)
+	}
+	if doc.Title != "Café" || doc.Date != "01/15/2026" {
+		t.Errorf("unexpected title %q and date %q", doc.Title, doc.Date)
+	}
+	if doc.Dimensions.Width != 3 || doc.Dimensions.Height != 3 {
+		t.Errorf("dimensions = %+v, want 3x3", doc.Dimensions)
+	}
+
+	// JSON numbers decode as float64
+	highlight := func(n float64) interface{} {
+		return map[string]interface{}{"cell": n, "style": map[string]interface{}{"highlight": true}}
+	}
+	wantPuzzle := [][]interface{}{
+		{highlight(1), highlight(0), highlight(2)},
+		{0.0, "#", 0.0},
+		{3.0, 0.0, 0.0},
+	}
+	if !reflect.DeepEqual(doc.Puzzle, wantPuzzle) {
+		t.Errorf("puzzle = %v, want %v", doc.Puzzle, wantPuzzle)
+	}
+	wantSolution := [][]string{{"C", "A", "T"}, {"A", "#", "O"}, {"R", "U", "E"}}
+	if !reflect.DeepEqual(doc.Solution, wantSolution) {
+		t.Errorf("solution = %v, want %v", doc.Solution, wantSolution)
+	}
+	wantClues := map[string][][2]interface{}{
+		"Across": {{1.0, "Matou"}, {3.0, "Voie"}},
+		"Down":   {{1.0, "Véhicule"}, {2.0, "Orteil"}},
+	}
+	if !reflect.DeepEqual(doc.Clues, wantClues) {
+		t.Errorf("clues = %v, want %v", doc.Clues, wantClues)
+	}
+}
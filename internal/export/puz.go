@@ -0,0 +1,178 @@
+// Package export writes puzzles in file formats read by other crossword apps.
+package export
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"lesmotsdatche/internal/domain"
+)
+
+// ErrUnsupportedGrid is returned when a grid cannot be represented in the
+// target format.
+var ErrUnsupportedGrid = errors.New("unsupported grid")
+
+// ErrMissingClue is returned when a grid entry has no clue in the puzzle.
+var ErrMissingClue = errors.New("entry has no clue")
+
+// puzMagic identifies Across Lite files.
+const puzMagic = "ACROSS&DOWN\x00"
+
+// puzVersion is the Across Lite format version written.
+const puzVersion = "1.3\x00"
+
+// puzHeaderSize is the size of the fixed .puz header.
+const puzHeaderSize = 0x34
+
+// WritePUZ writes the puzzle as an Across Lite .puz file. Clue cells of mots
+// fléchés grids become blocks, and entries are numbered American style, with
+// each entry taking its prompt from the clue in Clues.Across or Clues.Down
+// that starts on the same cell. Text is encoded as ISO-8859-1, with '?' for
+// characters outside it.
+func WritePUZ(w io.Writer, p *domain.Puzzle) error {
+	solution, state, err := puzGrids(p.Grid)
+	if err != nil {
+		return err
+	}
+	clues, err := puzClues(p)
+	if err != nil {
+		return err
+	}
+	if len(clues) > 0xFFFF {
+		return fmt.Errorf("%w: %d clues", ErrUnsupportedGrid, len(clues))
+	}
+
+	title := latin1(p.Title)
+	author := latin1(p.Author)
+	copyright := []byte(nil)
+	notes := latin1(p.Metadata.Notes)
+
+	header := make([]byte, puzHeaderSize)
+	copy(header[0x02:], puzMagic)
+	copy(header[0x18:], puzVersion)
+	header[0x2C] = byte(len(p.Grid[0]))
+	header[0x2D] = byte(len(p.Grid))
+	binary.LittleEndian.PutUint16(header[0x2E:], uint16(len(clues)))
+	binary.LittleEndian.PutUint16(header[0x30:], 0x0001) // Puzzle type: normal
+	binary.LittleEndian.PutUint16(header[0x32:], 0x0000) // Solution not scrambled
+
+	cib := puzChecksum(header[0x2C:puzHeaderSize], 0)
+	textSum := puzTextChecksum(title, author, copyright, clues, notes, 0)
+	solutionSum := puzChecksum(solution, 0)
+	stateSum := puzChecksum(state, 0)
+
+	sum := puzChecksum(solution, cib)
+	sum = puzChecksum(state, sum)
+	sum = puzTextChecksum(title, author, copyright, clues, notes, sum)
+
+	binary.LittleEndian.PutUint16(header[0x00:], sum)
+	binary.LittleEndian.PutUint16(header[0x0E:], cib)
+	// The masked checksums spell "ICHEATED" once unmasked
+	for i, s := range []uint16{cib, solutionSum, stateSum, textSum} {
+		header[0x10+i] = "ICHE"[i] ^ byte(s)
+		header[0x14+i] = "ATED"[i] ^ byte(s>>8)
+	}
+
+	body := append(header, solution...)
+	body = append(body, state...)
+	for _, s := range [][]byte{title, author, copyright} {
+		body = append(append(body, s...), 0)
+	}
+	for _, clue := range clues {
+		body = append(append(body, clue...), 0)
+	}
+	body = append(append(body, notes...), 0)
+
+	_, err = w.Write(body)
+	return err
+}
+
+// puzGrids returns the solution and blank player state, row by row, with '.'
+// for blocks and clue cells.
+func puzGrids(grid [][]domain.Cell) (solution, state []byte, err error) {
+	rows, cols, err := gridSize(grid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rows > 0xFF || cols > 0xFF {
+		return nil, nil, fmt.Errorf("%w: %dx%d exceeds 255 cells per side", ErrUnsupportedGrid, rows, cols)
+	}
+
+	solution = make([]byte, 0, rows*cols)
+	state = make([]byte, 0, rows*cols)
+	for i, row := range grid {
+		for j, cell := range row {
+			if !cell.IsLetter() {
+				solution = append(solution, '.')
+				state = append(state, '.')
+				continue
+			}
+			if len(cell.Solution) != 1 || cell.Solution[0] < 'A' || cell.Solution[0] > 'Z' {
+				return nil, nil, fmt.Errorf("%w: cell (%d,%d) solution %q is not a letter A-Z", ErrUnsupportedGrid, i, j, cell.Solution)
+			}
+			solution = append(solution, cell.Solution[0])
+			state = append(state, '-')
+		}
+	}
+	return solution, state, nil
+}
+
+// puzClues returns the prompts in .puz order: by entry number, across before
+// down for entries sharing a number.
+func puzClues(p *domain.Puzzle) ([][]byte, error) {
+	_, entries, err := numberedEntries(p)
+	if err != nil {
+		return nil, err
+	}
+
+	clues := make([][]byte, len(entries))
+	for i, entry := range entries {
+		clues[i] = latin1(entry.Prompt)
+	}
+	return clues, nil
+}
+
+// puzChecksum continues the Across Lite checksum sum over data.
+func puzChecksum(data []byte, sum uint16) uint16 {
+	for _, b := range data {
+		if sum&1 != 0 {
+			sum = sum>>1 | 0x8000
+		} else {
+			sum >>= 1
+		}
+		sum += uint16(b)
+	}
+	return sum
+}
+
+// puzTextChecksum continues sum over the text section: non-empty title,
+// author, copyright and notes including their NUL terminator, clues without.
+func puzTextChecksum(title, author, copyright []byte, clues [][]byte, notes []byte, sum uint16) uint16 {
+	for _, s := range [][]byte{title, author, copyright} {
+		if len(s) > 0 {
+			sum = puzChecksum(append(s[:len(s):len(s)], 0), sum)
+		}
+	}
+	for _, clue := range clues {
+		sum = puzChecksum(clue, sum)
+	}
+	if len(notes) > 0 {
+		sum = puzChecksum(append(notes[:len(notes):len(notes)], 0), sum)
+	}
+	return sum
+}
+
+// latin1 encodes s as ISO-8859-1, replacing characters outside it and NULs,
+// which terminate .puz strings, with '?'.
+func latin1(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r == 0 || r > 0xFF {
+			r = '?'
+		}
+		b = append(b, byte(r))
+	}
+	return b
+}
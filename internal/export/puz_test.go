@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	"lesmotsdatche/internal/domain"
+)
+
+// ringPuzzle returns a 3x3 puzzle with a clue cell in the middle:
+//
+//	C A T
+//	A # O
+//	R U E
+func ringPuzzle() *domain.Puzzle {
+	letter := func(s string) domain.Cell { return domain.Cell{Type: domain.CellTypeLetter, Solution: s} }
+	return &domain.Puzzle{
+		ID:     "ring",
+		Title:  "Café",
+		Author: "Test Author",
+		Grid: [][]domain.Cell{
+			{letter("C"), letter("A"), letter("T")},
+			{letter("A"), {Type: domain.CellTypeClue, ClueAcross: "Matou"}, letter("O")},
+			{letter("R"), letter("U"), letter("E")},
+		},
+		Clues: domain.Clues{
+			Across: []domain.Clue{
+				{Direction: domain.DirectionAcross, Prompt: "Matou", Answer: "CAT", Start: domain.Position{Row: 0, Col: 0}, Length: 3},
+				{Direction: domain.DirectionAcross, Prompt: "Voie", Answer: "RUE", Start: domain.Position{Row: 2, Col: 0}, Length: 3},
+			},
+			Down: []domain.Clue{
+				{Direction: domain.DirectionDown, Prompt: "Véhicule", Answer: "CAR", Start: domain.Position{Row: 0, Col: 0}, Length: 3},
+				{Direction: domain.DirectionDown, Prompt: "Orteil", Answer: "TOE", Start: domain.Position{Row: 0, Col: 2}, Length: 3},
+			},
+		},
+	}
+}
+
+func TestWritePUZ(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePUZ(&buf, ringPuzzle()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+	if len(data) < puzHeaderSize+18 {
+		t.Fatalf("file too short: %d bytes", len(data))
+	}
+
+	header := data[:puzHeaderSize]
+	if got := string(header[0x02:0x0E]); got != puzMagic {
+		t.Errorf("magic = %q, want %q", got, puzMagic)
+	}
+	width, height := int(header[0x2C]), int(header[0x2D])
+	if width != 3 || height != 3 {
+		t.Fatalf("size = %dx%d, want 3x3", width, height)
+	}
+	if n := binary.LittleEndian.Uint16(header[0x2E:]); n != 4 {
+		t.Errorf("clue count = %d, want 4", n)
+	}
+
+	solution := data[puzHeaderSize : puzHeaderSize+9]
+	state := data[puzHeaderSize+9 : puzHeaderSize+18]
+	if string(solution) != "CATA.ORUE" {
+		t.Errorf("solution = %q, want CATA.ORUE", solution)
+	}
+	if string(state) != "----.----" {
+		t.Errorf("state = %q, want ----.----", state)
+	}
+
+	strs := bytes.Split(data[puzHeaderSize+18:], []byte{0})
+	// title, author, copyright, 4 clues, notes, then the empty tail after the last NUL
+	if len(strs) != 9 {
+		t.Fatalf("expected 8 strings, got %d: %q", len(strs)-1, strs)
+	}
+	title, author, copyright, clues, notes := strs[0], strs[1], strs[2], strs[3:7], strs[7]
+	if string(title) != "Caf\xe9" {
+		t.Errorf("title = %q, want Latin-1 Café", title)
+	}
+	if string(author) != "Test Author" {
+		t.Errorf("author = %q", author)
+	}
+	want := [][]byte{[]byte("Matou"), []byte("V\xe9hicule"), []byte("Orteil"), []byte("Voie")}
+	if !reflect.DeepEqual(clues, want) {
+		t.Errorf("clues = %q, want %q (1A, 1D, 2D, 3A)", clues, want)
+	}
+
+	// Re-derive every checksum from the parsed sections
+	cib := puzChecksum(header[0x2C:puzHeaderSize], 0)
+	if got := binary.LittleEndian.Uint16(header[0x0E:]); got != cib {
+		t.Errorf("CIB checksum = %#04x, want %#04x", got, cib)
+	}
+	sum := puzChecksum(solution, cib)
+	sum = puzChecksum(state, sum)
+	sum = puzTextChecksum(title, author, copyright, clues, notes, sum)
+	if got := binary.LittleEndian.Uint16(header[0x00:]); got != sum {
+		t.Errorf("file checksum = %#04x, want %#04x", got, sum)
+	}
+	sums := []uint16{cib, puzChecksum(solution, 0), puzChecksum(state, 0),
+		puzTextChecksum(title, author, copyright, clues, notes, 0)}
+	for i, s := range sums {
+		if low := header[0x10+i] ^ byte(s); low != "ICHE"[i] {
+			t.Errorf("masked low checksum %d unmasks to %q", i, low)
+		}
+		if high := header[0x14+i] ^ byte(s>>8); high != "ATED"[i] {
+			t.Errorf("masked high checksum %d unmasks to %q", i, high)
+		}
+	}
+}
+
+func TestWritePUZ_Errors(t *testing.T) {
+	missing := ringPuzzle()
+	missing.Clues.Down = missing.Clues.Down[:1]
+	if err := WritePUZ(&bytes.Buffer{}, missing); !errors.Is(err, ErrMissingClue) {
+		t.Errorf("expected ErrMissingClue, got %v", err)
+	}
+
+	unfilled := ringPuzzle()
+	unfilled.Grid[2][1].Solution = ""
+	if err := WritePUZ(&bytes.Buffer{}, unfilled); !errors.Is(err, ErrUnsupportedGrid) {
+		t.Errorf("expected ErrUnsupportedGrid, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"lesmotsdatche/internal/domain"
 	"lesmotsdatche/internal/generator/languagepack"
@@ -18,6 +19,9 @@ type GeneratorConfig struct {
 	MaxCluesPerBatch int
 	ClueStyles       []string // e.g., ["definition", "wordplay", "cultural"]
 	DifficultyRange  [2]int   // Min and max difficulty to generate
+	MinClueWords     int      // Shorter clues are only selected when no candidate meets it
+	ClueLanguage     string   // Language code of the clue prompts, e.g. "en" for learners ("" = puzzle language)
+	FallbackClues    bool     // Give answers the LLM left without clues a placeholder for editorial review
 }
 
 // DefaultGeneratorConfig returns default configuration.
@@ -27,22 +31,45 @@ func DefaultGeneratorConfig() GeneratorConfig {
 		MaxCluesPerBatch: 10,
 		ClueStyles:       []string{"definition", "wordplay", "cultural"},
 		DifficultyRange:  [2]int{1, 5},
+		MinClueWords:     1,
+		FallbackClues:    true,
 	}
 }
 
+// StylePlaceholder is the style of placeholder clues given to answers the LLM
+// returned no clue for. They must be rewritten by an editor before publishing.
+const StylePlaceholder = "placeholder"
+
+// placeholderNote is the editorial note carried by placeholder clues.
+const placeholderNote = "placeholder clue, needs editorial review"
+
+// defaultPlaceholderClue is the placeholder prompt of packs that set none; it
+// is language neutral so no pack gets another language's wording.
+const defaultPlaceholderClue = "(?)"
+
 // Generator generates clues using an LLM.
 type Generator struct {
-	client   *llm.ValidatingClient
-	langPack languagepack.LanguagePack
-	config   GeneratorConfig
+	client     *llm.ValidatingClient
+	langPack   languagepack.LanguagePack
+	promptPack languagepack.LanguagePack // Pack whose prompts write the clues, per ClueLanguage
+	config     GeneratorConfig
 }
 
-// NewGenerator creates a new clue generator.
+// NewGenerator creates a new clue generator. An unknown ClueLanguage falls
+// back to the puzzle language.
 func NewGenerator(client *llm.ValidatingClient, langPack languagepack.LanguagePack, config GeneratorConfig) *Generator {
+	promptPack := langPack
+	if config.ClueLanguage != "" && config.ClueLanguage != langPack.Code() {
+		if pack, ok := languagepack.DefaultRegistry().Get(config.ClueLanguage); ok {
+			promptPack = pack
+		}
+	}
+
 	return &Generator{
-		client:   client,
-		langPack: langPack,
-		config:   config,
+		client:     client,
+		langPack:   langPack,
+		promptPack: promptPack,
+		config:     config,
 	}
 }
 
@@ -62,19 +89,9 @@ type GeneratedClues struct {
 
 // GenerateCluesForSlot generates clue candidates for a single slot.
 func (g *Generator) GenerateCluesForSlot(ctx context.Context, answer string, thm *theme.Theme, targetDifficulty int) (*GeneratedClues, error) {
-	prompts := g.langPack.Prompts()
-
-	systemPrompt := prompts.ClueGeneration
-	if systemPrompt == "" {
-		systemPrompt = defaultClueSystemPrompt(g.langPack.Code())
-	}
-
-	styleHint := prompts.ClueStyle
-	if styleHint == "" {
-		styleHint = defaultClueStyle(g.langPack.Code())
-	}
+	systemPrompt, styleHint := g.cluePrompts()
 
-	userPrompt := buildCluePrompt(answer, thm, targetDifficulty, styleHint, g.langPack.Code())
+	userPrompt := buildCluePrompt(answer, thm, targetDifficulty, styleHint, g.promptPack.Code())
 
 	req := llm.Request{
 		SystemPrompt: systemPrompt,
@@ -94,7 +111,14 @@ func (g *Generator) GenerateCluesForSlot(ctx context.Context, answer string, thm
 	}, nil
 }
 
-// GenerateCluesForPuzzle generates clues for all slots in a puzzle.
+// BatchSize returns the number of slots clued per LLM call.
+func (g *Generator) BatchSize() int {
+	return g.config.MaxCluesPerBatch
+}
+
+// GenerateCluesForPuzzle generates clues for all slots in a puzzle. Answers
+// the LLM skipped are requested again in a follow-up batch; those still
+// without a clue get a placeholder if FallbackClues is set.
 func (g *Generator) GenerateCluesForPuzzle(ctx context.Context, slots []SlotInfo, thm *theme.Theme) (map[int]*GeneratedClues, error) {
 	results := make(map[int]*GeneratedClues)
 
@@ -116,9 +140,93 @@ func (g *Generator) GenerateCluesForPuzzle(ctx context.Context, slots []SlotInfo
 		}
 	}
 
+	// Ask once more for the answers the LLM skipped, in batches of just those
+	missing := missingClues(slots, results)
+	for i := 0; i < len(missing); i += g.config.MaxCluesPerBatch {
+		end := min(i+g.config.MaxCluesPerBatch, len(missing))
+
+		batchResults, err := g.generateBatch(ctx, missing[i:end], thm)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("follow-up batch %d failed: %w", i/g.config.MaxCluesPerBatch, err)
+			}
+			break // Best effort: the rest keep no clue or get a placeholder
+		}
+
+		for slotID, clues := range batchResults {
+			if hasPrompt(clues) {
+				results[slotID] = clues
+			}
+		}
+	}
+
+	if g.config.FallbackClues {
+		for _, slot := range slots {
+			if !hasPrompt(results[slot.ID]) {
+				results[slot.ID] = g.placeholderClues(slot)
+			}
+		}
+	}
+
 	return results, nil
 }
 
+// missingClues returns the slots without a non-empty clue in results.
+func missingClues(slots []SlotInfo, results map[int]*GeneratedClues) []SlotInfo {
+	var missing []SlotInfo
+	for _, slot := range slots {
+		if !hasPrompt(results[slot.ID]) {
+			missing = append(missing, slot)
+		}
+	}
+	return missing
+}
+
+// hasPrompt reports whether clues has a candidate with a non-empty prompt.
+func hasPrompt(clues *GeneratedClues) bool {
+	if clues == nil {
+		return false
+	}
+	for _, c := range clues.Candidates {
+		if strings.TrimSpace(c.Prompt) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholderClues returns a single placeholder candidate for an answer with
+// no generated clue, in the clue language pack's wording.
+func (g *Generator) placeholderClues(slot SlotInfo) *GeneratedClues {
+	prompt := g.promptPack.Prompts().PlaceholderClue
+	if prompt == "" {
+		prompt = defaultPlaceholderClue
+	}
+
+	return &GeneratedClues{
+		Answer: slot.Answer,
+		Candidates: []ClueCandidate{{
+			Prompt:     prompt,
+			Style:      StylePlaceholder,
+			Difficulty: slot.TargetDifficulty,
+			Notes:      placeholderNote,
+		}},
+	}
+}
+
+// IsPlaceholder reports whether an answer's clues are only a placeholder.
+func IsPlaceholder(clues *GeneratedClues) bool {
+	if clues == nil || len(clues.Candidates) == 0 {
+		return false
+	}
+	for _, c := range clues.Candidates {
+		if c.Style != StylePlaceholder {
+			return false
+		}
+	}
+	return true
+}
+
 // SlotInfo provides information needed to generate a clue.
 type SlotInfo struct {
 	ID               int
@@ -129,14 +237,9 @@ type SlotInfo struct {
 }
 
 func (g *Generator) generateBatch(ctx context.Context, slots []SlotInfo, thm *theme.Theme) (map[int]*GeneratedClues, error) {
-	prompts := g.langPack.Prompts()
+	systemPrompt, styleHint := g.cluePrompts()
 
-	systemPrompt := prompts.ClueGeneration
-	if systemPrompt == "" {
-		systemPrompt = defaultClueSystemPrompt(g.langPack.Code())
-	}
-
-	userPrompt := buildBatchCluePrompt(slots, thm, g.langPack.Code())
+	userPrompt := buildBatchCluePrompt(slots, thm, styleHint, g.promptPack.Code())
 
 	req := llm.Request{
 		SystemPrompt: systemPrompt,
@@ -173,11 +276,22 @@ func (g *Generator) SelectBestClue(clues *GeneratedClues, targetDifficulty int,
 		return nil
 	}
 
+	// Skip clues below the minimum word count unless none meet it
+	longEnough := 0
+	for _, c := range clues.Candidates {
+		if ClueWordCount(c.Prompt) >= g.config.MinClueWords {
+			longEnough++
+		}
+	}
+
 	var best *ClueCandidate
 	bestScore := -1.0
 
 	for i := range clues.Candidates {
 		candidate := &clues.Candidates[i]
+		if longEnough > 0 && ClueWordCount(candidate.Prompt) < g.config.MinClueWords {
+			continue
+		}
 		score := g.scoreCandidate(candidate, targetDifficulty, preferredStyles)
 		if score > bestScore {
 			bestScore = score
@@ -188,6 +302,34 @@ func (g *Generator) SelectBestClue(clues *GeneratedClues, targetDifficulty int,
 	return best
 }
 
+// ClueWordCount returns the number of words in a clue, ignoring standalone
+// punctuation. Hyphenated and elided forms count as one word.
+func ClueWordCount(prompt string) int {
+	count := 0
+	for _, field := range strings.Fields(prompt) {
+		if strings.IndexFunc(field, unicode.IsLetter) >= 0 || strings.IndexFunc(field, unicode.IsDigit) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// AmbiguityNote returns an editorial note for clues too short to pin down
+// their answer (a single word, or fewer than MinClueWords), or "" otherwise.
+func (g *Generator) AmbiguityNote(prompt string) string {
+	words := ClueWordCount(prompt)
+	if words == 0 {
+		return ""
+	}
+	if words == 1 {
+		return "single-word clue may admit several answers"
+	}
+	if words < g.config.MinClueWords {
+		return fmt.Sprintf("clue has %d words, below the minimum of %d", words, g.config.MinClueWords)
+	}
+	return ""
+}
+
 func (g *Generator) scoreCandidate(candidate *ClueCandidate, targetDifficulty int, preferredStyles []string) float64 {
 	score := 1.0
 
@@ -226,6 +368,24 @@ type batchClueResponse struct {
 	} `json:"slots"`
 }
 
+// cluePrompts returns the clue system prompt and style hint, preferring the
+// clue language pack's templates and falling back to the built-in defaults.
+func (g *Generator) cluePrompts() (systemPrompt, styleHint string) {
+	prompts := g.promptPack.Prompts()
+
+	systemPrompt = prompts.ClueGeneration
+	if systemPrompt == "" {
+		systemPrompt = defaultClueSystemPrompt(g.promptPack.Code())
+	}
+
+	styleHint = prompts.ClueStyle
+	if styleHint == "" {
+		styleHint = defaultClueStyle(g.promptPack.Code())
+	}
+
+	return systemPrompt, styleHint
+}
+
 func defaultClueSystemPrompt(langCode string) string {
 	if langCode == "fr" {
 		return `Tu es un auteur de MOTS FLÉCHÉS français.
@@ -300,7 +460,7 @@ func buildCluePrompt(answer string, thm *theme.Theme, targetDifficulty int, styl
 	return sb.String()
 }
 
-func buildBatchCluePrompt(slots []SlotInfo, thm *theme.Theme, langCode string) string {
+func buildBatchCluePrompt(slots []SlotInfo, thm *theme.Theme, styleHint string, langCode string) string {
 	var sb strings.Builder
 
 	if langCode == "fr" {
@@ -319,6 +479,12 @@ func buildBatchCluePrompt(slots []SlotInfo, thm *theme.Theme, langCode string) s
 				slot.Number, dir, slot.Answer, len(slot.Answer), slot.TargetDifficulty))
 		}
 
+		if styleHint != "" {
+			sb.WriteString("\n")
+			sb.WriteString(styleHint)
+			sb.WriteString("\n")
+		}
+
 		sb.WriteString(`
 RAPPEL: Définitions TRÈS COURTES (2-4 mots max). Style mots fléchés.
 Exemples: "Fruit jaune", "Capitale française", "Métal précieux"
@@ -341,6 +507,12 @@ Réponds en JSON:
 				slot.Number, dir, slot.Answer, len(slot.Answer), slot.TargetDifficulty))
 		}
 
+		if styleHint != "" {
+			sb.WriteString("\n")
+			sb.WriteString(styleHint)
+			sb.WriteString("\n")
+		}
+
 		sb.WriteString(`
 REMINDER: VERY SHORT clues (2-4 words max). Arrow crossword style.
 Examples: "Yellow fruit", "French capital", "Precious metal"
@@ -2,6 +2,7 @@ package clue
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"lesmotsdatche/internal/domain"
@@ -103,6 +104,91 @@ func TestGenerator_GenerateCluesForPuzzle(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateCluesForPuzzle_Placeholder(t *testing.T) {
+	// The batch response omits NICHE
+	mockResponse := `{"slots": [
+		{"answer": "CHAT", "clues": [{"prompt": "Animal qui miaule", "style": "definition", "difficulty": 1}]},
+		{"answer": "CHIEN", "clues": [{"prompt": "Ami de l'homme", "style": "definition", "difficulty": 1}]}
+	]}`
+	slots := []SlotInfo{
+		{ID: 0, Answer: "CHAT", Direction: domain.DirectionAcross, Number: 1, TargetDifficulty: 2},
+		{ID: 1, Answer: "CHIEN", Direction: domain.DirectionDown, Number: 2, TargetDifficulty: 2},
+		{ID: 2, Answer: "NICHE", Direction: domain.DirectionAcross, Number: 3, TargetDifficulty: 3},
+	}
+
+	gen := NewGenerator(llm.NewValidatingClient(llm.NewMockClient(mockResponse), llm.DefaultConfig()), languagepack.NewFrenchPack(), DefaultGeneratorConfig())
+	results, err := gen.GenerateCluesForPuzzle(context.Background(), slots, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	placeholder := results[2]
+	if !IsPlaceholder(placeholder) {
+		t.Fatalf("expected a placeholder clue for NICHE, got %+v", placeholder)
+	}
+	c := placeholder.Candidates[0]
+	if c.Prompt != "(définition manquante)" || c.Style != StylePlaceholder || c.Difficulty != 3 || c.Notes == "" {
+		t.Errorf("unexpected placeholder candidate: %+v", c)
+	}
+	if IsPlaceholder(results[0]) || IsPlaceholder(results[1]) {
+		t.Error("generated clues should not be placeholders")
+	}
+
+	config := DefaultGeneratorConfig()
+	config.FallbackClues = false
+	gen = NewGenerator(llm.NewValidatingClient(llm.NewMockClient(mockResponse), llm.DefaultConfig()), languagepack.NewFrenchPack(), config)
+	results, err = gen.GenerateCluesForPuzzle(context.Background(), slots, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := results[2]; ok {
+		t.Error("expected no clues for NICHE with fallback disabled")
+	}
+}
+
+func TestGenerator_GenerateCluesForPuzzle_FollowUp(t *testing.T) {
+	// The batch omits NICHE and leaves CHIEN's prompt empty; the follow-up provides both
+	first := `{"slots": [
+		{"answer": "CHAT", "clues": [{"prompt": "Animal qui miaule", "style": "definition", "difficulty": 1}]},
+		{"answer": "CHIEN", "clues": [{"prompt": "", "style": "definition", "difficulty": 1}]}
+	]}`
+	followUp := `{"slots": [
+		{"answer": "CHIEN", "clues": [{"prompt": "Ami de l'homme", "style": "definition", "difficulty": 1}]},
+		{"answer": "NICHE", "clues": [{"prompt": "Abri du chien", "style": "definition", "difficulty": 3}]}
+	]}`
+	slots := []SlotInfo{
+		{ID: 0, Answer: "CHAT", Direction: domain.DirectionAcross, Number: 1, TargetDifficulty: 2},
+		{ID: 1, Answer: "CHIEN", Direction: domain.DirectionDown, Number: 2, TargetDifficulty: 2},
+		{ID: 2, Answer: "NICHE", Direction: domain.DirectionAcross, Number: 3, TargetDifficulty: 3},
+	}
+
+	mock := llm.NewMockClient(first, followUp)
+	gen := NewGenerator(llm.NewValidatingClient(mock, llm.DefaultConfig()), languagepack.NewFrenchPack(), DefaultGeneratorConfig())
+	results, err := gen.GenerateCluesForPuzzle(context.Background(), slots, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mock.CallCount() != 2 {
+		t.Fatalf("expected one follow-up call, got %d calls", mock.CallCount())
+	}
+	followUpPrompt := mock.Calls[1].Prompt
+	if strings.Contains(followUpPrompt, "CHAT") || !strings.Contains(followUpPrompt, "CHIEN") || !strings.Contains(followUpPrompt, "NICHE") {
+		t.Errorf("follow-up should request only CHIEN and NICHE:\n%s", followUpPrompt)
+	}
+
+	for _, slot := range slots {
+		clues := results[slot.ID]
+		if clues == nil || IsPlaceholder(clues) {
+			t.Errorf("%s: expected a generated clue, got %+v", slot.Answer, clues)
+			continue
+		}
+		if best := gen.SelectBestClue(clues, slot.TargetDifficulty, nil); best == nil || best.Prompt == "" {
+			t.Errorf("%s: expected a non-empty prompt, got %+v", slot.Answer, best)
+		}
+	}
+}
+
 func TestGenerator_SelectBestClue(t *testing.T) {
 	gen := NewGenerator(nil, languagepack.NewFrenchPack(), DefaultGeneratorConfig())
 
@@ -162,6 +248,47 @@ func TestGenerator_SelectBestClue_Empty(t *testing.T) {
 	}
 }
 
+func TestGenerator_SelectBestClue_MinClueWords(t *testing.T) {
+	config := DefaultGeneratorConfig()
+	config.MinClueWords = 2
+	gen := NewGenerator(nil, languagepack.NewFrenchPack(), config)
+
+	clues := &GeneratedClues{
+		Answer: "OR",
+		Candidates: []ClueCandidate{
+			{Prompt: "Métal", Style: "definition", Difficulty: 3},
+			{Prompt: "Métal précieux", Style: "definition", Difficulty: 2},
+		},
+	}
+
+	// The one-word clue scores higher on difficulty but is too short
+	best := gen.SelectBestClue(clues, 3, []string{"definition"})
+	if best == nil || best.Prompt != "Métal précieux" {
+		t.Errorf("expected 'Métal précieux', got %+v", best)
+	}
+
+	// Falls back to short clues when nothing meets the minimum
+	clues.Candidates = clues.Candidates[:1]
+	best = gen.SelectBestClue(clues, 3, []string{"definition"})
+	if best == nil || best.Prompt != "Métal" {
+		t.Errorf("expected fallback to 'Métal', got %+v", best)
+	}
+}
+
+func TestGenerator_AmbiguityNote(t *testing.T) {
+	gen := NewGenerator(nil, languagepack.NewFrenchPack(), DefaultGeneratorConfig())
+
+	if note := gen.AmbiguityNote("Métal."); note == "" {
+		t.Error("expected an ambiguity note for a single-word clue")
+	}
+	if note := gen.AmbiguityNote("Métal précieux"); note != "" {
+		t.Errorf("expected no ambiguity note for a two-word clue, got %q", note)
+	}
+	if n := ClueWordCount("Porte-monnaie — d'usage"); n != 2 {
+		t.Errorf("expected 2 words, got %d", n)
+	}
+}
+
 func TestDefaultClueSystemPrompt(t *testing.T) {
 	frPrompt := defaultClueSystemPrompt("fr")
 	if frPrompt == "" {
@@ -211,7 +338,7 @@ func TestBuildBatchCluePrompt(t *testing.T) {
 		Description: "Test description",
 	}
 
-	prompt := buildBatchCluePrompt(slots, thm, "fr")
+	prompt := buildBatchCluePrompt(slots, thm, "STYLE MOTS FLÉCHÉS", "fr")
 
 	if !containsSubstring(prompt, "MOT") {
 		t.Error("prompt should contain MOT")
@@ -225,6 +352,116 @@ func TestBuildBatchCluePrompt(t *testing.T) {
 	if !containsSubstring(prompt, "vertical") {
 		t.Error("prompt should contain direction")
 	}
+	if !containsSubstring(prompt, "STYLE MOTS FLÉCHÉS") {
+		t.Error("prompt should contain style hint")
+	}
+}
+
+// customPromptPack overrides the French pack's prompt templates.
+type customPromptPack struct {
+	*languagepack.FrenchPack
+	prompts languagepack.PromptTemplates
+}
+
+func (p *customPromptPack) Prompts() languagepack.PromptTemplates {
+	return p.prompts
+}
+
+func TestGenerator_PackPromptOverride(t *testing.T) {
+	const sentinelSystem = "SENTINEL CLUE SYSTEM PROMPT"
+	const sentinelStyle = "SENTINEL CLUE STYLE"
+
+	mock := llm.NewMockClient(
+		`{"clues": [{"prompt": "Félin", "style": "definition", "difficulty": 1}]}`,
+		`{"slots": [{"answer": "CHAT", "clues": [{"prompt": "Félin", "style": "definition", "difficulty": 1}]}]}`,
+	)
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+	pack := &customPromptPack{
+		FrenchPack: languagepack.NewFrenchPack(),
+		prompts: languagepack.PromptTemplates{
+			ClueGeneration: sentinelSystem,
+			ClueStyle:      sentinelStyle,
+		},
+	}
+
+	gen := NewGenerator(validatingClient, pack, DefaultGeneratorConfig())
+
+	if _, err := gen.GenerateCluesForSlot(context.Background(), "CHAT", nil, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slots := []SlotInfo{{ID: 0, Answer: "CHAT", Direction: domain.DirectionAcross, Number: 1, TargetDifficulty: 2}}
+	if _, err := gen.GenerateCluesForPuzzle(context.Background(), slots, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mock.Calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(mock.Calls))
+	}
+	for i, call := range mock.Calls {
+		if call.SystemPrompt != sentinelSystem {
+			t.Errorf("call %d: expected pack system prompt, got %q", i, call.SystemPrompt)
+		}
+		if !containsSubstring(call.Prompt, sentinelStyle) {
+			t.Errorf("call %d: expected pack style hint in prompt", i)
+		}
+	}
+}
+
+func TestGenerator_PlaceholderFromPack(t *testing.T) {
+	slot := SlotInfo{ID: 0, Answer: "CHAT", Direction: domain.DirectionAcross, Number: 1, TargetDifficulty: 2}
+	tests := []struct {
+		name     string
+		prompts  languagepack.PromptTemplates
+		expected string
+	}{
+		{"pack wording", languagepack.PromptTemplates{PlaceholderClue: "(définition à venir)"}, "(définition à venir)"},
+		{"pack without wording", languagepack.PromptTemplates{}, defaultPlaceholderClue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pack := &customPromptPack{FrenchPack: languagepack.NewFrenchPack(), prompts: tt.prompts}
+			gen := NewGenerator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()), pack, DefaultGeneratorConfig())
+
+			if got := gen.placeholderClues(slot).Candidates[0].Prompt; got != tt.expected {
+				t.Errorf("expected placeholder %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerator_ClueLanguage(t *testing.T) {
+	mock := llm.NewMockClient(
+		`{"slots": [{"answer": "CHAT", "clues": [{"prompt": "Pet that meows", "style": "definition", "difficulty": 1}]}]}`,
+	)
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+	config := DefaultGeneratorConfig()
+	config.ClueLanguage = "en"
+
+	gen := NewGenerator(validatingClient, languagepack.NewFrenchPack(), config)
+
+	slots := []SlotInfo{{ID: 0, Answer: "CHAT", Direction: domain.DirectionAcross, Number: 1, TargetDifficulty: 2}}
+	results, err := gen.GenerateCluesForPuzzle(context.Background(), slots, &theme.Theme{Title: "Animaux"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mock.Calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(mock.Calls))
+	}
+	call := mock.Calls[0]
+	if call.SystemPrompt != languagepack.NewEnglishPack().Prompts().ClueGeneration {
+		t.Errorf("expected English clue system prompt, got %q", call.SystemPrompt)
+	}
+	if !containsSubstring(call.Prompt, "Generate clues for the following words") {
+		t.Error("expected English batch prompt")
+	}
+	if !containsSubstring(call.Prompt, "CHAT (4 letters") {
+		t.Error("expected French answer in the prompt")
+	}
+	if results[0] == nil || results[0].Answer != "CHAT" {
+		t.Errorf("expected clues for French answer CHAT, got %+v", results[0])
+	}
 }
 
 func TestDefaultGeneratorConfig(t *testing.T) {
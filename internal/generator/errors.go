@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFillTimeout is returned when an attempt's candidates and grid fill
+// exceed Config.FillTimeout.
+var ErrFillTimeout = errors.New("fill phase timed out")
+
+// ErrClueTimeout is returned when an attempt's clue generation exceeds
+// Config.ClueTimeout.
+var ErrClueTimeout = errors.New("clue phase timed out")
+
+// GenerationError reports a failure in a specific phase of the generation pipeline.
+type GenerationError struct {
+	Phase string // Pipeline phase that failed (e.g., "validate", "theme", "fill")
+	Err   error  // Underlying cause
+
+	// Partial holds what the attempt produced before the phase failed (e.g.
+	// the theme and filled grid when clue generation timed out), if anything.
+	Partial *GenerateResult
+}
+
+func (e *GenerationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Phase, e.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (e *GenerationError) Unwrap() error {
+	return e.Err
+}
+
+// GenerationFailure is returned when every generation attempt failed. It
+// carries the highest-scoring attempt that reached QA, if any, so callers can
+// inspect the closest-to-acceptable puzzle and its QA breakdown.
+type GenerationFailure struct {
+	GenerationID string
+	BestResult   *GenerateResult // Nil if no attempt reached QA
+	Attempts     int
+	Err          error // Error of the last attempt
+}
+
+func (e *GenerationFailure) Error() string {
+	return fmt.Sprintf("generation %s failed after %d attempts: %v", e.GenerationID, e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *GenerationFailure) Unwrap() error {
+	return e.Err
+}
@@ -2,12 +2,33 @@
 package fill
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"math/rand"
 	"sort"
+	"strings"
 
 	"lesmotsdatche/internal/domain"
 )
 
+// ErrBuildStarted is returned when a word is pre-placed after building began.
+var ErrBuildStarted = errors.New("build already started")
+
+// ErrWordDoesNotFit is returned when a pre-placed word cannot go where requested.
+var ErrWordDoesNotFit = errors.New("word does not fit")
+
+// ErrInsufficientCandidates is reported by Build when fewer distinct
+// candidates fit the grid than a successful build must place.
+var ErrInsufficientCandidates = errors.New("insufficient candidates")
+
+// ErrNoViableCrossings is reported by Build when there are enough candidates
+// but too few of them interlock to place the minimum number of words.
+var ErrNoViableCrossings = errors.New("no viable crossings")
+
+// minBuildWords is the number of placed words for a build to succeed.
+const minBuildWords = 8
+
 // GridBuilder constructs a crossword grid word-by-word.
 // This follows the mots fléchés best practice: pick words first, build grid around them.
 type GridBuilder struct {
@@ -17,15 +38,37 @@ type GridBuilder struct {
 	maxRows     int // Maximum allowed (with buffer)
 	maxCols     int
 	grid        [][]rune
-	placed      []placedWord
+	placed      []PlacedWord
 	usedWords   map[string]bool
 	letterIndex map[rune][]letterPos // Fast lookup: letter -> positions in placed words
+	weights     map[string]float64   // Per-word score multipliers (optional)
+	vowelWeight float64              // Exponent applied to the vowel ratio in scoreWords
 	// Bounding box tracking for compact placement
 	minRow, maxRow int
 	minCol, maxCol int
+	// Step state for PlaceNext
+	phase      buildPhase
+	selected   []scoredWord     // Remaining words for the grow phase
+	fillWords  []string         // Short words, then candidates, for gap filling
+	byLength   map[int][]string // Gap-fill words by length, built on entering the fill phase
+	failures   int              // Consecutive grow-phase placement failures
+	maxFailure int
+	fillPasses int
 }
 
-type placedWord struct {
+// buildPhase tracks where PlaceNext is in the construction sequence.
+type buildPhase int
+
+const (
+	phaseStart    buildPhase = iota // Nothing placed yet
+	phaseSeedDown                   // Center across word placed, looking for a crossing down word
+	phaseGrow                       // Compact placement of selected words
+	phaseFillGaps                   // Filling leftover gaps with short words
+	phaseDone
+)
+
+// PlacedWord is a word placed on the builder grid, starting at Row, Col.
+type PlacedWord struct {
 	Word      string
 	Row, Col  int
 	Direction domain.Direction
@@ -36,12 +79,18 @@ type letterPos struct {
 	charIdx int // Position within the word
 }
 
+// DefaultTargetWords is the number of words the builder aims for when
+// BuilderConfig.TargetWords is zero.
+const DefaultTargetWords = 15
+
 // BuilderConfig configures the grid builder.
 type BuilderConfig struct {
-	MaxRows     int   // Target grid rows
-	MaxCols     int   // Target grid columns
-	TargetWords int   // Target number of words (default 15)
-	Seed        int64 // Random seed (0 = random)
+	MaxRows     int                // Target grid rows
+	MaxCols     int                // Target grid columns
+	TargetWords int                // Target number of words (default 15)
+	Seed        int64              // Random seed (0 = random)
+	Weights     map[string]float64 // Per-word score multiplier, e.g. lexicon frequency (nil = uniform)
+	VowelWeight float64            // Strength of the vowel preference; lower favors consonant-rich words (0 = 1.0)
 }
 
 // NewGridBuilder creates a new word-first grid builder.
@@ -54,7 +103,10 @@ func NewGridBuilder(cfg BuilderConfig) *GridBuilder {
 	}
 
 	if cfg.TargetWords == 0 {
-		cfg.TargetWords = 15
+		cfg.TargetWords = DefaultTargetWords
+	}
+	if cfg.VowelWeight <= 0 {
+		cfg.VowelWeight = 1.0
 	}
 
 	// Use target size as the working area - minimal buffer for density
@@ -75,6 +127,8 @@ func NewGridBuilder(cfg BuilderConfig) *GridBuilder {
 		maxCols:     targetCols + 1,
 		usedWords:   make(map[string]bool),
 		letterIndex: make(map[rune][]letterPos),
+		weights:     cfg.Weights,
+		vowelWeight: cfg.VowelWeight,
 		minRow:      targetRows, // Will be updated on first placement
 		maxRow:      0,
 		minCol:      targetCols,
@@ -87,19 +141,178 @@ type BuildResult struct {
 	Grid    [][]domain.Cell
 	Words   []string
 	Success bool
+	Err     error // Why the build failed, wrapping ErrInsufficientCandidates or ErrNoViableCrossings; nil on success
 }
 
 // Build constructs a grid from a list of candidate words.
 // Creates a dense, compact grid with gap filling to eliminate dead blocks.
 func (b *GridBuilder) Build(candidates []string) *BuildResult {
-	// Step 1: Score and select best words for crossability
-	scored := b.scoreWords(candidates)
-	selected := b.selectBestWords(scored, 40)
+	for {
+		if _, ok := b.PlaceNext(candidates); !ok {
+			break
+		}
+	}
+
+	// Success if we placed enough words - dead blocks are OK for now
+	// Gap filling is best-effort, we'll improve density iteratively
+	result := &BuildResult{
+		Grid:    b.Current(),
+		Words:   b.getPlacedWords(),
+		Success: len(b.placed) >= minBuildWords,
+	}
+	if !result.Success {
+		result.Err = b.buildError(candidates)
+	}
+	return result
+}
+
+// buildError explains why a build placed fewer than minBuildWords words:
+// either the candidates could never supply that many words, or they could but
+// did not cross each other enough.
+func (b *GridBuilder) buildError(candidates []string) error {
+	maxLen := max(b.targetRows, b.targetCols)
+	usable := make(map[string]bool)
+	for word := range b.usedWords {
+		usable[word] = true // Pre-placed words count even if not candidates
+	}
+	for _, word := range candidates {
+		if len(word) >= 2 && len(word) <= maxLen {
+			usable[word] = true
+		}
+	}
+
+	if len(usable) < minBuildWords {
+		return fmt.Errorf("%w: %d usable words of 2-%d letters, need %d",
+			ErrInsufficientCandidates, len(usable), maxLen, minBuildWords)
+	}
+	return fmt.Errorf("%w: placed %d of %d usable words, need %d",
+		ErrNoViableCrossings, len(b.placed), len(usable), minBuildWords)
+}
+
+// Current returns a snapshot of the grid built so far, trimmed to the placed
+// words plus a one-cell clue border. Empty cells are blocks.
+func (b *GridBuilder) Current() [][]domain.Cell {
+	return b.toTemplate()
+}
+
+// Origin returns the builder coordinates of the top-left cell of the grid
+// returned by Current, so builder positions (PrePlace, PlacedWord) can be
+// mapped onto it.
+func (b *GridBuilder) Origin() (row, col int) {
+	row, col = b.minRow, b.minCol
+	if row > 0 {
+		row--
+	}
+	if col > 0 {
+		col--
+	}
+	return row, col
+}
+
+// PrePlace pins a word at row, col (builder coordinates, within the target
+// size and its one-cell clue border) before Build or PlaceNext runs. The
+// build then grows around pinned words instead of placing its own center cross.
+func (b *GridBuilder) PrePlace(word string, row, col int, dir domain.Direction) error {
+	if b.phase != phaseStart {
+		return ErrBuildStarted
+	}
+	if len(word) < 2 {
+		return fmt.Errorf("%w: %q is too short", ErrWordDoesNotFit, word)
+	}
+	for _, c := range word {
+		if c < 'A' || c > 'Z' {
+			return fmt.Errorf("%w: %q must be uppercase A-Z", ErrWordDoesNotFit, word)
+		}
+	}
+	if b.usedWords[word] {
+		return fmt.Errorf("%w: %q is already placed", ErrWordDoesNotFit, word)
+	}
+
+	b.initGrid()
+	if !b.canPlace(word, row, col, dir) {
+		return fmt.Errorf("%w: %q %s at (%d,%d)", ErrWordDoesNotFit, word, dir, row, col)
+	}
+	b.placeWord(word, row, col, dir)
+	return nil
+}
+
+// PlaceNext places one more word and returns it, or false once no further word
+// fits. The first call starts the build from candidates; later calls continue
+// it and ignore their argument. Words are placed in the same order as Build:
+// a center cross, then compact placements, then gap fillers.
+func (b *GridBuilder) PlaceNext(candidates []string) (*PlacedWord, bool) {
+	if b.phase == phaseStart {
+		pinned := len(b.placed) > 0
+		b.start(candidates)
+		if pinned {
+			// A pinned across word stands in for the center seed
+			if b.placed[0].Direction == domain.DirectionAcross {
+				b.phase = phaseSeedDown
+			} else {
+				b.enterGrow()
+			}
+		} else if pw := b.placeSeedAcross(); pw != nil {
+			b.phase = phaseSeedDown
+			return pw, true
+		} else {
+			b.enterGrow()
+		}
+	}
+
+	if b.phase == phaseSeedDown {
+		pw := b.placeSeedDown()
+		b.enterGrow()
+		if pw != nil {
+			return pw, true
+		}
+	}
+
+	if b.phase == phaseGrow {
+		if pw := b.growOne(); pw != nil {
+			return pw, true
+		}
+		b.enterFillGaps()
+	}
 
-	// Also collect short words (2-4 letters) for gap filling
+	if b.phase == phaseFillGaps {
+		if pw := b.fillOneGap(); pw != nil {
+			return pw, true
+		}
+		b.phase = phaseDone
+	}
+
+	return nil, false
+}
+
+// start scores and selects words and initializes the grid, keeping any
+// pre-placed words.
+func (b *GridBuilder) start(candidates []string) {
+	// Score and select best words for crossability
+	b.selected = b.selectBestWords(b.scoreWords(candidates), 40)
+	if len(b.usedWords) > 0 {
+		unused := b.selected[:0]
+		for _, sw := range b.selected {
+			if !b.usedWords[sw.word] {
+				unused = append(unused, sw)
+			}
+		}
+		b.selected = unused
+	}
+
+	// Short words (2-4 letters) come first for gap filling, then all candidates
 	shortWords := b.collectShortWords(candidates)
+	b.fillWords = make([]string, 0, len(candidates)+len(shortWords))
+	b.fillWords = append(b.fillWords, shortWords...)
+	b.fillWords = append(b.fillWords, candidates...)
+
+	b.initGrid()
+}
 
-	// Step 2: Initialize grid
+// initGrid allocates an empty working grid unless one already exists.
+func (b *GridBuilder) initGrid() {
+	if b.grid != nil {
+		return
+	}
 	b.grid = make([][]rune, b.maxRows)
 	for i := range b.grid {
 		b.grid[i] = make([]rune, b.maxCols)
@@ -107,109 +320,106 @@ func (b *GridBuilder) Build(candidates []string) *BuildResult {
 			b.grid[i][j] = '.'
 		}
 	}
+}
 
-	// Step 3: Place two initial words as a cross in the center
-	centerRow := b.targetRows / 2
-	centerCol := b.targetCols / 2
+// placeSeedAcross places a 5-7 letter word across the center of the grid.
+func (b *GridBuilder) placeSeedAcross() *PlacedWord {
+	for i, sw := range b.selected {
+		if len(sw.word) < 5 || len(sw.word) > 7 {
+			continue
+		}
 
-	// Find a good horizontal word (5-7 letters)
-	horzIdx := -1
-	for i, sw := range selected {
-		if len(sw.word) >= 5 && len(sw.word) <= 7 {
-			horzIdx = i
-			break
+		row := b.targetRows / 2
+		col := b.targetCols/2 - len(sw.word)/2
+		if col < 1 || col+len(sw.word) >= b.targetCols-1 {
+			return nil
 		}
+		b.placeWord(sw.word, row, col, domain.DirectionAcross)
+		b.selected = append(b.selected[:i], b.selected[i+1:]...)
+		return b.lastPlaced()
 	}
+	return nil
+}
 
-	// Find a good vertical word that can cross the horizontal one
-	vertIdx := -1
-	if horzIdx >= 0 {
-		horzWord := selected[horzIdx].word
-		horzRow := centerRow
-		horzCol := centerCol - len(horzWord)/2
-		if horzCol >= 1 && horzCol+len(horzWord) < b.targetCols-1 {
-			b.placeWord(horzWord, horzRow, horzCol, domain.DirectionAcross)
-			selected = append(selected[:horzIdx], selected[horzIdx+1:]...)
-
-			// Find a vertical word that shares a letter with the horizontal word
-			for i, sw := range selected {
-				if len(sw.word) >= 4 && len(sw.word) <= 6 {
-					// Check if it can cross the horizontal word
-					for j, c := range sw.word {
-						for k, hc := range horzWord {
-							if c == hc {
-								// Try to place vertically crossing at this point
-								vRow := horzRow - j
-								vCol := horzCol + k
-								if vRow >= 1 && vRow+len(sw.word) < b.targetRows-1 {
-									if b.canPlace(sw.word, vRow, vCol, domain.DirectionDown) {
-										b.placeWord(sw.word, vRow, vCol, domain.DirectionDown)
-										vertIdx = i
-										break
-									}
-								}
-							}
-						}
-						if vertIdx >= 0 {
-							break
-						}
-					}
-					if vertIdx >= 0 {
-						selected = append(selected[:vertIdx], selected[vertIdx+1:]...)
-						break
-					}
+// placeSeedDown places a 4-6 letter word down through the center across word.
+func (b *GridBuilder) placeSeedDown() *PlacedWord {
+	horz := b.placed[0]
+	for i, sw := range b.selected {
+		if len(sw.word) < 4 || len(sw.word) > 6 {
+			continue
+		}
+		// Check if it can cross the horizontal word
+		for j, c := range sw.word {
+			for k, hc := range horz.Word {
+				if c != hc {
+					continue
+				}
+				vRow := horz.Row - j
+				vCol := horz.Col + k
+				if vRow >= 1 && vRow+len(sw.word) < b.targetRows-1 &&
+					b.canPlace(sw.word, vRow, vCol, domain.DirectionDown) {
+					b.placeWord(sw.word, vRow, vCol, domain.DirectionDown)
+					b.selected = append(b.selected[:i], b.selected[i+1:]...)
+					return b.lastPlaced()
 				}
 			}
 		}
 	}
+	return nil
+}
 
-	// Step 4: Place more words using compact placement strategy
-	placedCount := len(b.placed)
-	failures := 0
-	maxFailures := len(selected) * 3
-
-	for len(selected) > 0 && failures < maxFailures && placedCount < 20 {
-		placed := false
+// enterGrow starts the compact placement phase.
+func (b *GridBuilder) enterGrow() {
+	b.phase = phaseGrow
+	b.failures = 0
+	b.maxFailure = len(b.selected) * 3
+}
 
-		bestPlacement := b.findBestPlacement(selected)
-		if bestPlacement != nil {
-			b.placeWord(bestPlacement.word, bestPlacement.row, bestPlacement.col, bestPlacement.dir)
-			for i, sw := range selected {
-				if sw.word == bestPlacement.word {
-					selected = append(selected[:i], selected[i+1:]...)
+// growOne places the best-scoring selected word next to the existing grid.
+func (b *GridBuilder) growOne() *PlacedWord {
+	for len(b.selected) > 0 && b.failures < b.maxFailure && len(b.placed) < 20 {
+		best := b.findBestPlacement(b.selected)
+		if best != nil {
+			b.placeWord(best.word, best.row, best.col, best.dir)
+			for i, sw := range b.selected {
+				if sw.word == best.word {
+					b.selected = append(b.selected[:i], b.selected[i+1:]...)
 					break
 				}
 			}
-			placedCount++
-			placed = true
-			failures = 0
+			b.failures = 0
+			return b.lastPlaced()
 		}
 
-		if !placed {
-			failures++
-			if len(selected) > 1 {
-				selected = append(selected[1:], selected[0])
-			}
+		b.failures++
+		if len(b.selected) > 1 {
+			b.selected = append(b.selected[1:], b.selected[0])
 		}
 	}
+	return nil
+}
 
-	// Step 5: GAP FILLING PHASE - Fill gaps to eliminate dead blocks
-	// Combine all candidates with short words for maximum coverage
-	allFillWords := make([]string, 0, len(candidates)+len(shortWords))
-	allFillWords = append(allFillWords, shortWords...)
-	allFillWords = append(allFillWords, candidates...)
-	b.fillGaps(allFillWords)
-
-	// Build result
-	// Success if we placed enough words - dead blocks are OK for now
-	// Gap filling is best-effort, we'll improve density iteratively
-	return &BuildResult{
-		Grid:    b.toTemplate(),
-		Words:   b.getPlacedWords(),
-		Success: len(b.placed) >= 8,
+// enterFillGaps starts the gap filling phase.
+func (b *GridBuilder) enterFillGaps() {
+	b.phase = phaseFillGaps
+	b.fillPasses = 0
+	b.byLength = make(map[int][]string)
+	seen := make(map[string]bool, len(b.fillWords))
+	for _, word := range b.fillWords {
+		// Short candidates appear twice in fillWords, once as short words
+		if !seen[word] && !b.isPlaced(word) {
+			seen[word] = true
+			b.byLength[len(word)] = append(b.byLength[len(word)], word)
+		}
 	}
 }
 
+// lastPlaced returns a copy of the most recently placed word.
+func (b *GridBuilder) lastPlaced() *PlacedWord {
+	pw := b.placed[len(b.placed)-1]
+	return &pw
+}
+
 // Gap represents an empty sequence in the grid that could hold a word.
 type Gap struct {
 	Row, Col  int
@@ -366,82 +576,40 @@ func (b *GridBuilder) findGaps() []Gap {
 	return gaps
 }
 
-// fillGaps attempts to fill gaps with words to eliminate dead blocks.
-func (b *GridBuilder) fillGaps(allWords []string) {
-	// Build a map of words by length for fast lookup
-	byLength := make(map[int][]string)
-	for _, word := range allWords {
-		if !b.usedWords[word] {
-			l := len(word)
-			byLength[l] = append(byLength[l], word)
-		}
+// fillOneGap fills one gap with a word to eliminate dead blocks, trying exact
+// lengths first, then shorter words at the start of the gap. Gap filling
+// stops after 10 words or when no gap can be filled.
+func (b *GridBuilder) fillOneGap() *PlacedWord {
+	if b.fillPasses >= 10 {
+		return nil
 	}
 
-	// Multiple passes to fill as many gaps as possible
-	for pass := 0; pass < 10; pass++ {
-		gaps := b.findGaps()
-		if len(gaps) == 0 {
-			break
-		}
-
-		filled := false
-		for _, gap := range gaps {
-			// Try exact length first
-			if candidates, ok := byLength[gap.Length]; ok {
-				for _, word := range candidates {
-					if b.usedWords[word] {
-						continue
-					}
-					if b.canFillGap(word, gap) {
-						b.placeWord(word, gap.Row, gap.Col, gap.Direction)
-						filled = true
-						break
-					}
-				}
-				if filled {
-					break
-				}
+	for _, gap := range b.findGaps() {
+		for length := gap.Length; length >= 2; length-- {
+			// Create a sub-gap for shorter words
+			subGap := Gap{
+				Row:       gap.Row,
+				Col:       gap.Col,
+				Length:    length,
+				Direction: gap.Direction,
 			}
-
-			// Try shorter words that fit at the start of the gap
-			for length := gap.Length - 1; length >= 2; length-- {
-				if candidates, ok := byLength[length]; ok {
-					for _, word := range candidates {
-						if b.usedWords[word] {
-							continue
-						}
-						// Create a sub-gap for the shorter word
-						subGap := Gap{
-							Row:       gap.Row,
-							Col:       gap.Col,
-							Length:    length,
-							Direction: gap.Direction,
-						}
-						if b.canFillGap(word, subGap) {
-							b.placeWord(word, subGap.Row, subGap.Col, subGap.Direction)
-							filled = true
-							break
-						}
-					}
-					if filled {
-						break
-					}
+			for _, word := range b.byLength[length] {
+				if b.canFillGap(word, subGap) {
+					b.placeWord(word, subGap.Row, subGap.Col, subGap.Direction)
+					b.fillPasses++
+					return b.lastPlaced()
 				}
 			}
-			if filled {
-				break
-			}
-		}
-
-		if !filled {
-			break // No progress, stop trying
 		}
 	}
+
+	return nil
 }
 
-// canFillGap checks if a word can be placed in a gap.
+// canFillGap checks if a word can be placed in a gap. Words already in the
+// grid are rejected, so gap fillers never repeat an entry.
 func (b *GridBuilder) canFillGap(word string, gap Gap) bool {
-	if len(word) != gap.Length {
+	if len(word) != gap.Length || b.isPlaced(word) {
 		return false
 	}
 
@@ -493,6 +661,19 @@ func (b *GridBuilder) canFillGap(word string, gap Gap) bool {
 	return true
 }
 
+// isPlaced reports whether word is already an entry of the grid, ignoring case.
+func (b *GridBuilder) isPlaced(word string) bool {
+	if b.usedWords[word] {
+		return true
+	}
+	for _, pw := range b.placed {
+		if strings.EqualFold(pw.Word, word) {
+			return true
+		}
+	}
+	return false
+}
+
 // hasDeadBlocks checks if the grid has any adjacent blocks (dead blocks).
 func (b *GridBuilder) hasDeadBlocks() bool {
 	if len(b.placed) == 0 {
@@ -527,7 +708,8 @@ type scoredWord struct {
 }
 
 // scoreWords calculates crossability score for each word.
-// Higher score = more vowels = easier to cross.
+// Higher score = more vowels = easier to cross. The vowel ratio is raised to
+// the builder's vowel weight, so weights below 1 flatten the preference.
 func (b *GridBuilder) scoreWords(words []string) []scoredWord {
 	scored := make([]scoredWord, 0, len(words))
 	seen := make(map[string]bool)
@@ -553,7 +735,10 @@ func (b *GridBuilder) scoreWords(words []string) []scoredWord {
 			lengthScore = 1.5
 		}
 
-		score := vowelRatio * lengthScore * float64(len(word))
+		score := math.Pow(vowelRatio, b.vowelWeight) * lengthScore * float64(len(word))
+		if w, ok := b.weights[word]; ok {
+			score *= w
+		}
 		scored = append(scored, scoredWord{word: word, score: score})
 	}
 
@@ -882,7 +1067,7 @@ func (b *GridBuilder) placeWord(word string, row, col int, dir domain.Direction)
 		}
 	}
 
-	b.placed = append(b.placed, placedWord{
+	b.placed = append(b.placed, PlacedWord{
 		Word:      word,
 		Row:       row,
 		Col:       col,
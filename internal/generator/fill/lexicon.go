@@ -2,8 +2,11 @@ package fill
 
 import (
 	"bufio"
+	_ "embed"
 	"io"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -26,6 +29,16 @@ type WordEntry struct {
 	Tags      []string
 }
 
+// HasTag reports whether the entry carries the given tag.
+func (e WordEntry) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // MemoryLexicon is an in-memory lexicon implementation.
 type MemoryLexicon struct {
 	words    map[string]WordEntry
@@ -60,6 +73,18 @@ func (l *MemoryLexicon) AddWord(word string) {
 	l.Add(word, 1.0, nil)
 }
 
+// SetFrequency updates the frequency of a word already in the lexicon.
+// Unknown words are ignored.
+func (l *MemoryLexicon) SetFrequency(word string, freq float64) {
+	word = strings.ToUpper(word)
+	entry, ok := l.words[word]
+	if !ok {
+		return
+	}
+	entry.Frequency = freq
+	l.words[word] = entry
+}
+
 // Match returns words matching the pattern.
 func (l *MemoryLexicon) Match(pattern string) []string {
 	pattern = strings.ToUpper(pattern)
@@ -107,6 +132,71 @@ func (l *MemoryLexicon) Words() []string {
 	return words
 }
 
+// Frequencies returns each word's frequency, keyed by word.
+func (l *MemoryLexicon) Frequencies() map[string]float64 {
+	freqs := make(map[string]float64, len(l.words))
+	for word, entry := range l.words {
+		freqs[word] = entry.Frequency
+	}
+	return freqs
+}
+
+// MergeStrategy selects which entry Merge keeps for a word in both lexicons.
+type MergeStrategy string
+
+const (
+	MergeKeepMaxFrequency MergeStrategy = "keep-max-frequency" // Higher frequency wins; tags are united
+	MergeKeepExisting     MergeStrategy = "keep-existing"      // The receiver's entry is left untouched
+	MergeOverwrite        MergeStrategy = "overwrite"          // The other lexicon's entry replaces the receiver's
+)
+
+// Merge adds the words of other to the lexicon. Words only in other are
+// copied as is; shared words are resolved by strategy.
+func (l *MemoryLexicon) Merge(other *MemoryLexicon, strategy MergeStrategy) {
+	for _, word := range other.Words() {
+		theirs := other.words[word]
+		ours, exists := l.words[word]
+		if !exists {
+			l.Add(word, theirs.Frequency, append([]string(nil), theirs.Tags...))
+			continue
+		}
+
+		switch strategy {
+		case MergeKeepMaxFrequency:
+			ours.Frequency = math.Max(ours.Frequency, theirs.Frequency)
+			for _, tag := range theirs.Tags {
+				if !ours.HasTag(tag) {
+					ours.Tags = append(ours.Tags[:len(ours.Tags):len(ours.Tags)], tag)
+				}
+			}
+		case MergeOverwrite:
+			ours.Frequency = theirs.Frequency
+			ours.Tags = append([]string(nil), theirs.Tags...)
+		default:
+			continue
+		}
+		l.words[word] = ours
+	}
+}
+
+// Diff compares the words of two lexicons and returns, sorted, the words only
+// in l, the words only in other and the words in both.
+func (l *MemoryLexicon) Diff(other *MemoryLexicon) (onlyA, onlyB, common []string) {
+	for _, word := range l.Words() {
+		if other.Contains(word) {
+			common = append(common, word)
+		} else {
+			onlyA = append(onlyA, word)
+		}
+	}
+	for _, word := range other.Words() {
+		if !l.Contains(word) {
+			onlyB = append(onlyB, word)
+		}
+	}
+	return onlyA, onlyB, common
+}
+
 // matchPattern checks if a word matches a pattern (. = wildcard).
 func matchPattern(word, pattern string) bool {
 	if len(word) != len(pattern) {
@@ -120,8 +210,22 @@ func matchPattern(word, pattern string) bool {
 	return true
 }
 
+// Default word length bounds applied by LoadLexicon.
+// Crosswords rarely use 1-letter or 16+-letter entries.
+const (
+	DefaultMinWordLength = 2
+	DefaultMaxWordLength = 15
+)
+
 // LoadLexicon loads words from a reader (one word per line).
+// Words outside DefaultMinWordLength-DefaultMaxWordLength are skipped.
 func LoadLexicon(r io.Reader) (*MemoryLexicon, error) {
+	return LoadLexiconFiltered(r, DefaultMinWordLength, DefaultMaxWordLength)
+}
+
+// LoadLexiconFiltered loads words from a reader, skipping words whose length
+// is outside [minLen, maxLen]. A bound of 0 disables that side of the check.
+func LoadLexiconFiltered(r io.Reader, minLen, maxLen int) (*MemoryLexicon, error) {
 	lexicon := NewMemoryLexicon()
 	scanner := bufio.NewScanner(r)
 
@@ -135,17 +239,23 @@ func LoadLexicon(r io.Reader) (*MemoryLexicon, error) {
 		parts := strings.Split(word, ",")
 		w := strings.ToUpper(parts[0])
 
+		if (minLen > 0 && len(w) < minLen) || (maxLen > 0 && len(w) > maxLen) {
+			continue
+		}
+
 		freq := 1.0
 		var tags []string
 
 		if len(parts) > 1 {
 			// Parse frequency if present
-			if f, err := parseFloat(parts[1]); err == nil {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64); err == nil {
 				freq = f
 			}
 		}
-		if len(parts) > 2 {
-			tags = parts[2:]
+		for _, tag := range parts[min(len(parts), 2):] {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
 		}
 
 		lexicon.Add(w, freq, tags)
@@ -154,30 +264,57 @@ func LoadLexicon(r io.Reader) (*MemoryLexicon, error) {
 	return lexicon, scanner.Err()
 }
 
-func parseFloat(s string) (float64, error) {
-	s = strings.TrimSpace(s)
-	var f float64
-	_, err := strings.NewReader(s).Read([]byte{})
-	if err != nil {
-		return 0, err
-	}
-	// Simple parsing
-	for i, c := range s {
-		if c == '.' {
+// LoadCorpusFrequencies reads a word<TAB>count corpus and returns each word's
+// frequency in (0, 1], log-scaled so the most frequent word gets 1.0.
+// Counts of repeated words are summed; malformed lines and non-positive
+// counts are skipped.
+func LoadCorpusFrequencies(r io.Reader) (map[string]float64, error) {
+	counts := make(map[string]float64)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		if c < '0' || c > '9' {
-			return 0, io.EOF
+
+		word, count, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(count), 64)
+		if err != nil || n <= 0 {
+			continue
 		}
-		_ = i
+		counts[strings.ToUpper(strings.TrimSpace(word))] += n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var max float64
+	for _, n := range counts {
+		max = math.Max(max, n)
 	}
-	// Use fmt for actual parsing
-	_, err = strings.NewReader(s).Read([]byte{})
-	f = 1.0 // Default
-	return f, nil
+	freqs := make(map[string]float64, len(counts))
+	for word, n := range counts {
+		// log1p keeps a count of 1 above zero when max is 1
+		freqs[word] = math.Log1p(n) / math.Log1p(max)
+	}
+	return freqs, nil
 }
 
+// frenchCorpus holds approximate counts for common French words.
+//
+//go:embed corpus_fr.tsv
+var frenchCorpus string
+
+// unlistedCorpusFrequency is given to sample words missing from the corpus,
+// about the frequency of its rarest entries.
+const unlistedCorpusFrequency = 0.1
+
 // SampleFrenchLexicon returns a comprehensive lexicon for crossword solving.
+// Frequencies come from the embedded corpus; words it lacks are treated as rare.
 func SampleFrenchLexicon() *MemoryLexicon {
 	lexicon := NewMemoryLexicon()
 
@@ -327,5 +464,14 @@ func SampleFrenchLexicon() *MemoryLexicon {
 		lexicon.AddWord(w)
 	}
 
+	freqs, _ := LoadCorpusFrequencies(strings.NewReader(frenchCorpus))
+	for _, word := range lexicon.Words() {
+		freq, ok := freqs[word]
+		if !ok {
+			freq = unlistedCorpusFrequency
+		}
+		lexicon.SetFrequency(word, freq)
+	}
+
 	return lexicon
 }
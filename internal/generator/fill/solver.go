@@ -1,8 +1,11 @@
 package fill
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math/rand"
+	"sort"
 
 	"lesmotsdatche/internal/domain"
 )
@@ -18,12 +21,31 @@ type Solver struct {
 	lexicon              Lexicon
 	scorer               Scorer
 	rng                  *rand.Rand
-	maxBacktrack         int
+	maxBacktrack         int // Absolute budget, 0 when derived from maxBacktrackPerSlot
+	maxBacktrackPerSlot  int
+	backtrackLimit       int // Budget of the current solve
 	maxConsecutiveBlocks int
 	maxBlockClusterSize  int
 	backtrackCount       int
+	candidateCounts      map[int]int // Slot ID -> cached candidate count for MRV
+	preprocess           bool
+	domains              map[int]map[string]bool // Slot ID -> words surviving preprocessing (nil = unrestricted)
+	onProgress           func(filled, total, backtracks int)
+	progressFilled       int // Most slots filled at once during the current solve
+	progressTotal        int
+	ctx                  context.Context // Context of the current solve
+	ctxErr               error           // Set once ctx is found done
+	expansions           int             // Backtrack calls of the current solve
 }
 
+// progressBacktrackInterval is how many backtracks pass between progress
+// reports that don't come from a new fill record.
+const progressBacktrackInterval = 100
+
+// contextCheckInterval is how many backtrack calls pass between checks of
+// the solve context, keeping ctx.Err off the hot path.
+const contextCheckInterval = 64
+
 // Scorer scores candidates for ranking.
 type Scorer interface {
 	Score(word string, slot Slot, grid [][]rune) float64
@@ -53,9 +75,16 @@ type SolverConfig struct {
 	Lexicon             Lexicon
 	Scorer              Scorer
 	Seed                int64 // Random seed for determinism (0 = use time)
-	MaxBacktrack        int   // Maximum backtrack attempts (0 = unlimited)
+	MaxBacktrack        int   // Maximum backtrack attempts (0 = MaxBacktrackPerSlot, or 10000 if unset too)
+	MaxBacktrackPerSlot int   // Backtrack budget per template slot, used when MaxBacktrack is 0
 	MaxConsecutiveBlocks int  // Max consecutive blocks in a row/column (0 = unlimited, recommend 2-3)
 	MaxBlockClusterSize  int  // Max size of rectangular block cluster (0 = unlimited, recommend 4)
+	Preprocess           bool // Prune candidates by crossing compatibility (AC-3) before backtracking
+
+	// OnProgress, if set, is called whenever the solve fills more slots at
+	// once than ever before, and every 100 backtracks. filled is that record,
+	// so it never decreases; the last call of a successful solve has filled == total.
+	OnProgress func(filled, total, backtracks int)
 }
 
 // NewSolver creates a new solver.
@@ -68,7 +97,7 @@ func NewSolver(cfg SolverConfig) *Solver {
 	}
 
 	maxBacktrack := cfg.MaxBacktrack
-	if maxBacktrack == 0 {
+	if maxBacktrack == 0 && cfg.MaxBacktrackPerSlot <= 0 {
 		maxBacktrack = 10000
 	}
 
@@ -77,8 +106,11 @@ func NewSolver(cfg SolverConfig) *Solver {
 		scorer:               cfg.Scorer,
 		rng:                  rng,
 		maxBacktrack:         maxBacktrack,
+		maxBacktrackPerSlot:  cfg.MaxBacktrackPerSlot,
 		maxConsecutiveBlocks: cfg.MaxConsecutiveBlocks,
 		maxBlockClusterSize:  cfg.MaxBlockClusterSize,
+		preprocess:           cfg.Preprocess,
+		onProgress:           cfg.OnProgress,
 	}
 }
 
@@ -92,31 +124,45 @@ type Result struct {
 
 // Solve fills the grid template.
 func (s *Solver) Solve(template [][]domain.Cell) (*Result, error) {
+	return s.SolveContext(context.Background(), template)
+}
+
+// SolveContext fills the grid template, giving up once ctx is done. The
+// returned error then wraps ctx.Err(), so a timeout can be told apart from
+// ErrNoSolution; the result holds the partial fill, as with ErrNoSolution.
+func (s *Solver) SolveContext(ctx context.Context, template [][]domain.Cell) (*Result, error) {
 	slots := DiscoverSlots(template)
 	if len(slots) == 0 {
 		return nil, errors.New("no slots found in template")
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fill interrupted: %w", err)
+	}
 
-	// Initialize working grid
-	rows := len(template)
-	cols := len(template[0])
-	grid := make([][]rune, rows)
-	for i := range grid {
-		grid[i] = make([]rune, cols)
-		for j := range grid[i] {
-			if template[i][j].IsLetter() {
-				if template[i][j].Solution != "" {
-					grid[i][j] = rune(template[i][j].Solution[0])
-				} else {
-					grid[i][j] = '.'
-				}
-			} else {
-				grid[i][j] = '#' // Block marker
+	grid := workGrid(template)
+
+	s.backtrackCount = 0
+	s.backtrackLimit = s.maxBacktrack
+	if s.backtrackLimit == 0 {
+		s.backtrackLimit = len(slots) * s.maxBacktrackPerSlot
+	}
+	s.candidateCounts = make(map[int]int)
+	s.progressFilled = 0
+	s.progressTotal = len(slots)
+	s.ctx = ctx
+	s.ctxErr = nil
+	s.expansions = 0
+	s.domains = nil
+	if s.preprocess {
+		s.domains = make(map[int]map[string]bool, len(slots))
+		for id, candidates := range pruneCandidates(slots, grid, s.lexicon) {
+			allowed := make(map[string]bool, len(candidates))
+			for _, word := range candidates {
+				allowed[word] = true
 			}
+			s.domains[id] = allowed
 		}
 	}
-
-	s.backtrackCount = 0
 	words := make(map[int]string)
 
 	success := s.backtrack(slots, grid, words, 0)
@@ -134,6 +180,9 @@ func (s *Solver) Solve(template [][]domain.Cell) (*Result, error) {
 		}
 	}
 
+	if s.ctxErr != nil {
+		return result, fmt.Errorf("fill interrupted: %w", s.ctxErr)
+	}
 	if !success {
 		return result, ErrNoSolution
 	}
@@ -141,10 +190,44 @@ func (s *Solver) Solve(template [][]domain.Cell) (*Result, error) {
 	return result, nil
 }
 
+// workGrid returns the rune grid the solver works on: placed letters, '.' for
+// empty letter cells and '#' for blocks and clue cells.
+func workGrid(template [][]domain.Cell) [][]rune {
+	grid := make([][]rune, len(template))
+	for i, row := range template {
+		grid[i] = make([]rune, len(row))
+		for j, cell := range row {
+			if cell.IsLetter() {
+				if cell.Solution != "" {
+					grid[i][j] = rune(cell.Solution[0])
+				} else {
+					grid[i][j] = '.'
+				}
+			} else {
+				grid[i][j] = '#' // Block marker
+			}
+		}
+	}
+	return grid
+}
+
+// slotCandidates discovers the slots of a template and counts the lexicon
+// words matching each one in the solver's work grid, so placed letters
+// constrain their slots as they do during a fill.
+func slotCandidates(template [][]domain.Cell, lexicon Lexicon) ([]Slot, map[int]int) {
+	grid := workGrid(template)
+	slots := DiscoverSlots(template)
+	counts := make(map[int]int, len(slots))
+	for _, slot := range slots {
+		counts[slot.ID] = len(lexicon.Match(slot.Pattern(grid)))
+	}
+	return slots, counts
+}
+
 // backtrack performs recursive backtracking fill.
 func (s *Solver) backtrack(slots []Slot, grid [][]rune, words map[int]string, depth int) bool {
 	// Check backtrack limit
-	if s.backtrackCount > s.maxBacktrack {
+	if s.backtrackCount > s.backtrackLimit || s.interrupted() {
 		return false
 	}
 
@@ -155,8 +238,7 @@ func (s *Solver) backtrack(slots []Slot, grid [][]rune, words map[int]string, de
 	}
 
 	slot := slots[slotIdx]
-	pattern := slot.Pattern(grid)
-	candidates := s.lexicon.Match(pattern)
+	candidates := s.matchSlot(slot, grid)
 
 	if len(candidates) == 0 {
 		return false // No candidates
@@ -180,6 +262,10 @@ func (s *Solver) backtrack(slots []Slot, grid [][]rune, words map[int]string, de
 		// Place word
 		s.placeWord(slot, word, grid)
 		words[slot.ID] = word
+		if len(words) > s.progressFilled {
+			s.progressFilled = len(words)
+			s.reportProgress()
+		}
 
 		// Recurse
 		if s.backtrack(slots, grid, words, depth+1) {
@@ -189,9 +275,15 @@ func (s *Solver) backtrack(slots []Slot, grid [][]rune, words map[int]string, de
 		// Backtrack
 		delete(words, slot.ID)
 		s.removeWord(slot, grid, words)
+		if s.ctxErr != nil {
+			return false
+		}
 		s.backtrackCount++
+		if s.backtrackCount%progressBacktrackInterval == 0 {
+			s.reportProgress()
+		}
 
-		if s.backtrackCount > s.maxBacktrack {
+		if s.backtrackCount > s.backtrackLimit {
 			return false
 		}
 	}
@@ -199,19 +291,44 @@ func (s *Solver) backtrack(slots []Slot, grid [][]rune, words map[int]string, de
 	return false
 }
 
+// interrupted reports whether the solve context is done, checking it every
+// contextCheckInterval calls.
+func (s *Solver) interrupted() bool {
+	if s.ctxErr == nil && s.expansions%contextCheckInterval == 0 {
+		s.ctxErr = s.ctx.Err()
+	}
+	s.expansions++
+	return s.ctxErr != nil
+}
+
+// reportProgress calls the progress callback, if any, with the fill record.
+func (s *Solver) reportProgress() {
+	if s.onProgress != nil {
+		s.onProgress(s.progressFilled, s.progressTotal, s.backtrackCount)
+	}
+}
+
 // selectNextSlot returns the index of the most constrained unfilled slot.
+// Candidate counts are cached per slot and only recomputed after placeWord or
+// removeWord touches the slot or one of its crossings.
 func (s *Solver) selectNextSlot(slots []Slot, grid [][]rune, words map[int]string) int {
 	bestIdx := -1
 	bestScore := int(^uint(0) >> 1) // Max int
 
+	if s.candidateCounts == nil {
+		s.candidateCounts = make(map[int]int)
+	}
+
 	for i, slot := range slots {
 		if _, filled := words[slot.ID]; filled {
 			continue
 		}
 
-		pattern := slot.Pattern(grid)
-		candidates := s.lexicon.Match(pattern)
-		count := len(candidates)
+		count, ok := s.candidateCounts[slot.ID]
+		if !ok {
+			count = len(s.matchSlot(slot, grid))
+			s.candidateCounts[slot.ID] = count
+		}
 
 		if count == 0 {
 			return i // Force try on impossible slot
@@ -226,6 +343,146 @@ func (s *Solver) selectNextSlot(slots []Slot, grid [][]rune, words map[int]strin
 	return bestIdx
 }
 
+// matchSlot returns the lexicon words matching the slot's current pattern,
+// restricted to the slot's preprocessed domain when preprocessing is enabled.
+func (s *Solver) matchSlot(slot Slot, grid [][]rune) []string {
+	candidates := s.lexicon.Match(slot.Pattern(grid))
+	allowed, ok := s.domains[slot.ID]
+	if !ok {
+		return candidates
+	}
+
+	filtered := candidates[:0:0]
+	for _, word := range candidates {
+		if allowed[word] {
+			filtered = append(filtered, word)
+		}
+	}
+	return filtered
+}
+
+// pruneCandidates runs AC-3 over the slot crossings: starting from each slot's
+// pattern matches, it repeatedly drops words whose letter at some crossing is
+// not offered by any remaining word of the crossing slot. The result maps
+// slot ID to its surviving candidates; a slot with no survivors cannot be filled.
+func pruneCandidates(slots []Slot, grid [][]rune, lexicon Lexicon) map[int][]string {
+	domains := make(map[int][]string, len(slots))
+	byID := make(map[int]Slot, len(slots))
+	for _, slot := range slots {
+		domains[slot.ID] = lexicon.Match(slot.Pattern(grid))
+		byID[slot.ID] = slot
+	}
+
+	type arc struct {
+		slotID   int
+		crossing Crossing
+	}
+	var queue []arc
+	for _, slot := range slots {
+		for _, crossing := range slot.Crossings {
+			queue = append(queue, arc{slot.ID, crossing})
+		}
+	}
+
+	for len(queue) > 0 {
+		a := queue[0]
+		queue = queue[1:]
+
+		// Letters the crossing slot can still supply at the shared cell
+		supported := make(map[byte]bool)
+		for _, word := range domains[a.crossing.SlotID] {
+			supported[word[a.crossing.ThatIndex]] = true
+		}
+
+		current := domains[a.slotID]
+		kept := current[:0:0]
+		for _, word := range current {
+			if supported[word[a.crossing.ThisIndex]] {
+				kept = append(kept, word)
+			}
+		}
+		if len(kept) == len(current) {
+			continue
+		}
+		domains[a.slotID] = kept
+
+		// Slots crossing this one may have lost their support
+		for _, crossing := range byID[a.slotID].Crossings {
+			if crossing.SlotID == a.crossing.SlotID {
+				continue
+			}
+			other := byID[crossing.SlotID]
+			for _, back := range other.Crossings {
+				if back.SlotID == a.slotID {
+					queue = append(queue, arc{other.ID, back})
+				}
+			}
+		}
+	}
+
+	return domains
+}
+
+// ErrSlotNotFound is returned when no slot of the grid starts at a position
+// in a direction with the expected length.
+var ErrSlotNotFound = errors.New("slot not found")
+
+// Suggest returns the words that fit the slot starting at start in direction
+// dir of a partly filled grid, most frequent first. Beyond matching the slot's
+// pattern, a word must leave every crossing slot whose shared cell is still
+// empty with at least one matching word, as pruneCandidates checks during
+// preprocessing. Crossings whose cell is already filled are not rechecked.
+// A length of 0 accepts a slot of any length.
+func Suggest(template [][]domain.Cell, start domain.Position, dir domain.Direction, length int, lexicon *MemoryLexicon) ([]string, error) {
+	slots := DiscoverSlots(template)
+	var target *Slot
+	for i := range slots {
+		if slots[i].Start == start && slots[i].Direction == dir && (length == 0 || slots[i].Length == length) {
+			target = &slots[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%w: %s at (%d,%d)", ErrSlotNotFound, dir, start.Row, start.Col)
+	}
+
+	// Keep the target and the slots through its open cells, linked only to
+	// the target, so unrelated slots cannot prune its candidates
+	grid := workGrid(template)
+	star := []Slot{{ID: target.ID, Direction: target.Direction, Start: target.Start, Length: target.Length, Cells: target.Cells}}
+	byID := make(map[int]Slot, len(slots))
+	for _, slot := range slots {
+		byID[slot.ID] = slot
+	}
+	for _, crossing := range target.Crossings {
+		pos := target.Cells[crossing.ThisIndex]
+		if grid[pos.Row][pos.Col] != '.' {
+			continue
+		}
+		other := byID[crossing.SlotID]
+		star[0].Crossings = append(star[0].Crossings, crossing)
+		star = append(star, Slot{
+			ID:        other.ID,
+			Direction: other.Direction,
+			Start:     other.Start,
+			Length:    other.Length,
+			Cells:     other.Cells,
+			Crossings: []Crossing{{SlotID: target.ID, ThisIndex: crossing.ThatIndex, ThatIndex: crossing.ThisIndex}},
+		})
+	}
+
+	words := pruneCandidates(star, grid, lexicon)[target.ID]
+	sort.SliceStable(words, func(i, j int) bool {
+		ei, _ := lexicon.GetEntry(words[i])
+		ej, _ := lexicon.GetEntry(words[j])
+		if ei.Frequency != ej.Frequency {
+			return ei.Frequency > ej.Frequency
+		}
+		return words[i] < words[j]
+	})
+	return words, nil
+}
+
 type scoredCandidate struct {
 	word  string
 	score float64
@@ -286,6 +543,7 @@ func (s *Solver) placeWord(slot Slot, word string, grid [][]rune) {
 	for i, pos := range slot.Cells {
 		grid[pos.Row][pos.Col] = rune(word[i])
 	}
+	s.invalidateCounts(slot)
 }
 
 func (s *Solver) removeWord(slot Slot, grid [][]rune, words map[int]string) {
@@ -303,6 +561,16 @@ func (s *Solver) removeWord(slot Slot, grid [][]rune, words map[int]string) {
 			grid[pos.Row][pos.Col] = rune(crossWord[crossing.ThatIndex])
 		}
 	}
+	s.invalidateCounts(slot)
+}
+
+// invalidateCounts drops cached candidate counts for a slot and every slot
+// crossing it, since only those patterns change when the slot's cells do.
+func (s *Solver) invalidateCounts(slot Slot) {
+	delete(s.candidateCounts, slot.ID)
+	for _, crossing := range slot.Crossings {
+		delete(s.candidateCounts, crossing.SlotID)
+	}
 }
 
 func positionIndex(slot Slot, pos domain.Position) int {
@@ -505,3 +773,40 @@ func itoa(n int) string {
 	}
 	return string(digits)
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Constraint Heatmap
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ConstraintHeatmap scores how constrained each letter cell of a template is.
+// Each slot contributes 1/(1+candidates) to every cell it covers, so cells where
+// several slots with few candidates cross score highest. Scores are normalized
+// to [0, 1]; block cells score 0. The result has the template's dimensions.
+func ConstraintHeatmap(template [][]domain.Cell, lexicon Lexicon) [][]float64 {
+	heatmap := make([][]float64, len(template))
+	for i, row := range template {
+		heatmap[i] = make([]float64, len(row))
+	}
+
+	maxScore := 0.0
+	slots, counts := slotCandidates(template, lexicon)
+	for _, slot := range slots {
+		weight := 1.0 / float64(1+counts[slot.ID])
+		for _, pos := range slot.Cells {
+			heatmap[pos.Row][pos.Col] += weight
+			if heatmap[pos.Row][pos.Col] > maxScore {
+				maxScore = heatmap[pos.Row][pos.Col]
+			}
+		}
+	}
+
+	if maxScore > 0 {
+		for i := range heatmap {
+			for j := range heatmap[i] {
+				heatmap[i][j] /= maxScore
+			}
+		}
+	}
+
+	return heatmap
+}
@@ -1,6 +1,10 @@
 package fill
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -118,6 +122,143 @@ func TestLexiconMatch(t *testing.T) {
 	}
 }
 
+func TestMemoryLexicon_Merge(t *testing.T) {
+	newLexicons := func() (*MemoryLexicon, *MemoryLexicon) {
+		a := NewMemoryLexicon()
+		a.Add("CHAT", 0.8, []string{"animal"})
+		a.Add("PORTE", 0.2, []string{"maison"})
+		a.Add("MER", 0.5, nil)
+		b := NewMemoryLexicon()
+		b.Add("CHAT", 0.3, []string{"animal", "courant"})
+		b.Add("PORTE", 0.9, []string{"entree"})
+		b.Add("ROSE", 0.4, []string{"fleur"})
+		return a, b
+	}
+
+	a, b := newLexicons()
+	onlyA, onlyB, common := a.Diff(b)
+	if !reflect.DeepEqual(onlyA, []string{"MER"}) || !reflect.DeepEqual(onlyB, []string{"ROSE"}) ||
+		!reflect.DeepEqual(common, []string{"CHAT", "PORTE"}) {
+		t.Errorf("Diff = %v, %v, %v", onlyA, onlyB, common)
+	}
+
+	a.Merge(b, MergeKeepMaxFrequency)
+	if a.Size() != 4 {
+		t.Errorf("Size after merge = %d, want 4", a.Size())
+	}
+	tests := []struct {
+		word string
+		freq float64
+		tags []string
+	}{
+		{"CHAT", 0.8, []string{"animal", "courant"}},
+		{"PORTE", 0.9, []string{"maison", "entree"}},
+		{"MER", 0.5, nil},
+		{"ROSE", 0.4, []string{"fleur"}},
+	}
+	for _, tc := range tests {
+		entry, ok := a.GetEntry(tc.word)
+		if !ok {
+			t.Errorf("%s missing after merge", tc.word)
+			continue
+		}
+		if entry.Frequency != tc.freq || !reflect.DeepEqual(entry.Tags, tc.tags) {
+			t.Errorf("%s = %v %v, want %v %v", tc.word, entry.Frequency, entry.Tags, tc.freq, tc.tags)
+		}
+	}
+	if entry, _ := b.GetEntry("CHAT"); !reflect.DeepEqual(entry.Tags, []string{"animal", "courant"}) {
+		t.Errorf("merge modified the other lexicon: %v", entry.Tags)
+	}
+	if len(a.Match(".....")) != 1 || len(a.Match("....")) != 2 {
+		t.Error("merged words are not matchable")
+	}
+
+	a, b = newLexicons()
+	a.Merge(b, MergeKeepExisting)
+	if entry, _ := a.GetEntry("PORTE"); entry.Frequency != 0.2 || !reflect.DeepEqual(entry.Tags, []string{"maison"}) {
+		t.Errorf("keep-existing PORTE = %v %v", entry.Frequency, entry.Tags)
+	}
+
+	a, b = newLexicons()
+	a.Merge(b, MergeOverwrite)
+	if entry, _ := a.GetEntry("CHAT"); entry.Frequency != 0.3 || !reflect.DeepEqual(entry.Tags, []string{"animal", "courant"}) {
+		t.Errorf("overwrite CHAT = %v %v", entry.Frequency, entry.Tags)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	lexicon := NewMemoryLexicon()
+	for _, word := range []string{"CAT", "CAR", "TEN"} {
+		lexicon.AddWord(word)
+	}
+
+	// No down word starts with A, but that crossing is already filled
+	grid, err := ParsePartialGrid([]string{"CA.", "...", "..."})
+	if err != nil {
+		t.Fatalf("ParsePartialGrid: %v", err)
+	}
+	words, err := Suggest(grid, domain.Position{Row: 0, Col: 0}, domain.DirectionAcross, 3, lexicon)
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if !reflect.DeepEqual(words, []string{"CAT"}) {
+		t.Errorf("Suggest = %v, want [CAT] (no down word starts with R)", words)
+	}
+
+	_, err = Suggest(grid, domain.Position{Row: 0, Col: 0}, domain.DirectionAcross, 4, lexicon)
+	if !errors.Is(err, ErrSlotNotFound) {
+		t.Errorf("expected ErrSlotNotFound for a wrong length, got %v", err)
+	}
+}
+
+func TestTrieLexicon_MatchesMemoryLexicon(t *testing.T) {
+	memory := SampleFrenchLexicon()
+	trie := NewTrieLexiconFrom(memory)
+
+	if trie.Size() != memory.Size() {
+		t.Errorf("Size = %d, want %d", trie.Size(), memory.Size())
+	}
+	for _, pattern := range []string{"..E..", "C.T", "c..", "...", "A....E", ".....S", "ZZZ", "", "..........."} {
+		want := memory.Match(pattern)
+		sort.Strings(want)
+		got := trie.Match(pattern)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Match(%q) = %d words, want %d", pattern, len(got), len(want))
+		}
+	}
+	for _, word := range []string{"MAISON", "maison", "MAISO", "MAISONS", "QZX"} {
+		if trie.Contains(word) != memory.Contains(word) {
+			t.Errorf("Contains(%q) = %v, want %v", word, trie.Contains(word), memory.Contains(word))
+		}
+	}
+
+	trie.Add("maison") // Already present
+	if trie.Size() != memory.Size() {
+		t.Errorf("Size after re-adding a word = %d, want %d", trie.Size(), memory.Size())
+	}
+}
+
+func BenchmarkLexicon_Match(b *testing.B) {
+	memory := SampleFrenchLexicon()
+	lexicons := []struct {
+		name    string
+		lexicon Lexicon
+	}{
+		{"memory", memory},
+		{"trie", NewTrieLexiconFrom(memory)},
+	}
+
+	for _, pattern := range []string{"..E..", "C.T", "M.....", "......"} {
+		for _, l := range lexicons {
+			b.Run(l.name+"/"+pattern, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					l.lexicon.Match(pattern)
+				}
+			})
+		}
+	}
+}
+
 func TestSolver_Simple(t *testing.T) {
 	// Very simple template that's easy to fill
 	// A B
@@ -235,6 +376,110 @@ func TestSolver_NoSolution(t *testing.T) {
 	}
 }
 
+func TestSolver_SolveContext(t *testing.T) {
+	template, err := ParseTemplate([]string{
+		"...",
+		".#.",
+		"...",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Unsolvable: every word starts with A-M and ends with N-Z
+	lexicon := NewMemoryLexicon()
+	for first := 'A'; first <= 'M'; first++ {
+		for middle := 'A'; middle <= 'Z'; middle++ {
+			for last := 'N'; last <= 'Z'; last++ {
+				lexicon.AddWord(string([]rune{first, middle, last}))
+			}
+		}
+	}
+
+	t.Run("already cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		solver := NewSolver(SolverConfig{Lexicon: lexicon, Seed: 42})
+		_, err := solver.SolveContext(ctx, template)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if errors.Is(err, ErrNoSolution) {
+			t.Errorf("cancellation should not be reported as ErrNoSolution: %v", err)
+		}
+	})
+
+	t.Run("cancelled during solve", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		solver := NewSolver(SolverConfig{
+			Lexicon:      lexicon,
+			Seed:         42,
+			MaxBacktrack: 1000000,
+			OnProgress: func(filled, total, backtracks int) {
+				if backtracks >= 100 {
+					cancel()
+				}
+			},
+		})
+		result, err := solver.SolveContext(ctx, template)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		// Checked every contextCheckInterval calls, one backtrack per call
+		if result.Backtrack > 100+contextCheckInterval {
+			t.Errorf("solve kept going for %d backtracks after cancellation", result.Backtrack-100)
+		}
+	})
+}
+
+func TestSolver_MaxBacktrackPerSlot(t *testing.T) {
+	// 4 slots around a center block. Every word starts with A-M and ends with
+	// N-Z, so no word can start where another ends: each of the 4394 words
+	// tried in the first slot fails on a crossing, one backtrack each.
+	template, err := ParseTemplate([]string{
+		"...",
+		".#.",
+		"...",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lexicon := NewMemoryLexicon()
+	for first := 'A'; first <= 'M'; first++ {
+		for middle := 'A'; middle <= 'Z'; middle++ {
+			for last := 'N'; last <= 'Z'; last++ {
+				lexicon.AddWord(string([]rune{first, middle, last}))
+			}
+		}
+	}
+
+	tests := []struct {
+		name          string
+		cfg           SolverConfig
+		minBacktracks int
+		maxBacktracks int
+	}{
+		{"per slot", SolverConfig{MaxBacktrackPerSlot: 100}, 400, 401},
+		{"absolute wins", SolverConfig{MaxBacktrack: 50, MaxBacktrackPerSlot: 100}, 50, 51},
+		{"default exhausts the search", SolverConfig{}, 4394, 4394},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Lexicon = lexicon
+			tc.cfg.Seed = 42
+			result, err := NewSolver(tc.cfg).Solve(template)
+			if err != ErrNoSolution {
+				t.Fatalf("expected ErrNoSolution, got %v", err)
+			}
+			if result.Backtrack < tc.minBacktracks || result.Backtrack > tc.maxBacktracks {
+				t.Errorf("expected %d-%d backtracks, got %d", tc.minBacktracks, tc.maxBacktracks, result.Backtrack)
+			}
+		})
+	}
+}
+
 func TestSlotPattern(t *testing.T) {
 	slot := Slot{
 		Cells: []domain.Position{
@@ -299,6 +544,100 @@ TEST
 	}
 }
 
+func TestLoadLexicon_FrequencyAndTags(t *testing.T) {
+	lexicon, err := LoadLexicon(strings.NewReader("CHAT,0.8,animal\nCHIEN,0.5"))
+	if err != nil {
+		t.Fatalf("failed to load lexicon: %v", err)
+	}
+
+	chat, ok := lexicon.GetEntry("CHAT")
+	if !ok {
+		t.Fatal("expected CHAT in lexicon")
+	}
+	if chat.Frequency != 0.8 {
+		t.Errorf("CHAT frequency = %v, want 0.8", chat.Frequency)
+	}
+	if !reflect.DeepEqual(chat.Tags, []string{"animal"}) {
+		t.Errorf("CHAT tags = %v, want [animal]", chat.Tags)
+	}
+
+	chien, ok := lexicon.GetEntry("CHIEN")
+	if !ok {
+		t.Fatal("expected CHIEN in lexicon")
+	}
+	if chien.Frequency != 0.5 {
+		t.Errorf("CHIEN frequency = %v, want 0.5", chien.Frequency)
+	}
+	if len(chien.Tags) != 0 {
+		t.Errorf("CHIEN tags = %v, want none", chien.Tags)
+	}
+}
+
+func TestLoadLexicon_DefaultLengthBounds(t *testing.T) {
+	input := `
+A
+CHAT
+INCONSTITUTIONNELLES
+`
+	lexicon, err := LoadLexicon(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to load lexicon: %v", err)
+	}
+
+	if lexicon.Contains("A") {
+		t.Error("expected 1-letter word to be excluded")
+	}
+	if lexicon.Contains("INCONSTITUTIONNELLES") {
+		t.Error("expected 20-letter word to be excluded")
+	}
+	if !lexicon.Contains("CHAT") {
+		t.Error("expected CHAT in lexicon")
+	}
+}
+
+func TestLoadLexiconFiltered(t *testing.T) {
+	input := "AU\nCHAT\nMAISON\n"
+
+	lexicon, err := LoadLexiconFiltered(strings.NewReader(input), 3, 5)
+	if err != nil {
+		t.Fatalf("failed to load lexicon: %v", err)
+	}
+
+	if lexicon.Size() != 1 || !lexicon.Contains("CHAT") {
+		t.Errorf("expected only CHAT, got %v", lexicon.Words())
+	}
+}
+
+func TestLoadCorpusFrequencies(t *testing.T) {
+	input := "# word\tcount\nmaison\t5000\nCHAT\t60\nZESTE\t2\nbroken line\nZERO\t0\n"
+
+	freqs, err := LoadCorpusFrequencies(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to load corpus: %v", err)
+	}
+
+	if len(freqs) != 3 {
+		t.Fatalf("expected 3 words, got %v", freqs)
+	}
+	if freqs["MAISON"] != 1.0 {
+		t.Errorf("expected most frequent word at 1.0, got %.3f", freqs["MAISON"])
+	}
+	if !(freqs["MAISON"] > freqs["CHAT"] && freqs["CHAT"] > freqs["ZESTE"] && freqs["ZESTE"] > 0) {
+		t.Errorf("expected frequencies ordered by count, got %v", freqs)
+	}
+
+	lexicon := NewMemoryLexicon()
+	lexicon.AddWord("CHAT")
+	lexicon.SetFrequency("chat", freqs["CHAT"])
+	lexicon.SetFrequency("MAISON", freqs["MAISON"]) // Not in lexicon, ignored
+	if entry, _ := lexicon.GetEntry("CHAT"); entry.Frequency != freqs["CHAT"] {
+		t.Errorf("expected CHAT frequency %.3f, got %.3f", freqs["CHAT"], entry.Frequency)
+	}
+	if lexicon.Contains("MAISON") {
+		t.Error("SetFrequency should not add words")
+	}
+}
+
 func TestSampleFrenchLexicon(t *testing.T) {
 	lexicon := SampleFrenchLexicon()
 
@@ -313,6 +652,13 @@ func TestSampleFrenchLexicon(t *testing.T) {
 	if !lexicon.Contains("EAU") {
 		t.Error("expected EAU in sample lexicon")
 	}
+
+	// Corpus frequencies rank common words above rare ones
+	common, _ := lexicon.GetEntry("DANS")
+	rare, _ := lexicon.GetEntry("ZESTE")
+	if common.Frequency <= rare.Frequency {
+		t.Errorf("expected DANS (%.3f) more frequent than ZESTE (%.3f)", common.Frequency, rare.Frequency)
+	}
 }
 
 func TestGridToTemplate(t *testing.T) {
@@ -451,3 +797,648 @@ func TestValidateBlockPattern_Fail_Cluster(t *testing.T) {
 		t.Error("expected violations for large cluster")
 	}
 }
+
+func TestGridBuilder_ScoreWords_Weights(t *testing.T) {
+	// Thematic candidate and base word share frequency 1.0, but the base word
+	// was merged with a 0.1 weight.
+	b := NewGridBuilder(BuilderConfig{
+		MaxRows: 7,
+		MaxCols: 7,
+		Seed:    1,
+		Weights: map[string]float64{"CHAT": 1.0, "CHAR": 0.1},
+	})
+
+	scores := make(map[string]float64)
+	for _, sw := range b.scoreWords([]string{"CHAT", "CHAR"}) {
+		scores[sw.word] = sw.score
+	}
+
+	if scores["CHAT"] <= scores["CHAR"] {
+		t.Errorf("expected thematic CHAT (%.2f) to outrank base CHAR (%.2f)", scores["CHAT"], scores["CHAR"])
+	}
+}
+
+func TestGridBuilder_ScoreWords_VowelWeight(t *testing.T) {
+	// SPORTS is a thematic word with few vowels; OISEAU is a vowel-heavy filler
+	weights := map[string]float64{"SPORTS": 1.0, "OISEAU": 0.5}
+	score := func(vowelWeight float64) map[string]float64 {
+		b := NewGridBuilder(BuilderConfig{MaxRows: 7, MaxCols: 7, Seed: 1, Weights: weights, VowelWeight: vowelWeight})
+		scores := make(map[string]float64)
+		for _, sw := range b.scoreWords([]string{"SPORTS", "OISEAU"}) {
+			scores[sw.word] = sw.score
+		}
+		return scores
+	}
+
+	if scores := score(0); scores["OISEAU"] <= scores["SPORTS"] {
+		t.Errorf("expected default vowel weight to favor OISEAU (%.2f) over SPORTS (%.2f)", scores["OISEAU"], scores["SPORTS"])
+	}
+	if scores := score(0.1); scores["SPORTS"] <= scores["OISEAU"] {
+		t.Errorf("expected low vowel weight to favor SPORTS (%.2f) over OISEAU (%.2f)", scores["SPORTS"], scores["OISEAU"])
+	}
+}
+
+// countingLexicon wraps a Lexicon and counts Match calls.
+type countingLexicon struct {
+	Lexicon
+	matches int
+}
+
+func (l *countingLexicon) Match(pattern string) []string {
+	l.matches++
+	return l.Lexicon.Match(pattern)
+}
+
+// naiveNextSlot is the uncached MRV scan: it recomputes every unfilled slot's candidates.
+func naiveNextSlot(lexicon Lexicon, slots []Slot, grid [][]rune, words map[int]string) int {
+	bestIdx := -1
+	bestScore := int(^uint(0) >> 1)
+	for i, slot := range slots {
+		if _, filled := words[slot.ID]; filled {
+			continue
+		}
+		count := len(lexicon.Match(slot.Pattern(grid)))
+		if count == 0 {
+			return i
+		}
+		if count < bestScore {
+			bestScore = count
+			bestIdx = i
+		}
+	}
+	return bestIdx
+}
+
+func TestSolver_SelectNextSlot_CachedMatchesNaive(t *testing.T) {
+	template := createTestTemplate()
+	slots := DiscoverSlots(template)
+	cached := &countingLexicon{Lexicon: SampleFrenchLexicon()}
+	naive := &countingLexicon{Lexicon: SampleFrenchLexicon()}
+	solver := NewSolver(SolverConfig{Lexicon: cached, Seed: 12345})
+
+	grid := make([][]rune, len(template))
+	for i := range grid {
+		grid[i] = make([]rune, len(template[i]))
+		for j := range grid[i] {
+			if template[i][j].IsBlock() {
+				grid[i][j] = '#'
+			} else {
+				grid[i][j] = '.'
+			}
+		}
+	}
+	words := make(map[int]string)
+
+	var placed []Slot
+	for {
+		got := solver.selectNextSlot(slots, grid, words)
+		want := naiveNextSlot(naive, slots, grid, words)
+		if got != want {
+			t.Fatalf("after %d placements: cached MRV chose %d, naive chose %d", len(placed), got, want)
+		}
+		if got == -1 {
+			break
+		}
+
+		candidates := cached.Lexicon.Match(slots[got].Pattern(grid))
+		if len(candidates) == 0 {
+			// Dead end: undo the last placement to exercise invalidation on removal
+			if len(placed) == 0 {
+				break
+			}
+			last := placed[len(placed)-1]
+			placed = placed[:len(placed)-1]
+			delete(words, last.ID)
+			solver.removeWord(last, grid, words)
+			if got, want := solver.selectNextSlot(slots, grid, words), naiveNextSlot(naive, slots, grid, words); got != want {
+				t.Fatalf("after removal: cached MRV chose %d, naive chose %d", got, want)
+			}
+			break
+		}
+
+		solver.placeWord(slots[got], candidates[0], grid)
+		words[slots[got].ID] = candidates[0]
+		placed = append(placed, slots[got])
+	}
+
+	if cached.matches >= naive.matches {
+		t.Errorf("expected fewer Match calls with cache, got %d cached vs %d naive", cached.matches, naive.matches)
+	}
+}
+
+func BenchmarkSolver_Determinism(b *testing.B) {
+	template := createTestTemplate()
+	lexicon := &countingLexicon{Lexicon: SampleFrenchLexicon()}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		solver := NewSolver(SolverConfig{
+			Lexicon: lexicon,
+			Seed:    12345,
+		})
+		if _, err := solver.Solve(template); err != nil && err != ErrNoSolution {
+			b.Fatalf("solver failed: %v", err)
+		}
+	}
+	b.ReportMetric(float64(lexicon.matches)/float64(b.N), "matches/op")
+}
+
+func TestConstraintHeatmap(t *testing.T) {
+	template := createTestTemplate()
+
+	// Plenty of 2- and 5-letter words, very few 3-letter words: the 3-letter
+	// slots crossing at the center are the hardest part of the grid.
+	lexicon := NewMemoryLexicon()
+	for a := 'A'; a <= 'Z'; a++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			lexicon.AddWord(string([]rune{a, b}))
+			lexicon.AddWord(string([]rune{a, b, 'A', 'B', 'C'}))
+		}
+	}
+	lexicon.AddWord("AMI")
+	lexicon.AddWord("ETE")
+
+	heatmap := ConstraintHeatmap(template, lexicon)
+
+	if len(heatmap) != len(template) {
+		t.Fatalf("expected %d rows, got %d", len(template), len(heatmap))
+	}
+	for i := range heatmap {
+		if len(heatmap[i]) != len(template[i]) {
+			t.Fatalf("row %d: expected %d cols, got %d", i, len(template[i]), len(heatmap[i]))
+		}
+	}
+
+	if heatmap[0][2] != 0 {
+		t.Errorf("block cell should score 0, got %.2f", heatmap[0][2])
+	}
+
+	center := heatmap[2][2]
+	for _, edge := range [][2]int{{0, 0}, {0, 1}, {1, 0}, {4, 4}} {
+		if center <= heatmap[edge[0]][edge[1]] {
+			t.Errorf("center crossing (%.2f) should be more constrained than edge cell %v (%.2f)",
+				center, edge, heatmap[edge[0]][edge[1]])
+		}
+	}
+	if center != 1.0 {
+		t.Errorf("expected most constrained cell normalized to 1.0, got %.2f", center)
+	}
+}
+
+func TestNamedTemplate(t *testing.T) {
+	for _, name := range TemplateNames() {
+		t.Run(name, func(t *testing.T) {
+			template, err := NamedTemplate(name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			solver := NewSolver(SolverConfig{Lexicon: SampleFrenchLexicon(), Seed: 42})
+			if _, err := solver.Solve(template); err != nil {
+				t.Errorf("template %s not fillable from the sample lexicon: %v", name, err)
+			}
+		})
+	}
+
+	if _, err := NamedTemplate("nope"); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("expected ErrUnknownTemplate, got %v", err)
+	}
+}
+
+func TestSymmetrize(t *testing.T) {
+	template, err := ParseTemplate([]string{
+		"#....",
+		".....",
+		".....",
+		".....",
+		".....",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repaired, clustered := Symmetrize(template, domain.SymmetryRotational)
+	if repaired[4][4].Type != domain.CellTypeBlock {
+		t.Error("expected rotational counterpart (4,4) to become a block")
+	}
+	if template[4][4].Type != domain.CellTypeLetter {
+		t.Error("input template should not be modified")
+	}
+	if clustered != 0 {
+		t.Errorf("expected no clustered blocks, got %d", clustered)
+	}
+
+	mirrored, _ := Symmetrize(template, domain.SymmetryMirror)
+	if mirrored[0][4].Type != domain.CellTypeBlock || mirrored[4][4].Type != domain.CellTypeLetter {
+		t.Error("expected only mirror counterpart (0,4) to become a block")
+	}
+
+	// Counterparts of adjacent blocks are adjacent too
+	adjacent, err := ParseTemplate([]string{
+		"##.",
+		"...",
+		"...",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, clustered := Symmetrize(adjacent, domain.SymmetryRotational); clustered != 2 {
+		t.Errorf("expected 2 clustered blocks, got %d", clustered)
+	}
+}
+
+func TestRemoveRedundantBlocks(t *testing.T) {
+	template, err := ParseTemplate([]string{
+		"....",
+		".##.",
+		".##.",
+		"....",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template[0][0].Solution = "A"
+
+	opened, removed := RemoveRedundantBlocks(template, 0)
+	if removed != 2 {
+		t.Fatalf("expected 2 blocks opened, got %d", removed)
+	}
+	for _, pos := range []domain.Position{{Row: 1, Col: 1}, {Row: 2, Col: 2}} {
+		if !opened[pos.Row][pos.Col].IsLetter() {
+			t.Errorf("expected block at (%d,%d) to be opened", pos.Row, pos.Col)
+		}
+	}
+	// Opening these would merge the entries on either side
+	for _, pos := range []domain.Position{{Row: 1, Col: 2}, {Row: 2, Col: 1}} {
+		if !opened[pos.Row][pos.Col].IsBlock() {
+			t.Errorf("expected block at (%d,%d) to stay", pos.Row, pos.Col)
+		}
+	}
+	if before, after := len(DiscoverSlots(template)), len(DiscoverSlots(opened)); before != 4 || after != 8 {
+		t.Errorf("expected entries to go from 4 to 8, got %d to %d", before, after)
+	}
+	if opened[0][0].Solution != "A" || !template[1][1].IsBlock() {
+		t.Error("expected solutions kept and the input template untouched")
+	}
+
+	// Opening the corner would lengthen both entries past the maximum
+	capped, err := ParseTemplate([]string{
+		"..#",
+		"...",
+		"...",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, removed := RemoveRedundantBlocks(capped, 2); removed != 0 {
+		t.Errorf("expected no block opened with max slot length 2, got %d", removed)
+	}
+	if _, removed := RemoveRedundantBlocks(capped, 3); removed != 1 {
+		t.Errorf("expected the corner opened with max slot length 3, got %d", removed)
+	}
+}
+
+func TestParseASCIITemplate(t *testing.T) {
+	template, err := NamedTemplate("cross-5x5")
+	if err != nil {
+		t.Fatalf("NamedTemplate: %v", err)
+	}
+	template[1][0].Solution = "A"
+
+	ascii := GridToASCII(template)
+	if want := "..#..\nA....\n#...#\n.....\n..#.."; ascii != want {
+		t.Errorf("GridToASCII = %q, want %q", ascii, want)
+	}
+	got, err := ParseASCIITemplate(ascii, '#')
+	if err != nil {
+		t.Fatalf("ParseASCIITemplate: %v", err)
+	}
+	if !reflect.DeepEqual(got, template) {
+		t.Errorf("round trip = %v, want %v", got, template)
+	}
+
+	// Custom block character, surrounding blank lines and a short row
+	got, err = ParseASCIITemplate("\n..*\r\n.\n", '*')
+	if err != nil {
+		t.Fatalf("ParseASCIITemplate: %v", err)
+	}
+	want := [][]domain.Cell{
+		{{Type: domain.CellTypeLetter}, {Type: domain.CellTypeLetter}, {Type: domain.CellTypeBlock}},
+		{{Type: domain.CellTypeLetter}, {Type: domain.CellTypeBlock}, {Type: domain.CellTypeBlock}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseASCIITemplate = %v, want %v", got, want)
+	}
+
+	// Unknown characters, including the default block character when another
+	// one is configured, are rejected
+	for _, bad := range []string{"..x", "a..", "..#"} {
+		if _, err := ParseASCIITemplate(bad, '*'); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("ParseASCIITemplate(%q): expected ErrInvalidTemplate, got %v", bad, err)
+		}
+	}
+	if _, err := ParseASCIITemplate("\n\n", 0); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("expected ErrInvalidTemplate for an empty grid, got %v", err)
+	}
+}
+
+func TestTrimBorders(t *testing.T) {
+	grid, err := ParseTemplate([]string{
+		"#####",
+		"####.",
+		"#...#",
+		"#...#",
+		"#####",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Clue cells above the down entries and left of the across entries
+	for _, pos := range []domain.Position{{Row: 1, Col: 1}, {Row: 1, Col: 2}, {Row: 2, Col: 0}, {Row: 3, Col: 0}} {
+		grid[pos.Row][pos.Col] = domain.Cell{Type: domain.CellTypeClue, ClueDown: "Indice"}
+	}
+
+	trimmed := TrimBorders(grid)
+	if len(trimmed) != 3 || len(trimmed[0]) != 5 {
+		t.Fatalf("expected a 3x5 grid, got %dx%d", len(trimmed), len(trimmed[0]))
+	}
+	if !trimmed[0][1].IsClue() || !trimmed[0][4].IsLetter() || !trimmed[1][0].IsClue() {
+		t.Error("expected the clue padding row and column kept")
+	}
+	if !trimmed[2][3].IsLetter() {
+		t.Error("expected the letters kept in place relative to the clue cells")
+	}
+
+	blank, _ := ParseTemplate([]string{"##", "##"})
+	if got := TrimBorders(blank); len(got) != 2 {
+		t.Errorf("expected a grid without letters unchanged, got %d rows", len(got))
+	}
+}
+
+func TestGridBuilder_Build_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		want       error
+	}{
+		{"too few words", []string{"CHAT", "CHIEN", "NICHE"}, ErrInsufficientCandidates},
+		{"no shared letters", []string{"ABC", "DEF", "GHI", "JKL", "MNO", "PQR", "STU", "VWX", "YZ"}, ErrNoViableCrossings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: 42}).Build(tt.candidates)
+			if result.Success {
+				t.Fatalf("expected build to fail, placed %v", result.Words)
+			}
+			if !errors.Is(result.Err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, result.Err)
+			}
+		})
+	}
+
+	result := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: 42}).Build(SampleFrenchLexicon().Words())
+	if !result.Success || result.Err != nil {
+		t.Errorf("expected successful build without error, got %v", result.Err)
+	}
+}
+
+func TestGridBuilder_Build_NoDuplicateWords(t *testing.T) {
+	// Few short words, repeated and in mixed case, so gap filling keeps
+	// running into words that are already placed
+	candidates := []string{
+		"MAISON", "PLAGE", "SOLEIL", "OCEAN", "VAGUE", "SABLE",
+		"RUE", "RUE", "rue", "MER", "SEL", "EAU", "ILE",
+	}
+
+	for seed := int64(1); seed <= 50; seed++ {
+		result := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: seed}).Build(candidates)
+		seen := make(map[string]bool)
+		for _, word := range result.Words {
+			upper := strings.ToUpper(word)
+			if seen[upper] {
+				t.Errorf("seed %d: %s placed twice in %v", seed, upper, result.Words)
+			}
+			seen[upper] = true
+		}
+	}
+
+	b := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: 1})
+	if err := b.PrePlace("RUE", 1, 1, domain.DirectionAcross); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gap := Gap{Row: 5, Col: 1, Length: 3, Direction: domain.DirectionAcross}
+	if b.canFillGap("RUE", gap) || b.canFillGap("rue", gap) {
+		t.Error("expected a placed word to be rejected as gap filler")
+	}
+	if !b.canFillGap("MER", gap) {
+		t.Error("expected an unused word to fit the gap")
+	}
+}
+
+func TestGridBuilder_PlaceNext(t *testing.T) {
+	candidates := SampleFrenchLexicon().Words()
+	builder := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: 42})
+
+	countLetters := func(grid [][]domain.Cell) int {
+		n := 0
+		for _, row := range grid {
+			for _, cell := range row {
+				if cell.Type == domain.CellTypeLetter {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	var placed []string
+	letters := 0
+	for step := 0; ; step++ {
+		if step > 100 {
+			t.Fatal("PlaceNext never returned false")
+		}
+		pw, ok := builder.PlaceNext(candidates)
+		if !ok {
+			break
+		}
+		placed = append(placed, pw.Word)
+
+		current := countLetters(builder.Current())
+		if current < letters {
+			t.Fatalf("step %d: grid shrank from %d to %d letters", step, letters, current)
+		}
+		letters = current
+	}
+
+	if len(placed) < 8 {
+		t.Errorf("expected at least 8 words placed, got %d", len(placed))
+	}
+	if _, ok := builder.PlaceNext(candidates); ok {
+		t.Error("expected PlaceNext to stay false once done")
+	}
+
+	// Build runs the same steps
+	result := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: 42}).Build(candidates)
+	if strings.Join(result.Words, ",") != strings.Join(placed, ",") {
+		t.Errorf("Build placed %v, steps placed %v", result.Words, placed)
+	}
+}
+
+func TestSolver_Preprocess(t *testing.T) {
+	lexicon := SampleFrenchLexicon()
+	template, err := NamedTemplate("diagonal-7x7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slots := DiscoverSlots(template)
+	grid := make([][]rune, len(template))
+	for i, row := range template {
+		grid[i] = make([]rune, len(row))
+		for j, cell := range row {
+			if cell.IsLetter() {
+				grid[i][j] = '.'
+			} else {
+				grid[i][j] = '#'
+			}
+		}
+	}
+
+	pruned := pruneCandidates(slots, grid, lexicon)
+	rawTotal, prunedTotal := 0, 0
+	for _, slot := range slots {
+		raw := len(lexicon.Match(slot.Pattern(grid)))
+		if len(pruned[slot.ID]) > raw {
+			t.Errorf("slot %d: %d candidates after preprocessing, %d raw", slot.ID, len(pruned[slot.ID]), raw)
+		}
+		rawTotal += raw
+		prunedTotal += len(pruned[slot.ID])
+	}
+	t.Logf("candidates: %d raw, %d after preprocessing", rawTotal, prunedTotal)
+
+	solver := NewSolver(SolverConfig{Lexicon: lexicon, Seed: 42, Preprocess: true})
+	result, err := solver.Solve(template)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, slot := range slots {
+		word, ok := result.Words[slot.ID]
+		if !ok {
+			t.Fatalf("slot %d not filled", slot.ID)
+		}
+		if !lexicon.Contains(word) {
+			t.Errorf("slot %d: %q is not in the lexicon", slot.ID, word)
+		}
+		if got := slot.ExtractWord(result.Grid); got != word {
+			t.Errorf("slot %d: grid reads %q, want %q", slot.ID, got, word)
+		}
+	}
+}
+
+func TestSolver_OnProgress(t *testing.T) {
+	template, err := NamedTemplate("diagonal-7x7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	total := len(DiscoverSlots(template))
+
+	var calls [][3]int
+	solver := NewSolver(SolverConfig{
+		Lexicon: SampleFrenchLexicon(),
+		Seed:    42,
+		OnProgress: func(filled, total, backtracks int) {
+			calls = append(calls, [3]int{filled, total, backtracks})
+		},
+	})
+	if _, err := solver.Solve(template); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(calls) == 0 {
+		t.Fatal("expected progress callbacks")
+	}
+	for i, call := range calls {
+		if call[1] != total {
+			t.Errorf("call %d: total = %d, want %d", i, call[1], total)
+		}
+		if i > 0 && call[0] < calls[i-1][0] {
+			t.Errorf("call %d: filled decreased from %d to %d", i, calls[i-1][0], call[0])
+		}
+	}
+	if last := calls[len(calls)-1]; last[0] != total {
+		t.Errorf("final call filled = %d, want %d", last[0], total)
+	}
+
+	// A nil callback must be safe
+	if _, err := NewSolver(SolverConfig{Lexicon: SampleFrenchLexicon(), Seed: 42}).Solve(template); err != nil {
+		t.Fatalf("unexpected error without callback: %v", err)
+	}
+}
+
+func TestGridBuilder_PrePlace(t *testing.T) {
+	const word = "ETOILES"
+	candidates := SampleFrenchLexicon().Words()
+	builder := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: 42})
+
+	row, col := 5, 2 // Centered across a 10-wide target
+	if err := builder.PrePlace(word, row, col, domain.DirectionAcross); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := builder.PrePlace("MAISON", 0, 20, domain.DirectionAcross); !errors.Is(err, ErrWordDoesNotFit) {
+		t.Errorf("expected ErrWordDoesNotFit out of bounds, got %v", err)
+	}
+
+	result := builder.Build(candidates)
+	if result.Words[0] != word {
+		t.Errorf("expected pinned word first, got %v", result.Words)
+	}
+	if len(result.Words) < 2 {
+		t.Errorf("expected the build to grow around the pinned word, got %v", result.Words)
+	}
+
+	originRow, originCol := builder.Origin()
+	for i := range word {
+		cell := result.Grid[row-originRow][col-originCol+i]
+		if cell.Solution != string(word[i]) {
+			t.Fatalf("pinned word altered at offset %d: got %q, want %q", i, cell.Solution, string(word[i]))
+		}
+	}
+
+	if err := builder.PrePlace("MAISON", 2, 2, domain.DirectionAcross); !errors.Is(err, ErrBuildStarted) {
+		t.Errorf("expected ErrBuildStarted after Build, got %v", err)
+	}
+}
+
+func TestGridBuilder_PrePlace_SeedsDownWord(t *testing.T) {
+	const word = "ETOILES"
+	row, col := 5, 2
+	candidates := SampleFrenchLexicon().Words()
+	pinned := func() *GridBuilder {
+		builder := NewGridBuilder(BuilderConfig{MaxRows: 10, MaxCols: 10, Seed: 42})
+		if err := builder.PrePlace(word, row, col, domain.DirectionAcross); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return builder
+	}
+
+	// The pinned across word stands in for the center seed, so the next word
+	// is the seed down word crossing it
+	pw, ok := pinned().PlaceNext(candidates)
+	if !ok {
+		t.Fatal("expected a word to be placed")
+	}
+	ref := pinned()
+	ref.start(candidates)
+	want := ref.placeSeedDown()
+	if want == nil {
+		t.Fatal("expected a seed down word to fit across the pinned word")
+	}
+	if *pw != *want {
+		t.Errorf("expected seed down word %+v, got %+v", *want, *pw)
+	}
+	if pw.Direction != domain.DirectionDown || pw.Word[row-pw.Row] != word[pw.Col-col] {
+		t.Errorf("expected a down word crossing %q, got %+v", word, *pw)
+	}
+}
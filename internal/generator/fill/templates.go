@@ -0,0 +1,365 @@
+package fill
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"lesmotsdatche/internal/domain"
+)
+
+// ErrUnknownTemplate is returned when a named template does not exist.
+var ErrUnknownTemplate = errors.New("unknown template")
+
+// ErrInvalidTemplate is returned when template rows are empty, of unequal
+// lengths, or contain characters other than '.' and '#'.
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// namedTemplates holds the built-in grid templates, one string per row:
+// '.' is a letter cell and '#' a block.
+var namedTemplates = map[string][]string{
+	// Small cross pattern, quick to fill
+	"cross-5x5": {
+		"..#..",
+		".....",
+		"#...#",
+		".....",
+		"..#..",
+	},
+	// 6x6 with corner blocks and a staggered center
+	"stairs-6x6": {
+		"#.....",
+		"...#..",
+		"..#...",
+		"...#..",
+		"..#...",
+		".....#",
+	},
+	// 7x7 with diagonal block runs, mostly 3-4 letter entries
+	"diagonal-7x7": {
+		"....#..",
+		"...#...",
+		"..#...#",
+		".#...#.",
+		"#...#..",
+		"...#...",
+		"..#....",
+	},
+}
+
+// TemplateNames returns the names of the built-in templates, sorted.
+func TemplateNames() []string {
+	names := make([]string, 0, len(namedTemplates))
+	for name := range namedTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NamedTemplate returns a fresh copy of a built-in template.
+func NamedTemplate(name string) ([][]domain.Cell, error) {
+	rows, ok := namedTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (available: %v)", ErrUnknownTemplate, name, TemplateNames())
+	}
+
+	return ParseTemplate(rows)
+}
+
+// ParseTemplate builds a template from one string per row: '.' is a letter
+// cell and '#' a block.
+func ParseTemplate(rows []string) ([][]domain.Cell, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrInvalidTemplate)
+	}
+
+	template := make([][]domain.Cell, len(rows))
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("%w: row %d has %d cells, expected %d", ErrInvalidTemplate, i, len(row), len(rows[0]))
+		}
+		template[i] = make([]domain.Cell, len(row))
+		for j, c := range row {
+			switch c {
+			case '#':
+				template[i][j] = domain.Cell{Type: domain.CellTypeBlock}
+			case '.':
+				template[i][j] = domain.Cell{Type: domain.CellTypeLetter}
+			default:
+				return nil, fmt.Errorf("%w: unexpected %q at (%d,%d)", ErrInvalidTemplate, c, i, j)
+			}
+		}
+	}
+	return template, nil
+}
+
+// ParsePartialGrid builds a grid from one string per row like ParseTemplate,
+// where a letter A-Z is a letter cell already holding that letter.
+func ParsePartialGrid(rows []string) ([][]domain.Cell, error) {
+	blank := make([]string, len(rows))
+	for i, row := range rows {
+		blank[i] = strings.Map(func(c rune) rune {
+			if c >= 'A' && c <= 'Z' {
+				return '.'
+			}
+			return c
+		}, row)
+	}
+	grid, err := ParseTemplate(blank)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		for j, c := range row {
+			if c >= 'A' && c <= 'Z' {
+				grid[i][j].Solution = string(c)
+			}
+		}
+	}
+	return grid, nil
+}
+
+// DefaultBlockChar is the block character of ASCII grids.
+const DefaultBlockChar = '#'
+
+// GridToASCII renders a grid one line per row: '#' for blocks and clue
+// cells, the solution letter of filled letter cells and '.' for empty ones.
+func GridToASCII(grid [][]domain.Cell) string {
+	var sb strings.Builder
+	for i, row := range grid {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, cell := range row {
+			switch {
+			case !cell.IsLetter():
+				sb.WriteRune(DefaultBlockChar)
+			case cell.Solution != "":
+				sb.WriteString(cell.Solution)
+			default:
+				sb.WriteByte('.')
+			}
+		}
+	}
+	return sb.String()
+}
+
+// ParseASCIITemplate reads a multi-line ASCII grid such as GridToASCII
+// writes into a grid as ParsePartialGrid does. blockChar marks blocks
+// (0 = DefaultBlockChar), '.' an empty letter cell and a letter A-Z a letter
+// cell holding that letter; any other character is an error. Blank lines
+// around the grid are ignored and rows shorter than the widest one are padded
+// with blocks.
+func ParseASCIITemplate(s string, blockChar rune) ([][]domain.Cell, error) {
+	if blockChar == 0 {
+		blockChar = DefaultBlockChar
+	}
+
+	lines := strings.Split(strings.Trim(strings.ReplaceAll(s, "\r\n", "\n"), "\n"), "\n")
+	rows := make([]string, len(lines))
+	cols := 0
+	for i, line := range lines {
+		var sb strings.Builder
+		for j, c := range []rune(line) {
+			switch {
+			case c == blockChar:
+				sb.WriteRune(DefaultBlockChar)
+			case c == '.' || c >= 'A' && c <= 'Z':
+				sb.WriteRune(c)
+			default:
+				return nil, fmt.Errorf("%w: unexpected %q at (%d,%d)", ErrInvalidTemplate, c, i, j)
+			}
+		}
+		rows[i] = sb.String()
+		cols = max(cols, len(rows[i]))
+	}
+	for i, row := range rows {
+		rows[i] = row + strings.Repeat(string(DefaultBlockChar), cols-len(row))
+	}
+
+	return ParsePartialGrid(rows)
+}
+
+// Symmetrize returns a copy of a template with the counterpart of every block
+// (or clue cell) under the symmetry mode turned into a block. It also returns
+// how many added blocks touch another block, creating a cluster that block
+// pattern checks may reject; callers should warn when it is non-zero.
+func Symmetrize(template [][]domain.Cell, mode domain.SymmetryMode) ([][]domain.Cell, int) {
+	result := make([][]domain.Cell, len(template))
+	for i, row := range template {
+		result[i] = append([]domain.Cell(nil), row...)
+	}
+	if len(template) == 0 {
+		return result, 0
+	}
+
+	rows, cols := len(template), len(template[0])
+	var added []domain.Position
+	for i, row := range template {
+		for j, cell := range row {
+			if cell.IsLetter() {
+				continue
+			}
+			r, c, ok := mode.Partner(i, j, rows, cols)
+			if !ok || r >= len(result) || c >= len(result[r]) || !result[r][c].IsLetter() {
+				continue
+			}
+			result[r][c] = domain.Cell{Type: domain.CellTypeBlock}
+			added = append(added, domain.Position{Row: r, Col: c})
+		}
+	}
+
+	clustered := 0
+	for _, pos := range added {
+		for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			r, c := pos.Row+d[0], pos.Col+d[1]
+			if r >= 0 && r < len(result) && c >= 0 && c < len(result[r]) && !result[r][c].IsLetter() {
+				clustered++
+				break
+			}
+		}
+	}
+	return result, clustered
+}
+
+// RemoveRedundantBlocks returns a copy of a template where blocks that do not
+// separate two entries are opened into empty letter cells, with the number of
+// blocks opened. A block is opened only if no direction has letters on both
+// sides of it (which would merge two entries), the entries through it are at
+// most maxSlotLength long (0 = unlimited), and it ends up in at least one
+// entry. Other cells keep their solutions, so a filled grid must be filled
+// again after opening blocks.
+func RemoveRedundantBlocks(template [][]domain.Cell, maxSlotLength int) ([][]domain.Cell, int) {
+	result := make([][]domain.Cell, len(template))
+	for i, row := range template {
+		result[i] = append([]domain.Cell(nil), row...)
+	}
+
+	removed := 0
+	for i, row := range result {
+		for j, cell := range row {
+			if cell.IsBlock() && canOpenBlock(result, i, j, maxSlotLength) {
+				result[i][j] = domain.Cell{Type: domain.CellTypeLetter}
+				removed++
+			}
+		}
+	}
+	return result, removed
+}
+
+// canOpenBlock reports whether the block at row, col can become a letter cell
+// under the rules of RemoveRedundantBlocks.
+func canOpenBlock(grid [][]domain.Cell, row, col, maxSlotLength int) bool {
+	inEntry := false
+	for _, d := range [][2]int{{0, 1}, {1, 0}} {
+		before := letterRun(grid, row, col, -d[0], -d[1])
+		after := letterRun(grid, row, col, d[0], d[1])
+		if before > 0 && after > 0 {
+			return false
+		}
+		length := before + after + 1
+		if maxSlotLength > 0 && length > maxSlotLength {
+			return false
+		}
+		if length >= 2 {
+			inEntry = true
+		}
+	}
+	return inEntry
+}
+
+// letterRun counts the consecutive letter cells from the neighbor of row, col
+// in direction dr, dc.
+func letterRun(grid [][]domain.Cell, row, col, dr, dc int) int {
+	n := 0
+	for r, c := row+dr, col+dc; r >= 0 && r < len(grid) && c >= 0 && c < len(grid[r]) && grid[r][c].IsLetter(); r, c = r+dr, c+dc {
+		n++
+	}
+	return n
+}
+
+// TrimBorders returns a grid without its leading and trailing rows and
+// columns that hold no letter or clue cell. A padding row or column of clue
+// cells is kept, but one of plain blocks adds no entry and is removed, so it
+// is meant for grids whose clue cells are already placed. A grid without
+// letter or clue cells is returned unchanged.
+func TrimBorders(grid [][]domain.Cell) [][]domain.Cell {
+	minRow, maxRow := len(grid), -1
+	minCol, maxCol := -1, -1
+	for i, row := range grid {
+		for j, cell := range row {
+			if !cell.IsLetter() && !cell.IsClue() {
+				continue
+			}
+			minRow, maxRow = min(minRow, i), max(maxRow, i)
+			if minCol < 0 || j < minCol {
+				minCol = j
+			}
+			maxCol = max(maxCol, j)
+		}
+	}
+	if maxRow < 0 {
+		return grid
+	}
+
+	trimmed := make([][]domain.Cell, 0, maxRow-minRow+1)
+	for _, row := range grid[minRow : maxRow+1] {
+		trimmed = append(trimmed, append([]domain.Cell(nil), row[minCol:maxCol+1]...))
+	}
+	return trimmed
+}
+
+// bottleneckCandidates is the candidate count under which a slot is reported
+// as a bottleneck.
+const bottleneckCandidates = 5
+
+// SlotAnalysis describes one slot of an analyzed template.
+type SlotAnalysis struct {
+	ID         int              `json:"id"`
+	Direction  domain.Direction `json:"direction"`
+	Start      domain.Position  `json:"start"`
+	Length     int              `json:"length"`
+	Candidates int              `json:"candidates"` // Lexicon words matching the empty slot
+}
+
+// TemplateAnalysis estimates how hard a template is to fill with a lexicon.
+type TemplateAnalysis struct {
+	Slots       int            `json:"slots"`
+	Bottlenecks []SlotAnalysis `json:"bottlenecks"` // Slots with fewer than 5 candidates, fewest first
+	Fillability float64        `json:"fillability"` // Share of slots that are not bottlenecks; 0 if any slot has no candidate
+}
+
+// AnalyzeTemplate counts the candidates of every slot of a template and
+// reports the bottlenecks. It looks at slots independently, so a template
+// without bottlenecks can still be unfillable once crossings are considered.
+func AnalyzeTemplate(template [][]domain.Cell, lexicon Lexicon) *TemplateAnalysis {
+	slots, counts := slotCandidates(template, lexicon)
+	analysis := &TemplateAnalysis{Slots: len(slots), Bottlenecks: []SlotAnalysis{}}
+	dead := false
+	for _, slot := range slots {
+		count := counts[slot.ID]
+		if count == 0 {
+			dead = true
+		}
+		if count < bottleneckCandidates {
+			analysis.Bottlenecks = append(analysis.Bottlenecks, SlotAnalysis{
+				ID:         slot.ID,
+				Direction:  slot.Direction,
+				Start:      slot.Start,
+				Length:     slot.Length,
+				Candidates: count,
+			})
+		}
+	}
+	sort.SliceStable(analysis.Bottlenecks, func(i, j int) bool {
+		return analysis.Bottlenecks[i].Candidates < analysis.Bottlenecks[j].Candidates
+	})
+
+	if len(slots) > 0 && !dead {
+		analysis.Fillability = float64(len(slots)-len(analysis.Bottlenecks)) / float64(len(slots))
+	}
+	return analysis
+}
@@ -0,0 +1,121 @@
+package fill
+
+import (
+	"sort"
+	"strings"
+)
+
+// TrieLexicon is a lexicon indexed by a trie per word length, so Match only
+// walks the branches whose letters agree with the pattern instead of testing
+// every word of that length. It returns the same words as MemoryLexicon, in
+// alphabetical order.
+type TrieLexicon struct {
+	roots map[int]*trieNode // Trie of the words of each length
+	size  int
+}
+
+// trieNode is one letter position of a trie. Children are kept sorted by
+// label so matches come out in alphabetical order.
+type trieNode struct {
+	labels   []byte
+	children []*trieNode
+	word     string // Set on the nodes ending a word
+}
+
+// NewTrieLexicon creates an empty trie lexicon.
+func NewTrieLexicon() *TrieLexicon {
+	return &TrieLexicon{roots: make(map[int]*trieNode)}
+}
+
+// NewTrieLexiconFrom indexes the words of a memory lexicon.
+func NewTrieLexiconFrom(l *MemoryLexicon) *TrieLexicon {
+	t := NewTrieLexicon()
+	for _, word := range l.Words() {
+		t.Add(word)
+	}
+	return t
+}
+
+// Add adds a word to the lexicon.
+func (t *TrieLexicon) Add(word string) {
+	word = strings.ToUpper(word)
+	node, ok := t.roots[len(word)]
+	if !ok {
+		node = &trieNode{}
+		t.roots[len(word)] = node
+	}
+
+	for i := 0; i < len(word); i++ {
+		node = node.child(word[i], true)
+	}
+	if node.word == "" {
+		node.word = word
+		t.size++
+	}
+}
+
+// child returns the child of n labeled c, adding it if create is set.
+func (n *trieNode) child(c byte, create bool) *trieNode {
+	i := sort.Search(len(n.labels), func(i int) bool { return n.labels[i] >= c })
+	if i < len(n.labels) && n.labels[i] == c {
+		return n.children[i]
+	}
+	if !create {
+		return nil
+	}
+
+	child := &trieNode{}
+	n.labels = append(n.labels, 0)
+	copy(n.labels[i+1:], n.labels[i:])
+	n.labels[i] = c
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+	return child
+}
+
+// Match returns words matching the pattern (dots = wildcards).
+func (t *TrieLexicon) Match(pattern string) []string {
+	pattern = strings.ToUpper(pattern)
+	root, ok := t.roots[len(pattern)]
+	if !ok {
+		return nil
+	}
+
+	var matches []string
+	var walk func(n *trieNode, depth int)
+	walk = func(n *trieNode, depth int) {
+		if depth == len(pattern) {
+			matches = append(matches, n.word)
+			return
+		}
+		if c := pattern[depth]; c != '.' {
+			if child := n.child(c, false); child != nil {
+				walk(child, depth+1)
+			}
+			return
+		}
+		for _, child := range n.children {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	return matches
+}
+
+// Contains returns true if the word is in the lexicon.
+func (t *TrieLexicon) Contains(word string) bool {
+	word = strings.ToUpper(word)
+	node, ok := t.roots[len(word)]
+	for i := 0; ok && i < len(word); i++ {
+		node = node.child(word[i], false)
+		ok = node != nil
+	}
+	return ok && node.word != ""
+}
+
+// Size returns the number of words.
+func (t *TrieLexicon) Size() int {
+	return t.size
+}
@@ -7,19 +7,24 @@ import (
 // EnglishPack implements LanguagePack for English crosswords.
 // This is a stub implementation for future English support.
 type EnglishPack struct {
-	tabooSet map[string]bool
+	tabooSet        map[string]bool
+	abbreviationSet map[string]bool
 }
 
 // NewEnglishPack creates a new English language pack (stub).
 func NewEnglishPack() *EnglishPack {
 	pack := &EnglishPack{
-		tabooSet: make(map[string]bool),
+		tabooSet:        make(map[string]bool),
+		abbreviationSet: make(map[string]bool),
 	}
 
 	// Initialize taboo list
 	for _, word := range englishTabooList {
 		pack.tabooSet[word] = true
 	}
+	for _, word := range englishAbbreviationList {
+		pack.abbreviationSet[word] = true
+	}
 
 	return pack
 }
@@ -50,6 +55,18 @@ func (p *EnglishPack) TabooList() []string {
 	return englishTabooList
 }
 
+// Alphabet returns nil: normalized English uses A-Z only.
+func (p *EnglishPack) Alphabet() []rune {
+	return nil
+}
+
+// IsAbbreviation returns true if the word is a known English abbreviation
+// or a short word without vowels.
+func (p *EnglishPack) IsAbbreviation(word string) bool {
+	normalized := p.Normalize(word)
+	return p.abbreviationSet[normalized] || looksLikeAbbreviation(normalized)
+}
+
 // IsConfigured returns false (English is a stub).
 func (p *EnglishPack) IsConfigured() bool {
 	return false // Stub - not ready for production use
@@ -62,6 +79,7 @@ func (p *EnglishPack) Prompts() PromptTemplates {
 		SlotCandidates:  englishSlotPrompt,
 		ClueGeneration:  englishCluePrompt,
 		ClueStyle:       englishClueStyle,
+		PlaceholderClue: "(missing clue)",
 	}
 }
 
@@ -74,6 +92,12 @@ var englishTabooList = []string{
 	"NAZI", "GENOCIDE", "RAPE",
 }
 
+// Common English abbreviations and acronyms
+var englishAbbreviationList = []string{
+	"FBI", "CIA", "USA", "NATO", "UN", "EU", "BBC", "NBA",
+	"ASAP", "DIY", "TV", "NASA", "UK", "CEO", "DNA", "RSVP",
+}
+
 // English prompt templates (placeholders)
 var englishThemePrompt = `You are an expert crossword puzzle creator.
 
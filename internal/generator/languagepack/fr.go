@@ -6,19 +6,24 @@ import (
 
 // FrenchPack implements LanguagePack for French crosswords.
 type FrenchPack struct {
-	tabooSet map[string]bool
+	tabooSet        map[string]bool
+	abbreviationSet map[string]bool
 }
 
 // NewFrenchPack creates a new French language pack.
 func NewFrenchPack() *FrenchPack {
 	pack := &FrenchPack{
-		tabooSet: make(map[string]bool),
+		tabooSet:        make(map[string]bool),
+		abbreviationSet: make(map[string]bool),
 	}
 
 	// Initialize taboo list
 	for _, word := range frenchTabooList {
 		pack.tabooSet[word] = true
 	}
+	for _, word := range frenchAbbreviationList {
+		pack.abbreviationSet[word] = true
+	}
 
 	return pack
 }
@@ -49,6 +54,18 @@ func (p *FrenchPack) TabooList() []string {
 	return frenchTabooList
 }
 
+// Alphabet returns nil: normalized French uses A-Z only.
+func (p *FrenchPack) Alphabet() []rune {
+	return nil
+}
+
+// IsAbbreviation returns true if the word is a known French abbreviation
+// or a short word without vowels.
+func (p *FrenchPack) IsAbbreviation(word string) bool {
+	normalized := p.Normalize(word)
+	return p.abbreviationSet[normalized] || looksLikeAbbreviation(normalized)
+}
+
 // IsConfigured returns true (French is fully configured).
 func (p *FrenchPack) IsConfigured() bool {
 	return true
@@ -61,6 +78,7 @@ func (p *FrenchPack) Prompts() PromptTemplates {
 		SlotCandidates:  frenchSlotPrompt,
 		ClueGeneration:  frenchCluePrompt,
 		ClueStyle:       frenchClueStyle,
+		PlaceholderClue: "(définition manquante)",
 	}
 }
 
@@ -78,6 +96,13 @@ var frenchTabooList = []string{
 	"NAZI", "GENOCIDE", "VIOL", "VIOLER",
 }
 
+// Common French abbreviations and acronyms
+var frenchAbbreviationList = []string{
+	"ONG", "SNCF", "RATP", "EDF", "TVA", "PME", "HLM", "PDG",
+	"SMIC", "CGT", "RER", "TGV", "ADN", "UE", "ONU", "OTAN",
+	"SAMU", "CNRS", "INSEE", "BTP", "RSA", "CAF", "SDF", "VTT",
+}
+
 // French prompt templates
 var frenchThemePrompt = `Tu es un expert en création de mots croisés français.
 
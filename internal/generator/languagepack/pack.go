@@ -3,6 +3,7 @@ package languagepack
 
 import (
 	"errors"
+	"strings"
 )
 
 // ErrNotConfigured is returned when a language pack is not fully configured.
@@ -19,12 +20,19 @@ type LanguagePack interface {
 	// Normalize converts text to grid-compatible format (A-Z only).
 	Normalize(text string) string
 
+	// Alphabet returns the letters valid in grid solutions, or nil for A-Z.
+	Alphabet() []rune
+
 	// IsTaboo returns true if the word should be avoided.
 	IsTaboo(word string) bool
 
 	// TabooList returns the list of taboo words.
 	TabooList() []string
 
+	// IsAbbreviation returns true if the word looks like an abbreviation or
+	// acronym (SNCF, ONG) rather than a dictionary word.
+	IsAbbreviation(word string) bool
+
 	// IsConfigured returns true if the pack is ready for use.
 	IsConfigured() bool
 
@@ -32,6 +40,18 @@ type LanguagePack interface {
 	Prompts() PromptTemplates
 }
 
+// maxAbbreviationLength is the longest vowel-less word treated as an abbreviation.
+const maxAbbreviationLength = 5
+
+// looksLikeAbbreviation reports whether a normalized word is short and has no
+// vowel (Y counts as one), which real words almost never are.
+func looksLikeAbbreviation(word string) bool {
+	if word == "" || len(word) > maxAbbreviationLength {
+		return false
+	}
+	return !strings.ContainsAny(word, "AEIOUY")
+}
+
 // PromptTemplates contains LLM prompt templates for a language.
 type PromptTemplates struct {
 	// ThemeGeneration generates a theme and candidate entries.
@@ -45,6 +65,9 @@ type PromptTemplates struct {
 
 	// ClueStyle describes the desired clue writing style.
 	ClueStyle string
+
+	// PlaceholderClue is shown in place of a clue the LLM did not write.
+	PlaceholderClue string
 }
 
 // Registry holds available language packs.
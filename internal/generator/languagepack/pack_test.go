@@ -55,6 +55,21 @@ func TestFrenchPack_IsTaboo(t *testing.T) {
 	}
 }
 
+func TestFrenchPack_IsAbbreviation(t *testing.T) {
+	pack := NewFrenchPack()
+
+	for _, word := range []string{"SNCF", "ong", "TVA", "PDG", "otan"} {
+		if !pack.IsAbbreviation(word) {
+			t.Errorf("expected %q to be an abbreviation", word)
+		}
+	}
+	for _, word := range []string{"MAISON", "ONDE", "OR", "TSAR", "RYTHME"} {
+		if pack.IsAbbreviation(word) {
+			t.Errorf("expected %q not to be an abbreviation", word)
+		}
+	}
+}
+
 func TestFrenchPack_IsConfigured(t *testing.T) {
 	pack := NewFrenchPack()
 	if !pack.IsConfigured() {
@@ -78,6 +93,9 @@ func TestFrenchPack_Prompts(t *testing.T) {
 	if prompts.ClueStyle == "" {
 		t.Error("expected non-empty ClueStyle prompt")
 	}
+	if prompts.PlaceholderClue == "" {
+		t.Error("expected non-empty PlaceholderClue")
+	}
 }
 
 func TestEnglishPack_Code(t *testing.T) {
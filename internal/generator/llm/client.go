@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
 )
@@ -23,6 +25,30 @@ type Request struct {
 	Temperature  float64           `json:"temperature,omitempty"`
 	Schema       *jsonschema.Schema `json:"-"` // For output validation
 	SchemaName   string            `json:"schema_name,omitempty"`
+	Model        string            `json:"model,omitempty"` // Overrides the client's model ("" = client default, or the model set with WithModel)
+
+	// ToolName and ToolSchema (the JSON schema of the tool arguments) ask
+	// providers with tool calling to answer through a call to that tool, whose
+	// arguments become Response.Content. Other providers ignore them and the
+	// prompt alone describes the output.
+	ToolName   string          `json:"tool_name,omitempty"`
+	ToolSchema json.RawMessage `json:"-"`
+}
+
+// modelKey is the context key of the model set with WithModel.
+type modelKey struct{}
+
+// WithModel returns a context whose ValidatingClient requests use model,
+// unless the request sets its own. It overrides the model for every call of an
+// operation without threading it through each request.
+func WithModel(ctx context.Context, model string) context.Context {
+	return context.WithValue(ctx, modelKey{}, model)
+}
+
+// ModelFromContext returns the model set with WithModel, or "".
+func ModelFromContext(ctx context.Context) string {
+	model, _ := ctx.Value(modelKey{}).(string)
+	return model
 }
 
 // Response represents an LLM response.
@@ -38,6 +64,13 @@ type Client interface {
 	Complete(ctx context.Context, req Request) (*Response, error)
 }
 
+// Describer is implemented by clients that can name their provider and
+// default model.
+type Describer interface {
+	Provider() string
+	Model() string
+}
+
 // Config holds client configuration.
 type Config struct {
 	MaxRetries     int     // Max retry attempts for validation failures
@@ -45,15 +78,27 @@ type Config struct {
 	DefaultTemp    float64 // Default temperature
 	DefaultTokens  int     // Default max tokens
 	RedactSecrets  bool    // Whether to redact secrets in traces
+
+	PerRequestTimeout time.Duration // Deadline for each underlying Complete call (0 = none)
+	TransportRetries  int           // Extra Complete calls after a request error (0 = fail immediately)
+
+	// A response cut off by the token limit (finish_reason "length") is
+	// retried with MaxTokens doubled, up to MaxTokensCeiling; once at the
+	// ceiling, ConcisePrompt is appended to the original prompt instead.
+	MaxTokensCeiling int
+	ConcisePrompt    string
 }
 
 // DefaultConfig returns default client configuration.
 func DefaultConfig() Config {
 	return Config{
-		MaxRetries:    3,
-		DefaultTemp:   0.7,
-		DefaultTokens: 2048,
-		RedactSecrets: true,
+		MaxRetries:       3,
+		DefaultTemp:      0.7,
+		DefaultTokens:    2048,
+		RedactSecrets:    true,
+		MaxTokensCeiling: 8192,
+		ConcisePrompt: `Your previous response was cut off by the length limit.
+Answer again with the same JSON structure, but keep every field as short as possible.`,
 		RepairPrompt: `The previous response was invalid JSON or didn't match the required schema.
 Error: %s
 Previous response: %s
@@ -63,18 +108,23 @@ Please provide a corrected response that is valid JSON matching the schema.`,
 }
 
 // ValidatingClient wraps a Client with JSON schema validation and retry logic.
+// It is safe for concurrent use.
 type ValidatingClient struct {
 	client Client
 	config Config
+
+	mu     sync.Mutex // Guards traces and calls
 	traces []Trace
+	calls  int // Underlying Complete calls made so far
 }
 
 // Trace records an LLM interaction for debugging.
 type Trace struct {
-	Request  Request  `json:"request"`
-	Response Response `json:"response"`
-	Error    string   `json:"error,omitempty"`
-	Attempt  int      `json:"attempt"`
+	Request   Request  `json:"request"`
+	Response  Response `json:"response"`
+	Error     string   `json:"error,omitempty"`
+	Attempt   int      `json:"attempt"`    // Validation attempt (1-based)
+	CallIndex int      `json:"call_index"` // Network call number over the client's lifetime, transport retries included (1-based)
 }
 
 // NewValidatingClient creates a new validating client wrapper.
@@ -85,6 +135,24 @@ func NewValidatingClient(client Client, config Config) *ValidatingClient {
 	}
 }
 
+// Provider returns the wrapped client's provider name, or "" if it is not a
+// Describer.
+func (c *ValidatingClient) Provider() string {
+	if d, ok := c.client.(Describer); ok {
+		return d.Provider()
+	}
+	return ""
+}
+
+// Model returns the wrapped client's default model, or "" if it is not a
+// Describer.
+func (c *ValidatingClient) Model() string {
+	if d, ok := c.client.(Describer); ok {
+		return d.Model()
+	}
+	return ""
+}
+
 // CompleteWithValidation sends a request and validates the JSON response.
 // It retries with repair prompts on validation failures.
 func (c *ValidatingClient) CompleteWithValidation(ctx context.Context, req Request, target interface{}) error {
@@ -92,14 +160,11 @@ func (c *ValidatingClient) CompleteWithValidation(ctx context.Context, req Reque
 	originalPrompt := req.Prompt
 
 	for attempt := 1; attempt <= c.config.MaxRetries; attempt++ {
-		resp, err := c.client.Complete(ctx, req)
+		resp, err := c.completeWithRetries(ctx, req, attempt)
 		if err != nil {
-			c.recordTrace(req, Response{}, err.Error(), attempt)
 			return fmt.Errorf("LLM request failed: %w", err)
 		}
 
-		c.recordTrace(req, *resp, "", attempt)
-
 		// Check for empty response
 		if resp.Content == "" {
 			lastError = fmt.Errorf("empty response from LLM (finish_reason: %s)", resp.FinishReason)
@@ -111,6 +176,12 @@ func (c *ValidatingClient) CompleteWithValidation(ctx context.Context, req Reque
 
 		// Try to unmarshal
 		if err := json.Unmarshal([]byte(jsonContent), target); err != nil {
+			if resp.FinishReason == "length" {
+				// Partial JSON: a repair prompt would only be cut off again
+				lastError = fmt.Errorf("response truncated at max_tokens %d: %w", c.maxTokens(req), err)
+				req = c.expandTruncated(req, originalPrompt)
+				continue
+			}
 			lastError = fmt.Errorf("JSON parse error: %w (response length: %d, content: %s)",
 				err, len(resp.Content), truncate(resp.Content, 200))
 			req.Prompt = fmt.Sprintf(c.config.RepairPrompt, lastError.Error(), truncate(resp.Content, 500))
@@ -132,15 +203,74 @@ func (c *ValidatingClient) CompleteWithValidation(ctx context.Context, req Reque
 		return nil
 	}
 
-	// Restore original prompt for error context
-	_ = originalPrompt
 	return fmt.Errorf("%w: %v", ErrMaxRetries, lastError)
 }
 
+// maxTokens returns the token limit a request is sent with.
+func (c *ValidatingClient) maxTokens(req Request) int {
+	if req.MaxTokens > 0 {
+		return req.MaxTokens
+	}
+	return c.config.DefaultTokens
+}
+
+// expandTruncated prepares the retry of a truncated response: it doubles
+// MaxTokens while under MaxTokensCeiling, and otherwise asks for a more
+// concise answer to the original prompt.
+func (c *ValidatingClient) expandTruncated(req Request, originalPrompt string) Request {
+	if tokens := c.maxTokens(req); tokens < c.config.MaxTokensCeiling {
+		req.MaxTokens = min(2*tokens, c.config.MaxTokensCeiling)
+		return req
+	}
+	if c.config.ConcisePrompt != "" {
+		req.Prompt = originalPrompt + "\n\n" + c.config.ConcisePrompt
+	}
+	return req
+}
+
+// completeWithRetries calls the underlying client, retrying request errors up
+// to TransportRetries times unless ctx itself is done. Every call is traced.
+func (c *ValidatingClient) completeWithRetries(ctx context.Context, req Request, attempt int) (*Response, error) {
+	if req.Model == "" {
+		req.Model = ModelFromContext(ctx)
+	}
+	for retry := 0; ; retry++ {
+		resp, callIndex, err := c.complete(ctx, req)
+		if err == nil {
+			c.recordTrace(req, *resp, "", attempt, callIndex)
+			return resp, nil
+		}
+		c.recordTrace(req, Response{}, err.Error(), attempt, callIndex)
+		if retry >= c.config.TransportRetries || ctx.Err() != nil {
+			return nil, err
+		}
+	}
+}
+
+// complete calls the underlying client, bounded by PerRequestTimeout when set,
+// and returns the call's index over the client's lifetime.
+func (c *ValidatingClient) complete(ctx context.Context, req Request) (*Response, int, error) {
+	c.mu.Lock()
+	c.calls++
+	callIndex := c.calls
+	c.mu.Unlock()
+
+	if c.config.PerRequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.PerRequestTimeout)
+		defer cancel()
+	}
+	resp, err := c.client.Complete(ctx, req)
+	return resp, callIndex, err
+}
+
 // Traces returns recorded traces (with secrets redacted if configured).
 func (c *ValidatingClient) Traces() []Trace {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if !c.config.RedactSecrets {
-		return c.traces
+		return append([]Trace(nil), c.traces...)
 	}
 
 	redacted := make([]Trace, len(c.traces))
@@ -152,10 +282,13 @@ func (c *ValidatingClient) Traces() []Trace {
 				MaxTokens:    t.Request.MaxTokens,
 				Temperature:  t.Request.Temperature,
 				SchemaName:   t.Request.SchemaName,
+				Model:        t.Request.Model,
+				ToolName:     t.Request.ToolName,
 			},
-			Response: t.Response,
-			Error:    t.Error,
-			Attempt:  t.Attempt,
+			Response:  t.Response,
+			Error:     t.Error,
+			Attempt:   t.Attempt,
+			CallIndex: t.CallIndex,
 		}
 	}
 	return redacted
@@ -163,15 +296,20 @@ func (c *ValidatingClient) Traces() []Trace {
 
 // ClearTraces clears recorded traces.
 func (c *ValidatingClient) ClearTraces() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.traces = nil
 }
 
-func (c *ValidatingClient) recordTrace(req Request, resp Response, errStr string, attempt int) {
+func (c *ValidatingClient) recordTrace(req Request, resp Response, errStr string, attempt, callIndex int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.traces = append(c.traces, Trace{
-		Request:  req,
-		Response: resp,
-		Error:    errStr,
-		Attempt:  attempt,
+		Request:   req,
+		Response:  resp,
+		Error:     errStr,
+		Attempt:   attempt,
+		CallIndex: callIndex,
 	})
 }
 
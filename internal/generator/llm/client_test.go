@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestValidatingClient_Success(t *testing.T) {
@@ -91,6 +93,70 @@ func TestValidatingClient_RetryOnInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestValidatingClient_TruncatedResponse(t *testing.T) {
+	// Two cut-off responses, then a complete one
+	mock := NewMockClient(
+		`{"words": ["CHAT", "CHI`,
+		`{"words": ["CHAT", "CHIEN", "OIS`,
+		`{"words": ["CHAT", "CHIEN", "OISEAU"]}`,
+	).WithFinishReasons("length", "length", "stop")
+	config := DefaultConfig()
+	config.MaxTokensCeiling = 3000
+	client := NewValidatingClient(mock, config)
+
+	var result struct {
+		Words []string `json:"words"`
+	}
+	err := client.CompleteWithValidation(context.Background(), Request{
+		Prompt:    "List animals",
+		MaxTokens: 1024,
+	}, &result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Words) != 3 {
+		t.Errorf("expected 3 words, got %v", result.Words)
+	}
+
+	if mock.CallCount() != 3 {
+		t.Fatalf("expected 3 calls, got %d", mock.CallCount())
+	}
+	wantTokens := []int{1024, 2048, 3000} // Doubled, then capped at the ceiling
+	for i, want := range wantTokens {
+		if got := mock.Calls[i].MaxTokens; got != want {
+			t.Errorf("call %d: expected MaxTokens %d, got %d", i+1, want, got)
+		}
+		if mock.Calls[i].Prompt != "List animals" {
+			t.Errorf("call %d: expected original prompt, got %q", i+1, mock.Calls[i].Prompt)
+		}
+	}
+}
+
+func TestValidatingClient_TruncatedAtCeiling(t *testing.T) {
+	mock := NewMockClient(`{"words": ["CHAT", "CHI`, `{"words": ["CHAT"]}`).
+		WithFinishReasons("length")
+	client := NewValidatingClient(mock, DefaultConfig())
+
+	var result struct {
+		Words []string `json:"words"`
+	}
+	err := client.CompleteWithValidation(context.Background(), Request{
+		Prompt:    "List animals",
+		MaxTokens: 8192,
+	}, &result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	retry := mock.Calls[1]
+	if retry.MaxTokens != 8192 {
+		t.Errorf("expected MaxTokens to stay at the ceiling, got %d", retry.MaxTokens)
+	}
+	if !strings.HasPrefix(retry.Prompt, "List animals") || !strings.Contains(retry.Prompt, "cut off") {
+		t.Errorf("expected concise instruction after the original prompt, got %q", retry.Prompt)
+	}
+}
+
 func TestValidatingClient_MaxRetriesExceeded(t *testing.T) {
 	// All responses are invalid
 	mock := NewMockClient(
@@ -136,6 +202,26 @@ func TestValidatingClient_LLMError(t *testing.T) {
 	}
 }
 
+func TestValidatingClient_PerRequestTimeout(t *testing.T) {
+	mock := NewMockClient(`{}`).WithDelay(time.Second)
+	config := DefaultConfig()
+	config.PerRequestTimeout = 10 * time.Millisecond
+	client := NewValidatingClient(mock, config)
+
+	start := time.Now()
+	var result struct{}
+	err := client.CompleteWithValidation(context.Background(), Request{
+		Prompt: "Generate JSON",
+	}, &result)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected call to stop at the per-request timeout, took %s", elapsed)
+	}
+}
+
 func TestValidatingClient_Traces(t *testing.T) {
 	mock := NewMockClient(`{"name": "test"}`)
 	client := NewValidatingClient(mock, DefaultConfig())
@@ -158,6 +244,37 @@ func TestValidatingClient_Traces(t *testing.T) {
 	}
 }
 
+func TestValidatingClient_TransportRetryCallIndex(t *testing.T) {
+	mock := NewMockClient("", `{"name": "test"}`).WithErrors(errors.New("connection reset"))
+	config := DefaultConfig()
+	config.TransportRetries = 1
+	client := NewValidatingClient(mock, config)
+
+	var result struct {
+		Name string `json:"name"`
+	}
+	if err := client.CompleteWithValidation(context.Background(), Request{
+		Prompt: "Test prompt",
+	}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	traces := client.Traces()
+	if len(traces) != 2 {
+		t.Fatalf("expected 2 traces, got %d", len(traces))
+	}
+	if traces[0].Error == "" || traces[1].Error != "" {
+		t.Errorf("expected failed then successful call, got errors %q, %q", traces[0].Error, traces[1].Error)
+	}
+	if traces[0].CallIndex != 1 || traces[1].CallIndex != 2 {
+		t.Errorf("expected call indexes 1, 2, got %d, %d", traces[0].CallIndex, traces[1].CallIndex)
+	}
+	if traces[0].Attempt != 1 || traces[1].Attempt != 1 {
+		t.Errorf("transport retries should not advance the validation attempt, got %d, %d",
+			traces[0].Attempt, traces[1].Attempt)
+	}
+}
+
 func TestValidatingClient_TracesRedaction(t *testing.T) {
 	mock := NewMockClient(`{"name": "test"}`)
 	config := DefaultConfig()
@@ -181,6 +298,49 @@ func TestValidatingClient_TracesRedaction(t *testing.T) {
 	}
 }
 
+// staticClient answers every request with the same content and is safe for
+// concurrent use, unlike MockClient.
+type staticClient struct {
+	content string
+}
+
+func (c staticClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	return &Response{Content: c.content, FinishReason: "stop", TokensUsed: 10}, nil
+}
+
+func TestValidatingClient_ConcurrentTraces(t *testing.T) {
+	client := NewValidatingClient(staticClient{content: `{"name": "test"}`}, DefaultConfig())
+
+	const calls = 50
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var result struct {
+				Name string `json:"name"`
+			}
+			if err := client.CompleteWithValidation(context.Background(), Request{Prompt: "Test prompt"}, &result); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			client.Traces()
+		}()
+	}
+	wg.Wait()
+
+	traces := client.Traces()
+	if len(traces) != calls {
+		t.Fatalf("expected %d traces, got %d", calls, len(traces))
+	}
+	seen := make(map[int]bool)
+	for _, trace := range traces {
+		if trace.CallIndex < 1 || trace.CallIndex > calls || seen[trace.CallIndex] {
+			t.Errorf("unexpected or duplicate call index %d", trace.CallIndex)
+		}
+		seen[trace.CallIndex] = true
+	}
+}
+
 func TestExtractJSON(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -3,13 +3,16 @@ package llm
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // MockClient is a mock LLM client for testing.
 type MockClient struct {
-	Responses []string // Responses to return in order
-	Errors    []error  // Errors to return in order
-	Calls     []Request // Recorded calls
+	Responses []string      // Responses to return in order
+	Errors    []error       // Errors to return in order
+	Finishes  []string      // Finish reasons to return in order ("stop" when missing or empty)
+	Calls     []Request     // Recorded calls
+	Delay     time.Duration // Blocks each call this long, or until the context ends
 	callIndex int
 }
 
@@ -18,16 +21,47 @@ func NewMockClient(responses ...string) *MockClient {
 	return &MockClient{Responses: responses}
 }
 
+// Provider returns the provider name.
+func (m *MockClient) Provider() string {
+	return "mock"
+}
+
+// Model returns the model name, which requests may override.
+func (m *MockClient) Model() string {
+	return "mock"
+}
+
 // WithErrors sets errors to return.
 func (m *MockClient) WithErrors(errs ...error) *MockClient {
 	m.Errors = errs
 	return m
 }
 
+// WithFinishReasons sets the finish reasons of successive responses.
+func (m *MockClient) WithFinishReasons(reasons ...string) *MockClient {
+	m.Finishes = reasons
+	return m
+}
+
+// WithDelay makes each call block for d before answering.
+func (m *MockClient) WithDelay(d time.Duration) *MockClient {
+	m.Delay = d
+	return m
+}
+
 // Complete returns the next mock response.
 func (m *MockClient) Complete(ctx context.Context, req Request) (*Response, error) {
 	m.Calls = append(m.Calls, req)
 
+	if m.Delay > 0 {
+		select {
+		case <-time.After(m.Delay):
+		case <-ctx.Done():
+			m.callIndex++
+			return nil, ctx.Err()
+		}
+	}
+
 	// Check for error
 	if m.callIndex < len(m.Errors) && m.Errors[m.callIndex] != nil {
 		err := m.Errors[m.callIndex]
@@ -46,6 +80,9 @@ func (m *MockClient) Complete(ctx context.Context, req Request) (*Response, erro
 		FinishReason: "stop",
 		TokensUsed:   100,
 	}
+	if m.callIndex < len(m.Finishes) && m.Finishes[m.callIndex] != "" {
+		resp.FinishReason = m.Finishes[m.callIndex]
+	}
 	m.callIndex++
 	return resp, nil
 }
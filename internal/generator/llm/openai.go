@@ -10,6 +10,10 @@ import (
 	"time"
 )
 
+// Version is reported in the default User-Agent. Release builds set it with
+// -ldflags "-X lesmotsdatche/internal/generator/llm.Version=1.2.0".
+var Version = "dev"
+
 // OpenAIConfig holds OpenAI-specific configuration.
 type OpenAIConfig struct {
 	APIKey       string
@@ -17,14 +21,17 @@ type OpenAIConfig struct {
 	BaseURL      string
 	Timeout      time.Duration
 	Organization string
+	UserAgent    string            // Defaults to lesmotsdatche/<Version>
+	Headers      map[string]string // Extra request headers, e.g. for an LLM gateway; cannot override Content-Type or Authorization
 }
 
 // DefaultOpenAIConfig returns default OpenAI configuration.
 func DefaultOpenAIConfig() OpenAIConfig {
 	return OpenAIConfig{
-		Model:   "gpt-4o",
-		BaseURL: "https://api.openai.com/v1",
-		Timeout: 60 * time.Second,
+		Model:     "gpt-4o",
+		BaseURL:   "https://api.openai.com/v1",
+		Timeout:   60 * time.Second,
+		UserAgent: "lesmotsdatche/" + Version,
 	}
 }
 
@@ -45,6 +52,9 @@ func NewOpenAIClient(config OpenAIConfig) *OpenAIClient {
 	if config.Timeout == 0 {
 		config.Timeout = DefaultOpenAIConfig().Timeout
 	}
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultOpenAIConfig().UserAgent
+	}
 
 	return &OpenAIClient{
 		config: config,
@@ -60,11 +70,37 @@ type openAIRequest struct {
 	Messages    []openAIMessage `json:"messages"`
 	MaxTokens   int             `json:"max_tokens,omitempty"`
 	Temperature float64         `json:"temperature,omitempty"`
+	Tools       []openAITool    `json:"tools,omitempty"`
+	ToolChoice  *openAITool     `json:"tool_choice,omitempty"` // Forces a call to the named function
 }
 
 type openAIMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []openAIToolCall `json:"tool_calls,omitempty"`
+}
+
+// openAITool declares a function the model can call, or names the one it must
+// call in tool_choice.
+type openAITool struct {
+	Type     string         `json:"type"`
+	Function openAIFunction `json:"function"`
+}
+
+type openAIFunction struct {
+	Name       string          `json:"name"`
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// openAIToolCall is a function call returned by the model. Arguments is a
+// JSON document encoded as a string.
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
 }
 
 // openAIResponse is the response structure from OpenAI's chat completions API.
@@ -108,8 +144,13 @@ func (c *OpenAIClient) Complete(ctx context.Context, req Request) (*Response, er
 		Content: req.Prompt,
 	})
 
+	model := c.config.Model
+	if req.Model != "" {
+		model = req.Model
+	}
+
 	openaiReq := openAIRequest{
-		Model:       c.config.Model,
+		Model:       model,
 		Messages:    messages,
 		MaxTokens:   req.MaxTokens,
 		Temperature: req.Temperature,
@@ -121,6 +162,16 @@ func (c *OpenAIClient) Complete(ctx context.Context, req Request) (*Response, er
 	if openaiReq.Temperature == 0 {
 		openaiReq.Temperature = 0.7
 	}
+	if req.ToolName != "" && len(req.ToolSchema) > 0 {
+		openaiReq.Tools = []openAITool{{
+			Type:     "function",
+			Function: openAIFunction{Name: req.ToolName, Parameters: req.ToolSchema},
+		}}
+		openaiReq.ToolChoice = &openAITool{
+			Type:     "function",
+			Function: openAIFunction{Name: req.ToolName},
+		}
+	}
 
 	body, err := json.Marshal(openaiReq)
 	if err != nil {
@@ -132,6 +183,10 @@ func (c *OpenAIClient) Complete(ctx context.Context, req Request) (*Response, er
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	httpReq.Header.Set("User-Agent", c.config.UserAgent)
+	for name, value := range c.config.Headers {
+		httpReq.Header.Set(name, value)
+	}
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Authorization", "Bearer "+c.config.APIKey)
 	if c.config.Organization != "" {
@@ -167,8 +222,19 @@ func (c *OpenAIClient) Complete(ctx context.Context, req Request) (*Response, er
 		return nil, fmt.Errorf("no choices in response")
 	}
 
+	content := openaiResp.Choices[0].Message.Content
+	if openaiReq.ToolChoice != nil {
+		// Without a call to the tool, fall back to the message content
+		for _, call := range openaiResp.Choices[0].Message.ToolCalls {
+			if call.Function.Name == req.ToolName {
+				content = call.Function.Arguments
+				break
+			}
+		}
+	}
+
 	return &Response{
-		Content:      openaiResp.Choices[0].Message.Content,
+		Content:      content,
 		FinishReason: openaiResp.Choices[0].FinishReason,
 		TokensUsed:   openaiResp.Usage.TotalTokens,
 	}, nil
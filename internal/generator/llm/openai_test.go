@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -35,6 +36,9 @@ func TestOpenAIClient_Complete(t *testing.T) {
 		if len(req.Messages) != 2 {
 			t.Errorf("expected 2 messages, got %d", len(req.Messages))
 		}
+		if len(req.Tools) != 0 || req.ToolChoice != nil {
+			t.Errorf("expected no tools without ToolName, got %+v", req.Tools)
+		}
 
 		// Send mock response
 		resp := openAIResponse{
@@ -98,6 +102,76 @@ func TestOpenAIClient_Complete(t *testing.T) {
 	}
 }
 
+func TestOpenAIClient_ModelOverride(t *testing.T) {
+	var models []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		models = append(models, req.Model)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "{}"}, "finish_reason": "stop"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewValidatingClient(NewOpenAIClient(OpenAIConfig{
+		APIKey:  "test-key",
+		Model:   "gpt-4o",
+		BaseURL: server.URL,
+	}), DefaultConfig())
+
+	var out map[string]interface{}
+	ctx := context.Background()
+	client.CompleteWithValidation(ctx, Request{Prompt: "default"}, &out)
+	client.CompleteWithValidation(ctx, Request{Prompt: "explicit", Model: "gpt-4o-mini"}, &out)
+	client.CompleteWithValidation(WithModel(ctx, "gpt-4.1"), Request{Prompt: "context"}, &out)
+	client.CompleteWithValidation(WithModel(ctx, "gpt-4.1"), Request{Prompt: "both", Model: "gpt-4o-mini"}, &out)
+
+	want := []string{"gpt-4o", "gpt-4o-mini", "gpt-4.1", "gpt-4o-mini"}
+	if strings.Join(models, ",") != strings.Join(want, ",") {
+		t.Errorf("expected models %v, got %v", want, models)
+	}
+}
+
+func TestOpenAIClient_ToolCall(t *testing.T) {
+	schema := `{"type":"object","properties":{"words":{"type":"array"}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(req.Tools) != 1 || req.Tools[0].Function.Name != "submit_words" {
+			t.Errorf("expected submit_words tool, got %+v", req.Tools)
+		} else if string(req.Tools[0].Function.Parameters) != schema {
+			t.Errorf("expected tool parameters %s, got %s", schema, req.Tools[0].Function.Parameters)
+		}
+		if req.ToolChoice == nil || req.ToolChoice.Function.Name != "submit_words" {
+			t.Errorf("expected tool_choice submit_words, got %+v", req.ToolChoice)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": null, "tool_calls": [
+			{"id": "call_1", "type": "function", "function": {"name": "submit_words", "arguments": "{\"words\": [\"CHAT\"]}"}}
+		]}, "finish_reason": "tool_calls"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	resp, err := client.Complete(context.Background(), Request{
+		Prompt:     "Give me words",
+		ToolName:   "submit_words",
+		ToolSchema: json.RawMessage(schema),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Content != `{"words": ["CHAT"]}` {
+		t.Errorf("expected tool arguments as content, got %q", resp.Content)
+	}
+	if resp.FinishReason != "tool_calls" {
+		t.Errorf("expected finish reason tool_calls, got %q", resp.FinishReason)
+	}
+}
+
 func TestOpenAIClient_APIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp := openAIResponse{
@@ -211,3 +285,43 @@ func TestOpenAIClient_Organization(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestOpenAIClient_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "lesmotsdatche/"+Version {
+			t.Errorf("expected default user agent, got %q", ua)
+		}
+		if tenant := r.Header.Get("X-Gateway-Tenant"); tenant != "crosswords" {
+			t.Errorf("expected custom header 'crosswords', got %q", tenant)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
+			t.Errorf("custom headers must not override authorization, got %q", auth)
+		}
+
+		resp := openAIResponse{
+			Choices: []struct {
+				Index        int           `json:"index"`
+				Message      openAIMessage `json:"message"`
+				FinishReason string        `json:"finish_reason"`
+			}{
+				{Message: openAIMessage{Content: "ok"}, FinishReason: "stop"},
+			},
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient(OpenAIConfig{
+		APIKey:  "test-key",
+		BaseURL: server.URL,
+		Headers: map[string]string{
+			"X-Gateway-Tenant": "crosswords",
+			"Authorization":    "Bearer other",
+		},
+	})
+
+	_, err := client.Complete(context.Background(), Request{Prompt: "Test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
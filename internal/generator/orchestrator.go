@@ -3,9 +3,20 @@ package generator
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
 	"lesmotsdatche/internal/domain"
 	"lesmotsdatche/internal/generator/clue"
 	"lesmotsdatche/internal/generator/fill"
@@ -25,17 +36,57 @@ type Orchestrator struct {
 	scorer         *qa.Scorer
 	baseLexicon    *fill.MemoryLexicon
 	config         Config
+	logger         *slog.Logger
+	answerHistory  AnswerHistory
+
+	cacheMu sync.Mutex
+	cache   map[string]*GenerateResult // Successful results by cacheKey, when Config.CacheResults is set
+}
+
+// AnswerHistory provides answers of previously stored puzzles.
+// store.PuzzleRepository satisfies it.
+type AnswerHistory interface {
+	RecentAnswers(ctx context.Context, language, fromDate, toDate string) ([]string, error)
 }
 
+// FillStrategy selects how the grid of a generation attempt is filled.
+type FillStrategy string
+
+const (
+	FillStrategyTemplateSolver FillStrategy = "template-solver" // Backtracking solver on a supplied Template, word-first without one
+	FillStrategyWordFirst      FillStrategy = "word-first"      // Always build around the candidates; a Template only sets the grid size
+)
+
 // Config holds orchestrator configuration.
 type Config struct {
-	MaxAttempts          int           // Maximum generation attempts
-	Timeout              time.Duration // Total timeout for generation
-	TargetDifficulty     int           // Target puzzle difficulty (1-5)
-	MinQAScore           float64       // Minimum acceptable QA score
-	GridSize             [2]int        // Grid dimensions [rows, cols]
-	MaxConsecutiveBlocks int           // Max consecutive blocks in row/column (0 = unlimited, 1 = isolated only)
-	MaxBlockClusterSize  int           // Max rectangular block cluster area (0 = unlimited, 1 = no clusters)
+	MaxAttempts            int                 // Maximum generation attempts
+	Timeout                time.Duration       // Total timeout for generation
+	TargetDifficulty       int                 // Target puzzle difficulty (1-5)
+	MinQAScore             float64             // Minimum acceptable QA score
+	GridSize               [2]int              // Grid dimensions [rows, cols]
+	MaxConsecutiveBlocks   int                 // Max consecutive blocks in row/column (0 = unlimited, 1 = isolated only)
+	MaxBlockClusterSize    int                 // Max rectangular block cluster area (0 = unlimited, 1 = no clusters)
+	MaxGridCells           int                 // Max rows*cols accepted for a request (0 = unlimited)
+	BaseLexiconWeight      float64             // Frequency multiplier for base lexicon words merged with candidates (0 = 1.0)
+	Seed                   int64               // Builder/solver seed, offset by attempt (0 = time-based)
+	AnswerRepeatWindowDays int                 // Ban answers used in the previous N days of puzzles (0 = disabled)
+	AnswerRepeatSoft       bool                // Soft-avoid recent answers (see GenerateRequest.SoftAvoid) instead of banning them
+	SoftAvoidWeight        float64             // Frequency multiplier for soft-avoided words (0 = 0.01)
+	MinClues               int                 // Minimum across+down clue count for a valid puzzle (0 = unlimited)
+	SymmetryMode           domain.SymmetryMode // Block symmetry of generated templates, also expected by QA
+	CacheResults           bool                // Serve repeated identical requests from memory (meant for seeded test runs)
+	VowelWeight            float64             // Vowel preference in candidate prompts and grid building (lower favors theme fidelity)
+	AttemptBackoff         time.Duration       // Wait between attempts, so transient LLM errors do not retry instantly (0 = none)
+	AttemptBackoffJitter   time.Duration       // Random extra wait in [0, jitter) added to AttemptBackoff
+	MaxElapsed             time.Duration       // No attempt starts once this budget would be exceeded (0 = unlimited); Timeout still bounds running attempts
+	FillStrategy           FillStrategy        // How grids are filled ("" = template-solver)
+	Author                 string              // Author of puzzles whose request sets none ("" = DefaultAuthor)
+	FillTimeout            time.Duration       // Per-attempt budget for candidates and grid fill (0 = only Timeout applies)
+	ClueTimeout            time.Duration       // Per-attempt budget for clue generation, so a slow fill cannot use it up (0 = only Timeout applies)
+
+	// MinComponentScores rejects attempts whose QA component (e.g. "fill")
+	// scores below the given minimum, whatever the overall score.
+	MinComponentScores map[string]float64
 }
 
 // DefaultConfig returns default configuration.
@@ -48,9 +99,20 @@ func DefaultConfig() Config {
 		GridSize:             [2]int{13, 13}, // French standard grid
 		MaxConsecutiveBlocks: 1,   // No consecutive blocks (isolated blocks only)
 		MaxBlockClusterSize:  1,   // No block clusters (single blocks only)
+		MaxGridCells:         225, // 15x15 keeps token usage and fill time bounded
+		BaseLexiconWeight:    1.0,
+		MinClues:             4, // Rejects degenerate grids of a handful of words
+		SymmetryMode:         domain.SymmetryRotational,
+		VowelWeight:          1.0,
+		FillStrategy:         FillStrategyTemplateSolver,
+		Author:               DefaultAuthor,
 	}
 }
 
+// DefaultAuthor is the author of generated puzzles when neither the request
+// nor the config sets one.
+const DefaultAuthor = "LLM Generator"
+
 // NewOrchestrator creates a new orchestrator.
 func NewOrchestrator(
 	llmClient *llm.ValidatingClient,
@@ -60,8 +122,11 @@ func NewOrchestrator(
 ) *Orchestrator {
 	themeConfig := theme.DefaultGeneratorConfig()
 	candidateConfig := theme.DefaultCandidateConfig()
+	candidateConfig.VowelWeight = config.VowelWeight
 	clueConfig := clue.DefaultGeneratorConfig()
 	scorerConfig := qa.DefaultScorerConfig()
+	scorerConfig.MinComponentScores = config.MinComponentScores
+	scorerConfig.SymmetryMode = config.SymmetryMode
 
 	return &Orchestrator{
 		llmClient:    llmClient,
@@ -72,26 +137,68 @@ func NewOrchestrator(
 		scorer:       qa.NewScorer(langPack, scorerConfig),
 		baseLexicon:  baseLexicon,
 		config:       config,
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
 	}
 }
 
+// WithLogger sets the logger used for generation phase logging.
+func (o *Orchestrator) WithLogger(logger *slog.Logger) *Orchestrator {
+	if logger != nil {
+		o.logger = logger
+	}
+	return o
+}
+
+// WithAnswerHistory sets the source of recent answers used to avoid repeating
+// answers across consecutive puzzles (see Config.AnswerRepeatWindowDays).
+func (o *Orchestrator) WithAnswerHistory(history AnswerHistory) *Orchestrator {
+	o.answerHistory = history
+	return o
+}
+
+// Scorer returns the QA scorer used to rate generated puzzles.
+func (o *Orchestrator) Scorer() *qa.Scorer {
+	return o.scorer
+}
+
 // GenerateRequest holds parameters for puzzle generation.
 type GenerateRequest struct {
-	Date        string                // Target date (YYYY-MM-DD)
-	Language    string                // Language code
-	Template    [][]domain.Cell       // Optional grid template
-	GridRows    int                   // Grid rows (10-16, 0 = use default)
-	GridCols    int                   // Grid columns (10-16, 0 = use default)
-	Constraints theme.ThemeConstraints // Theme constraints
+	Date         string                 // Target date (YYYY-MM-DD)
+	Language     string                 // Language code
+	Template     [][]domain.Cell        // Optional grid template
+	GridRows     int                    // Grid rows (10-16, 0 = use default)
+	GridCols     int                    // Grid columns (10-16, 0 = use default)
+	Constraints  theme.ThemeConstraints // Theme constraints
+	AvoidAnswers []string               // Words banned from the fill (recent answers are added automatically)
+	SoftAvoid    []string               // Words deprioritized in the fill, used only where nothing else fits
+	Model        string                 // LLM model for this generation's calls ("" = client default)
+	Author       string                 // Puzzle author ("" = Config.Author)
 }
 
 // GenerateResult holds the generation result.
 type GenerateResult struct {
-	Puzzle     *domain.Puzzle   `json:"puzzle"`
-	Theme      *theme.Theme     `json:"theme"`
-	QAScore    *qa.Score        `json:"qa_score"`
-	FillResult *fill.Result     `json:"fill_result"`
-	Stats      GenerationStats  `json:"stats"`
+	GenerationID string              `json:"generation_id"` // Correlates logs and traces for one Generate call
+	Report       *domain.DraftReport `json:"report,omitempty"`
+	Puzzle       *domain.Puzzle      `json:"puzzle"`
+	Theme        *theme.Theme        `json:"theme"`
+	QAScore      *qa.Score           `json:"qa_score"`
+	FillResult   *fill.Result        `json:"fill_result"`
+	Stats        GenerationStats     `json:"stats"`
+	// ClueCollisions lists entries whose clue could not be embedded because
+	// another entry of the same direction already claimed the clue cell.
+	ClueCollisions []ClueCollision `json:"clue_collisions,omitempty"`
+	// PlaceholderClues lists answers the LLM returned no clue for, which got a
+	// placeholder clue that an editor must replace.
+	PlaceholderClues []string `json:"placeholder_clues,omitempty"`
+}
+
+// ClueCollision records two entries of the same direction claiming one clue cell.
+// A cell can hold one across and one down clue, but never two of either.
+type ClueCollision struct {
+	Cell        domain.Position  `json:"cell"`
+	Direction   domain.Direction `json:"direction"`
+	KeptSlot    int              `json:"kept_slot"`    // Slot whose clue occupies the cell
+	DroppedSlot int              `json:"dropped_slot"` // Slot whose clue was not embedded
 }
 
 // GenerationStats holds generation statistics.
@@ -108,12 +215,50 @@ type GenerationStats struct {
 type clueData struct {
 	prompt     string
 	answer     string
-	difficulty int
+	difficulty int    // Declared by the selected candidate, 0 if none
+	style      string // Style of the selected candidate, "" if none
+	ambiguity  string
 }
 
 // Generate creates a new puzzle.
 func (o *Orchestrator) Generate(ctx context.Context, req GenerateRequest) (*GenerateResult, error) {
 	start := time.Now()
+	generationID := uuid.NewString()
+	logger := o.logger.With("generation_id", generationID)
+
+	// Reject oversized requests before spending any LLM tokens
+	if err := o.validateRequest(req); err != nil {
+		logger.Warn("generation request rejected", "error", err)
+		return nil, err
+	}
+	logger.Info("generation started", "date", req.Date, "language", req.Language)
+
+	var key string
+	if o.config.CacheResults {
+		key = o.cacheKey(req)
+		if cached := o.cachedResult(key); cached != nil {
+			logger.Info("generation served from cache", "cached_generation_id", cached.GenerationID)
+			return cached, nil
+		}
+	}
+
+	recent, err := o.recentAnswers(ctx, req)
+	if err != nil {
+		// Repetition avoidance is best effort; generate anyway
+		logger.Warn("failed to load recent answers", "error", err)
+	}
+	if len(recent) > 0 {
+		logger.Info("avoiding recent answers", "count", len(recent), "window_days", o.config.AnswerRepeatWindowDays, "soft", o.config.AnswerRepeatSoft)
+		if o.config.AnswerRepeatSoft {
+			req.SoftAvoid = append(append([]string(nil), req.SoftAvoid...), recent...)
+		} else {
+			req.AvoidAnswers = append(append([]string(nil), req.AvoidAnswers...), recent...)
+		}
+	}
+
+	if req.Model != "" {
+		ctx = llm.WithModel(ctx, req.Model)
+	}
 
 	// Apply timeout
 	if o.config.Timeout > 0 {
@@ -123,27 +268,321 @@ func (o *Orchestrator) Generate(ctx context.Context, req GenerateRequest) (*Gene
 	}
 
 	var lastError error
+	var best *GenerateResult
+	attempts := 0
 	for attempt := 1; attempt <= o.config.MaxAttempts; attempt++ {
-		result, err := o.generateAttempt(ctx, req, attempt)
+		if attempt > 1 {
+			if err := o.waitBeforeAttempt(ctx, start); err != nil {
+				logger.Warn("stopping before attempt", "attempt", attempt, "error", err)
+				lastError = fmt.Errorf("%w (last attempt: %v)", err, lastError)
+				break
+			}
+		}
+		attempts = attempt
+
+		attemptLogger := logger.With("attempt", attempt)
+		result, err := o.generateAttempt(ctx, req, attempt, attemptLogger)
 		if err != nil {
+			attemptLogger.Warn("attempt failed", "error", err)
 			lastError = err
 			continue
 		}
 
 		// Check QA score
-		if result.QAScore != nil && result.QAScore.IsAcceptable() {
+		if result.QAScore != nil && o.scorer.IsAcceptable(result.QAScore) {
+			result.GenerationID = generationID
 			result.Stats.Attempts = attempt
 			result.Stats.Duration = time.Since(start)
+			result.Report = buildDraftReport(result)
+			logger.Info("generation succeeded", "attempts", attempt, "qa_score", result.QAScore.Overall,
+				"duration", result.Stats.Duration.String())
+			if o.config.CacheResults {
+				o.storeResult(key, result)
+			}
 			return result, nil
 		}
 
 		lastError = fmt.Errorf("QA score too low: %.2f", result.QAScore.Overall)
+		if failed := result.QAScore.FailedComponents(o.config.MinComponentScores); len(failed) > 0 {
+			lastError = fmt.Errorf("QA components below minimum: %s", strings.Join(failed, ", "))
+		}
+		attemptLogger.Warn("attempt rejected", "error", lastError)
+
+		if result.QAScore != nil && (best == nil || result.QAScore.Overall > best.QAScore.Overall) {
+			result.Stats.Attempts = attempt
+			best = result
+		}
+	}
+
+	if best != nil {
+		best.GenerationID = generationID
+		best.Stats.Duration = time.Since(start)
+		best.Report = buildDraftReport(best)
+	}
+	logger.Error("generation failed", "attempts", attempts, "error", lastError)
+	return nil, &GenerationFailure{
+		GenerationID: generationID,
+		BestResult:   best,
+		Attempts:     attempts,
+		Err:          lastError,
+	}
+}
+
+// waitBeforeAttempt sleeps for the attempt backoff plus jitter. It returns an
+// error without waiting when the wait would exceed MaxElapsed since start,
+// and returns early if ctx ends.
+func (o *Orchestrator) waitBeforeAttempt(ctx context.Context, start time.Time) error {
+	delay := o.config.AttemptBackoff
+	if o.config.AttemptBackoffJitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(o.config.AttemptBackoffJitter)))
+	}
+	if o.config.MaxElapsed > 0 && time.Since(start)+delay >= o.config.MaxElapsed {
+		return fmt.Errorf("time budget of %s exhausted", o.config.MaxElapsed)
+	}
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cacheKey hashes a request together with the configured seed. Requests are
+// keyed before recent answers are added, so history changes do not bust the cache.
+func (o *Orchestrator) cacheKey(req GenerateRequest) string {
+	data, _ := json.Marshal(struct {
+		Request GenerateRequest
+		Seed    int64
+	}{req, o.config.Seed})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedResult returns the result stored under key, or nil. The result is
+// shared with earlier callers and must not be modified.
+func (o *Orchestrator) cachedResult(key string) *GenerateResult {
+	o.cacheMu.Lock()
+	defer o.cacheMu.Unlock()
+	return o.cache[key]
+}
+
+// storeResult caches a successful result. The cache is never evicted.
+func (o *Orchestrator) storeResult(key string, result *GenerateResult) {
+	o.cacheMu.Lock()
+	defer o.cacheMu.Unlock()
+	if o.cache == nil {
+		o.cache = make(map[string]*GenerateResult)
+	}
+	o.cache[key] = result
+}
+
+// recentAnswers returns answers of puzzles dated within the repeat window
+// before the request date.
+func (o *Orchestrator) recentAnswers(ctx context.Context, req GenerateRequest) ([]string, error) {
+	if o.config.AnswerRepeatWindowDays <= 0 || o.answerHistory == nil || req.Date == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", req.Date, err)
 	}
+	from := date.AddDate(0, 0, -o.config.AnswerRepeatWindowDays).Format("2006-01-02")
+	to := date.AddDate(0, 0, -1).Format("2006-01-02")
 
-	return nil, fmt.Errorf("generation failed after %d attempts: %w", o.config.MaxAttempts, lastError)
+	return o.answerHistory.RecentAnswers(ctx, req.Language, from, to)
 }
 
-func (o *Orchestrator) generateAttempt(ctx context.Context, req GenerateRequest, attempt int) (*GenerateResult, error) {
+// buildDraftReport summarizes QA results for storing a generated puzzle as a draft.
+// LLMTraceRef carries the generation ID so stored drafts can be matched to logs.
+func buildDraftReport(result *GenerateResult) *domain.DraftReport {
+	report := &domain.DraftReport{LLMTraceRef: result.GenerationID}
+	if len(result.ClueCollisions) > 0 {
+		report.RiskFlags = append(report.RiskFlags, "CLUE_CELL_COLLISION")
+	}
+	if len(result.PlaceholderClues) > 0 {
+		report.RiskFlags = append(report.RiskFlags, "PLACEHOLDER_CLUE")
+	}
+	if result.QAScore == nil {
+		return report
+	}
+
+	report.FillScore = int(result.QAScore.Components["fill"] * 100)
+	report.ClueScore = int(result.QAScore.Components["clues"] * 100)
+	report.FreshnessScore = int(result.QAScore.Components["freshness"] * 100)
+	for _, flag := range result.QAScore.Flags {
+		report.RiskFlags = append(report.RiskFlags, flag.Code)
+	}
+	return report
+}
+
+// GenerateClues generates clue candidates for a single answer, outside of a full
+// puzzle generation. Used by editors to refresh clues on a hand-edited grid.
+func (o *Orchestrator) GenerateClues(ctx context.Context, answer string, thm *theme.Theme, difficulty int) (*clue.GeneratedClues, error) {
+	normalized := o.langPack.Normalize(answer)
+	if normalized == "" {
+		return nil, &GenerationError{Phase: "validate", Err: fmt.Errorf("answer %q has no grid letters", answer)}
+	}
+	if difficulty < 1 || difficulty > 5 {
+		difficulty = o.config.TargetDifficulty
+	}
+
+	clues, err := o.clueGen.GenerateCluesForSlot(ctx, normalized, thm, difficulty)
+	if err != nil {
+		return nil, &GenerationError{Phase: "clue", Err: err}
+	}
+	return clues, nil
+}
+
+// RethemeOnly generates a new theme for an existing puzzle and returns a copy
+// with the title, theme tags and description updated. Grid and clues are kept.
+func (o *Orchestrator) RethemeOnly(ctx context.Context, p *domain.Puzzle, constraints theme.ThemeConstraints) (*domain.Puzzle, error) {
+	if constraints.Difficulty == 0 {
+		constraints.Difficulty = p.Difficulty
+	}
+
+	thm, err := o.themeGen.GenerateTheme(ctx, p.Date, constraints)
+	if err != nil {
+		return nil, &GenerationError{Phase: "theme", Err: err}
+	}
+
+	updated := *p
+	updated.Title = thm.Title
+	updated.Metadata.ThemeTags = append([]string(nil), thm.Keywords...)
+	updated.Metadata.Notes = thm.Description
+
+	return &updated, nil
+}
+
+// GenerationPlan describes what Generate would do for a request, without
+// calling the LLM.
+type GenerationPlan struct {
+	GridRows     int          `json:"grid_rows"`
+	GridCols     int          `json:"grid_cols"`
+	FillStrategy FillStrategy `json:"fill_strategy"` // Strategy in effect for the request
+	Lengths      []int        `json:"lengths"`       // Word lengths candidates are requested for
+	LengthGroups [][]int      `json:"length_groups"` // One candidate request per group
+	ThemeRequest llm.Request  `json:"theme_request"` // Request sent to generate the theme
+	// EstimatedEntries is the template's slot count when solving a template,
+	// or the builder's target word count when building word-first.
+	EstimatedEntries int `json:"estimated_entries"`
+	ClueBatchSize    int `json:"clue_batch_size"`
+	ClueBatches      int `json:"clue_batches"` // Clue requests for EstimatedEntries entries
+	LLMCalls         int `json:"llm_calls"`    // Minimum calls of one attempt, without retries
+}
+
+// Plan returns the generation plan of a request: grid size, candidate length
+// groups, theme prompt and estimated clue batches of one attempt. It makes no
+// LLM call, so it can be used to check a request and its prompts before
+// spending tokens.
+func (o *Orchestrator) Plan(req GenerateRequest) (*GenerationPlan, error) {
+	if err := o.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	rows, cols := o.gridSize(req)
+	lengths := o.candidateLengths(req, rows, cols)
+	plan := &GenerationPlan{
+		GridRows:         rows,
+		GridCols:         cols,
+		FillStrategy:     FillStrategyWordFirst,
+		Lengths:          lengths,
+		LengthGroups:     theme.GroupLengths(lengths),
+		ThemeRequest:     o.themeGen.BuildRequest(req.Date, req.Constraints),
+		EstimatedEntries: fill.DefaultTargetWords,
+		ClueBatchSize:    o.clueGen.BatchSize(),
+	}
+	plan.ThemeRequest.Model = req.Model
+	if o.usesTemplateSolver(req) {
+		plan.FillStrategy = FillStrategyTemplateSolver
+		plan.EstimatedEntries = len(fill.DiscoverSlots(req.Template))
+	}
+	if plan.ClueBatchSize > 0 {
+		plan.ClueBatches = (plan.EstimatedEntries + plan.ClueBatchSize - 1) / plan.ClueBatchSize
+	}
+	plan.LLMCalls = 1 + len(plan.LengthGroups) + plan.ClueBatches
+
+	return plan, nil
+}
+
+// usesTemplateSolver reports whether a request is filled with the backtracking
+// solver on its template rather than built word-first.
+func (o *Orchestrator) usesTemplateSolver(req GenerateRequest) bool {
+	return req.Template != nil && o.config.FillStrategy != FillStrategyWordFirst
+}
+
+// gridSize returns the grid dimensions of a generation attempt: the requested
+// size, or the template's when building word-first around it, falling back to
+// the configured size when out of range.
+func (o *Orchestrator) gridSize(req GenerateRequest) (rows, cols int) {
+	rows, cols = req.GridRows, req.GridCols
+	if req.Template != nil && !o.usesTemplateSolver(req) {
+		rows, cols = len(req.Template), len(req.Template[0])
+	}
+	if rows < 7 || rows > 16 {
+		rows = o.config.GridSize[0]
+	}
+	if cols < 7 || cols > 16 {
+		cols = o.config.GridSize[1]
+	}
+	return rows, cols
+}
+
+// candidateLengths returns the word lengths candidates are requested for:
+// every length that fits the grid when building word-first, or the
+// template's slot lengths when solving it.
+func (o *Orchestrator) candidateLengths(req GenerateRequest, rows, cols int) []int {
+	if o.usesTemplateSolver(req) {
+		return theme.LengthsFromSlots(fill.DiscoverSlots(req.Template))
+	}
+	return theme.AllLengthsForGrid(rows, cols)
+}
+
+// validateRequest checks request parameters that can be rejected up front.
+func (o *Orchestrator) validateRequest(req GenerateRequest) error {
+	switch o.config.FillStrategy {
+	case "", FillStrategyTemplateSolver, FillStrategyWordFirst:
+	default:
+		return &GenerationError{Phase: "validate", Err: fmt.Errorf("unknown fill strategy %q", o.config.FillStrategy)}
+	}
+
+	rows := req.GridRows
+	cols := req.GridCols
+	if req.Template != nil {
+		rows = len(req.Template)
+		cols = 0
+		if rows > 0 {
+			cols = len(req.Template[0])
+		}
+		if cols == 0 {
+			return &GenerationError{Phase: "validate", Err: fmt.Errorf("template is empty")}
+		}
+	}
+	if rows <= 0 {
+		rows = o.config.GridSize[0]
+	}
+	if cols <= 0 {
+		cols = o.config.GridSize[1]
+	}
+
+	if o.config.MaxGridCells > 0 && rows*cols > o.config.MaxGridCells {
+		return &GenerationError{
+			Phase: "validate",
+			Err: fmt.Errorf("grid %dx%d has %d cells, exceeds maximum of %d",
+				rows, cols, rows*cols, o.config.MaxGridCells),
+		}
+	}
+
+	return nil
+}
+
+func (o *Orchestrator) generateAttempt(ctx context.Context, req GenerateRequest, attempt int, logger *slog.Logger) (*GenerateResult, error) {
 	result := &GenerateResult{
 		Stats: GenerationStats{},
 	}
@@ -156,59 +595,180 @@ func (o *Orchestrator) generateAttempt(ctx context.Context, req GenerateRequest,
 	}
 	result.Theme = thm
 	result.Stats.ThemeTime = time.Since(themeStart)
+	logger.Info("theme generated", "title", thm.Title, "duration", result.Stats.ThemeTime.String())
 
 	// Step 2: Determine grid size
-	rows := req.GridRows
-	cols := req.GridCols
-	if rows < 7 || rows > 16 {
-		rows = o.config.GridSize[0]
-	}
-	if cols < 7 || cols > 16 {
-		cols = o.config.GridSize[1]
-	}
+	rows, cols := o.gridSize(req)
+	useSolver := o.usesTemplateSolver(req)
 
 	// Step 3: Generate candidates (word-first approach)
-	// Get lengths from 3-9 (optimal for mots fléchés)
-	lengths := theme.AllLengthsForGrid(rows, cols)
+	lengths := o.candidateLengths(req, rows, cols)
 
-	lexicon, err := o.candidateGen.GenerateCandidates(ctx, thm, lengths)
+	fillCtx, cancelFill := phaseContext(ctx, o.config.FillTimeout)
+	defer cancelFill()
+
+	lexicon, err := o.candidateGen.GenerateCandidates(fillCtx, thm, lengths)
 	if err != nil {
+		if timeoutErr := phaseTimeout(ctx, fillCtx, "fill", ErrFillTimeout, err); timeoutErr != nil {
+			return nil, timeoutErr
+		}
 		return nil, fmt.Errorf("candidate generation failed: %w", err)
 	}
 
+	logger.Info("candidates generated", "count", lexicon.Size())
+
 	// Merge with base lexicon
-	if o.baseLexicon != nil {
-		for _, word := range o.baseLexicon.Words() {
-			entry, _ := o.baseLexicon.GetEntry(word)
-			lexicon.Add(word, entry.Frequency, entry.Tags)
+	o.mergeBaseLexicon(lexicon)
+	o.softAvoid(lexicon, req.SoftAvoid)
+
+	// Step 4: Build grid using word-first approach, or fill the requested template
+	fillStart := time.Now()
+
+	// Collect all candidate words from lexicon, minus banned answers
+	avoid := make(map[string]bool, len(req.AvoidAnswers))
+	for _, answer := range req.AvoidAnswers {
+		if normalized := o.langPack.Normalize(answer); normalized != "" {
+			avoid[normalized] = true
+		}
+	}
+	var candidates []string
+	for _, word := range lexicon.Words() {
+		if !avoid[word] {
+			candidates = append(candidates, word)
 		}
 	}
 
-	// Step 4: Build grid using word-first approach
-	// Place larger words first, then fill gaps with smaller words
-	fillStart := time.Now()
+	seed := o.config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	seed += int64(attempt)
+
+	var template [][]domain.Cell
+	var slots []fill.Slot
+	var fillResult *fill.Result
+	if useSolver {
+		// Fill the requested template with the backtracking solver
+		template, slots, fillResult, err = o.fillTemplate(fillCtx, req.Template, candidates, lexicon, seed)
+	} else {
+		template, slots, fillResult, err = o.buildWordFirst(candidates, lexicon, rows, cols, seed)
+	}
+	if err != nil {
+		if timeoutErr := phaseTimeout(ctx, fillCtx, "fill", ErrFillTimeout, err); timeoutErr != nil {
+			return nil, timeoutErr
+		}
+		return nil, err
+	}
+	// The grid builder cannot be interrupted, so the budget is also checked
+	// once it returns
+	if timeoutErr := phaseTimeout(ctx, fillCtx, "fill", ErrFillTimeout, fillCtx.Err()); timeoutErr != nil {
+		return nil, timeoutErr
+	}
+
+	result.FillResult = fillResult
+	result.Stats.FillTime = time.Since(fillStart)
+	logger.Info("grid built", "words", len(fillResult.Words), "template_solver", useSolver, "duration", result.Stats.FillTime.String())
+
+	// Step 5: Generate clues
+	clueStart := time.Now()
+	slotInfos := o.buildSlotInfos(slots, fillResult)
 
-	// Collect all candidate words from lexicon
-	candidates := lexicon.Words()
+	clueCtx, cancelClue := phaseContext(ctx, o.config.ClueTimeout)
+	defer cancelClue()
 
+	clueResults, err := o.clueGen.GenerateCluesForPuzzle(clueCtx, slotInfos, thm)
+	if err != nil {
+		if timeoutErr := phaseTimeout(ctx, clueCtx, "clue", ErrClueTimeout, err); timeoutErr != nil {
+			// Keep the filled grid so the caller can retry clues alone
+			timeoutErr.Partial = result
+			return nil, timeoutErr
+		}
+		return nil, fmt.Errorf("clue generation failed: %w", err)
+	}
+	result.Stats.ClueTime = time.Since(clueStart)
+	logger.Info("clues generated", "slots", len(clueResults), "duration", result.Stats.ClueTime.String())
+	for _, info := range slotInfos {
+		if clue.IsPlaceholder(clueResults[info.ID]) {
+			result.PlaceholderClues = append(result.PlaceholderClues, info.Answer)
+			logger.Warn("placeholder clue", "slot", info.ID, "answer", info.Answer)
+		}
+	}
+
+	// Step 6: Assemble puzzle
+	puzzle, collisions := o.assemblePuzzle(req, thm, template, fillResult, clueResults, slots)
+	puzzle.Difficulty, puzzle.Metadata.DifficultyConfidence = qa.EstimateDifficulty(puzzle, lexicon)
+	puzzle.Metadata.EstimatedSolveSeconds = int(qa.EstimateSolveTime(puzzle, lexicon).Seconds())
+	result.Puzzle = puzzle
+	result.ClueCollisions = collisions
+	for _, c := range collisions {
+		logger.Warn("clue cell collision", "row", c.Cell.Row, "col", c.Cell.Col,
+			"direction", c.Direction, "kept_slot", c.KeptSlot, "dropped_slot", c.DroppedSlot)
+	}
+	if count := len(puzzle.Clues.Across) + len(puzzle.Clues.Down); count < o.config.MinClues {
+		return nil, &GenerationError{
+			Phase: "assemble",
+			Err:   fmt.Errorf("puzzle has %d clues, below minimum of %d", count, o.config.MinClues),
+		}
+	}
+
+	// Step 7: Score puzzle
+	result.QAScore = o.scorer.ScorePuzzle(qa.PuzzleInput{
+		Puzzle:     puzzle,
+		FillResult: fillResult,
+		Theme:      thm,
+	})
+	logger.Info("puzzle scored", "qa_score", result.QAScore.Overall)
+
+	return result, nil
+}
+
+// phaseContext derives the context of one generation phase, ending after
+// timeout if it is positive.
+func phaseContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// phaseTimeout returns a GenerationError wrapping budgetErr if phaseCtx ran
+// out of its own budget while ctx is still live, and nil otherwise, including
+// when err is nil.
+func phaseTimeout(ctx, phaseCtx context.Context, phase string, budgetErr, err error) *GenerationError {
+	if err == nil || ctx.Err() != nil || !errors.Is(phaseCtx.Err(), context.DeadlineExceeded) {
+		return nil
+	}
+	return &GenerationError{Phase: phase, Err: fmt.Errorf("%w: %v", budgetErr, err)}
+}
+
+// buildWordFirst builds a grid around the candidates, placing larger words
+// first and filling gaps with smaller ones.
+func (o *Orchestrator) buildWordFirst(
+	candidates []string,
+	lexicon *fill.MemoryLexicon,
+	rows, cols int,
+	seed int64,
+) (template [][]domain.Cell, slots []fill.Slot, fillResult *fill.Result, err error) {
 	// Build grid word-first: start with larger words, fill gaps with smaller ones
 	builder := fill.NewGridBuilder(fill.BuilderConfig{
-		MaxRows: rows,
-		MaxCols: cols,
-		Seed:    time.Now().UnixNano() + int64(attempt),
+		MaxRows:     rows,
+		MaxCols:     cols,
+		Seed:        seed,
+		Weights:     lexicon.Frequencies(),
+		VowelWeight: o.config.VowelWeight,
 	})
 	buildResult := builder.Build(candidates)
 
 	if !buildResult.Success {
-		return nil, fmt.Errorf("grid building failed: not enough words placed")
+		return nil, nil, nil, fmt.Errorf("grid building failed: %w", buildResult.Err)
 	}
 
 	// Convert build result to fill result format
-	template := buildResult.Grid
-	slots := fill.DiscoverSlots(template)
+	template = buildResult.Grid
+	slots = fill.DiscoverSlots(template)
 
 	// Create fill result from the built grid
-	fillResult := &fill.Result{
+	fillResult = &fill.Result{
 		Grid:  make([][]rune, len(template)),
 		Words: make(map[int]string),
 	}
@@ -238,30 +798,48 @@ func (o *Orchestrator) generateAttempt(ctx context.Context, req GenerateRequest,
 		}
 	}
 
-	result.FillResult = fillResult
-	result.Stats.FillTime = time.Since(fillStart)
-
-	// Step 5: Generate clues
-	clueStart := time.Now()
-	slotInfos := o.buildSlotInfos(slots, fillResult)
+	return template, slots, fillResult, nil
+}
 
-	clueResults, err := o.clueGen.GenerateCluesForPuzzle(ctx, slotInfos, thm)
-	if err != nil {
-		return nil, fmt.Errorf("clue generation failed: %w", err)
+// fillTemplate fills a fixed template with the backtracking solver, drawing
+// words from the candidates with their lexicon frequencies.
+func (o *Orchestrator) fillTemplate(
+	ctx context.Context,
+	requested [][]domain.Cell,
+	candidates []string,
+	lexicon *fill.MemoryLexicon,
+	seed int64,
+) (template [][]domain.Cell, slots []fill.Slot, fillResult *fill.Result, err error) {
+	allowed := fill.NewMemoryLexicon()
+	for _, word := range candidates {
+		entry, _ := lexicon.GetEntry(word)
+		allowed.Add(word, entry.Frequency, entry.Tags)
 	}
-	result.Stats.ClueTime = time.Since(clueStart)
 
-	// Step 6: Assemble puzzle
-	puzzle := o.assemblePuzzle(req, thm, template, fillResult, clueResults, slots)
-	result.Puzzle = puzzle
+	template = make([][]domain.Cell, len(requested))
+	for i, row := range requested {
+		template[i] = append([]domain.Cell(nil), row...)
+	}
 
-	// Step 7: Score puzzle
-	result.QAScore = o.scorer.ScorePuzzle(qa.PuzzleInput{
-		Puzzle:     puzzle,
-		FillResult: fillResult,
+	solver := fill.NewSolver(fill.SolverConfig{
+		Lexicon: allowed,
+		Scorer:  fill.NewDefaultScorer(allowed),
+		Seed:    seed,
 	})
+	fillResult, err = solver.SolveContext(ctx, template)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("template fill failed: %w", err)
+	}
 
-	return result, nil
+	for i, row := range fillResult.Grid {
+		for j, r := range row {
+			if template[i][j].Type == domain.CellTypeLetter && r != '.' {
+				template[i][j].Solution = string(r)
+			}
+		}
+	}
+
+	return template, fill.DiscoverSlots(template), fillResult, nil
 }
 
 // createTemplateWithSize creates a template with the specified size, or uses defaults.
@@ -304,13 +882,13 @@ func (o *Orchestrator) createSafeTemplate(rows, cols int) [][]domain.Cell {
 	}
 
 	// Safe block placement: scattered pattern with minimum 2-cell gaps
-	addSafeBlocks(template, rows, cols, o.config.MaxConsecutiveBlocks)
+	addSafeBlocks(template, rows, cols, o.config.MaxConsecutiveBlocks, o.config.SymmetryMode)
 	return template
 }
 
 // addSafeBlocks adds blocks in a pattern that guarantees no dead block clusters.
-// Maintains 180° rotational symmetry while ensuring blocks are not adjacent.
-func addSafeBlocks(grid [][]domain.Cell, rows, cols int, maxConsec int) {
+// Maintains the given symmetry while ensuring blocks are not adjacent.
+func addSafeBlocks(grid [][]domain.Cell, rows, cols int, maxConsec int, symmetry domain.SymmetryMode) {
 	if maxConsec <= 0 {
 		maxConsec = 2
 	}
@@ -331,8 +909,8 @@ func addSafeBlocks(grid [][]domain.Cell, rows, cols int, maxConsec int) {
 		}
 
 		grid[r][c] = domain.Cell{Type: domain.CellTypeBlock}
-		// Symmetric placement
-		sr, sc := rows-1-r, cols-1-c
+		// Symmetric placement (a cell without partner is its own)
+		sr, sc, _ := symmetry.Partner(r, c, rows, cols)
 		grid[sr][sc] = domain.Cell{Type: domain.CellTypeBlock}
 
 		// Mark nearby cells as blocked
@@ -354,11 +932,20 @@ func addSafeBlocks(grid [][]domain.Cell, rows, cols int, maxConsec int) {
 
 	// Target ~12-15% block density with scattered placement
 	targetBlocks := (rows * cols * 13) / 100 / 2 // Divide by 2 for symmetry
+	// Rotational partners lie in the other half of the rows, mirror ones in the same row
+	lastRow := rows/2 + 1
+	switch symmetry {
+	case domain.SymmetryNone:
+		targetBlocks *= 2
+		lastRow = rows
+	case domain.SymmetryMirror:
+		lastRow = rows
+	}
 
 	// Use staggered diagonal pattern
 	placed := 0
 	for offset := 0; placed < targetBlocks && offset < rows+cols; offset++ {
-		for r := 0; r < rows/2+1 && placed < targetBlocks; r++ {
+		for r := 0; r < lastRow && placed < targetBlocks; r++ {
 			c := (r*3 + offset*2) % cols
 			if setBlock(r, c) {
 				placed++
@@ -378,7 +965,7 @@ func (o *Orchestrator) createTemplate(rows, cols int) [][]domain.Cell {
 	}
 
 	// Add symmetric blocks for French-style grids
-	addSymmetricBlocks(template, rows, cols)
+	addSymmetricBlocks(template, rows, cols, o.config.SymmetryMode)
 
 	return template
 }
@@ -459,15 +1046,16 @@ func createDenseTemplate(rows, cols int) [][]domain.Cell {
 	return template
 }
 
-// addSymmetricBlocks adds blocks with 180° rotational symmetry.
+// addSymmetricBlocks adds blocks with the given symmetry (180° rotational by default).
 // Following mots fléchés best practices: sparse isolated blocks for breathing room.
 // Key insight: fewer blocks = easier to fill = more fun puzzles.
-func addSymmetricBlocks(grid [][]domain.Cell, rows, cols int) {
+func addSymmetricBlocks(grid [][]domain.Cell, rows, cols int, symmetry domain.SymmetryMode) {
 	setBlock := func(r, c int) {
 		if r >= 0 && r < rows && c >= 0 && c < cols {
 			grid[r][c] = domain.Cell{Type: domain.CellTypeBlock}
-			// 180° rotational symmetry
-			grid[rows-1-r][cols-1-c] = domain.Cell{Type: domain.CellTypeBlock}
+			if sr, sc, ok := symmetry.Partner(r, c, rows, cols); ok {
+				grid[sr][sc] = domain.Cell{Type: domain.CellTypeBlock}
+			}
 		}
 	}
 
@@ -494,6 +1082,48 @@ func addSymmetricBlocks(grid [][]domain.Cell, rows, cols int) {
 	}
 }
 
+// mergeBaseLexicon adds base lexicon words to the candidate lexicon, scaling
+// their frequency by BaseLexiconWeight. Candidates already present keep their own entry.
+// A zero weight means the default of 1.0, so a Config literal that leaves it
+// unset keeps the base lexicon.
+func (o *Orchestrator) mergeBaseLexicon(lexicon *fill.MemoryLexicon) {
+	if o.baseLexicon == nil {
+		return
+	}
+	weight := o.config.BaseLexiconWeight
+	if weight == 0 {
+		weight = 1.0
+	}
+	for _, word := range o.baseLexicon.Words() {
+		entry, _ := o.baseLexicon.GetEntry(word)
+		lexicon.Add(word, entry.Frequency*weight, entry.Tags)
+	}
+}
+
+// defaultSoftAvoidWeight is the SoftAvoidWeight used when the config sets none.
+const defaultSoftAvoidWeight = 0.01
+
+// softAvoid scales down the frequency of soft-avoided words in the lexicon by
+// SoftAvoidWeight. Unlike banned answers they stay available, but the builder
+// and solver try them last.
+func (o *Orchestrator) softAvoid(lexicon *fill.MemoryLexicon, words []string) {
+	weight := o.config.SoftAvoidWeight
+	if weight == 0 {
+		weight = defaultSoftAvoidWeight
+	}
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		normalized := o.langPack.Normalize(word)
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		if entry, ok := lexicon.GetEntry(normalized); ok {
+			lexicon.SetFrequency(normalized, entry.Frequency*weight)
+		}
+	}
+}
+
 func (o *Orchestrator) buildSlotInfos(slots []fill.Slot, fillResult *fill.Result) []clue.SlotInfo {
 	infos := make([]clue.SlotInfo, 0, len(slots))
 
@@ -530,7 +1160,7 @@ func (o *Orchestrator) assemblePuzzle(
 	fillResult *fill.Result,
 	clueResults map[int]*clue.GeneratedClues,
 	slots []fill.Slot,
-) *domain.Puzzle {
+) (*domain.Puzzle, []ClueCollision) {
 	// Copy template and fill in solutions
 	grid := make([][]domain.Cell, len(template))
 	for i, row := range template {
@@ -549,6 +1179,14 @@ func (o *Orchestrator) assemblePuzzle(
 		}
 	}
 
+	// Number the start cells before clue cells are placed; the numbers move
+	// with their cells when the grid is trimmed
+	grid = domain.AssignNumbers(grid)
+	numbers := make(map[int]int, len(slots))
+	for _, slot := range slots {
+		numbers[slot.ID] = grid[slot.Start.Row][slot.Start.Col].Number
+	}
+
 	// Build clue data for mots fléchés conversion
 	slotClues := make(map[int]clueData)
 
@@ -559,25 +1197,39 @@ func (o *Orchestrator) assemblePuzzle(
 		}
 
 		prompt := ""
-		difficulty := o.config.TargetDifficulty
+		difficulty := 0 // Unknown unless a candidate is selected
+		style := ""
+		ambiguity := ""
 		if clues, ok := clueResults[slot.ID]; ok && len(clues.Candidates) > 0 {
-			best := o.clueGen.SelectBestClue(clues, o.config.TargetDifficulty, []string{"definition", "wordplay"})
+			best := o.clueGen.SelectBestClue(clues, o.config.TargetDifficulty, clueStylesForDifficulty(o.config.TargetDifficulty))
 			if best != nil {
 				prompt = best.Prompt
 				difficulty = best.Difficulty
+				style = strings.ToLower(strings.TrimSpace(best.Style))
+				ambiguity = o.clueGen.AmbiguityNote(prompt)
+				if best.Style == clue.StylePlaceholder {
+					ambiguity = best.Notes // Tag the clue itself for editorial review
+				}
 			}
 		}
 
-		slotClues[slot.ID] = clueData{prompt: prompt, answer: answer, difficulty: difficulty}
+		slotClues[slot.ID] = clueData{
+			prompt:     prompt,
+			answer:     answer,
+			difficulty: difficulty,
+			style:      style,
+			ambiguity:  ambiguity,
+		}
 	}
 
 	// Convert to mots fléchés format: embed clues in grid cells
-	grid = o.convertToMotsFleches(grid, slots, slotClues)
+	grid, collisions := o.convertToMotsFleches(grid, slots, slotClues)
 
 	// For mots fléchés, we keep clues list empty (clues are in grid)
 	// But we can populate it for backwards compatibility
 	var acrossClues, downClues []domain.Clue
 
+	seeds := o.seedWords(thm)
 	for _, slot := range slots {
 		data, ok := slotClues[slot.ID]
 		if !ok {
@@ -585,14 +1237,17 @@ func (o *Orchestrator) assemblePuzzle(
 		}
 
 		c := domain.Clue{
-			ID:         fmt.Sprintf("%d-%s", slot.ID+1, slot.Direction),
-			Direction:  slot.Direction,
-			Number:     slot.ID + 1,
-			Prompt:     data.prompt,
-			Answer:     data.answer,
-			Start:      slot.Start,
-			Length:     slot.Length,
-			Difficulty: data.difficulty,
+			ID:             fmt.Sprintf("%d-%s", numbers[slot.ID], slot.Direction),
+			Direction:      slot.Direction,
+			Number:         numbers[slot.ID],
+			Prompt:         data.prompt,
+			Answer:         data.answer,
+			Start:          slot.Start,
+			Length:         slot.Length,
+			Difficulty:     data.difficulty,
+			Style:          data.style,
+			AmbiguityNotes: data.ambiguity,
+			Highlighted:    seeds[data.answer],
 		}
 
 		if slot.Direction == domain.DirectionAcross {
@@ -605,12 +1260,23 @@ func (o *Orchestrator) assemblePuzzle(
 	sortClues(acrossClues)
 	sortClues(downClues)
 
+	// Mots fléchés grids are read from their arrow cells, so numbers are not shown
+	numbering := domain.NumberingAmerican
+	if hasClueCells(grid) {
+		numbering = domain.NumberingNone
+	}
+
+	model := req.Model
+	if model == "" {
+		model = o.llmClient.Model()
+	}
+
 	return &domain.Puzzle{
 		ID:         fmt.Sprintf("%s-%s", req.Language, req.Date),
 		Date:       req.Date,
 		Language:   req.Language,
 		Title:      thm.Title,
-		Author:     "LLM Generator",
+		Author:     o.author(req),
 		Difficulty: o.config.TargetDifficulty,
 		Status:     domain.StatusDraft,
 		Grid:       grid,
@@ -618,27 +1284,105 @@ func (o *Orchestrator) assemblePuzzle(
 			Across: acrossClues,
 			Down:   downClues,
 		},
+		NumberingScheme: numbering,
 		Metadata: domain.Metadata{
 			ThemeTags: thm.Keywords,
 			Notes:     thm.Description,
+			Model:     model,
+			Provider:  o.llmClient.Provider(),
 		},
 		CreatedAt: time.Now(),
+	}, collisions
+}
+
+// author returns the byline of a generated puzzle: the request's author, else
+// the configured one, else DefaultAuthor.
+func (o *Orchestrator) author(req GenerateRequest) string {
+	switch {
+	case req.Author != "":
+		return req.Author
+	case o.config.Author != "":
+		return o.config.Author
+	default:
+		return DefaultAuthor
+	}
+}
+
+// clueStylesForDifficulty returns the preferred clue styles, most preferred
+// first: definitions for easy puzzles, wordplay and cultural references for
+// hard ones.
+func clueStylesForDifficulty(difficulty int) []string {
+	switch {
+	case difficulty <= 2:
+		return []string{"definition"}
+	case difficulty >= 4:
+		return []string{"wordplay", "cultural", "definition"}
+	default:
+		return []string{"definition", "wordplay"}
 	}
 }
 
+// seedWords returns the theme's seed words, normalized, as a set. Only these
+// entries are highlighted: candidates the LLM merely marked thematic are fill.
+func (o *Orchestrator) seedWords(thm *theme.Theme) map[string]bool {
+	seeds := make(map[string]bool, len(thm.SeedWords))
+	for _, word := range thm.SeedWords {
+		seeds[o.langPack.Normalize(word)] = true
+	}
+	return seeds
+}
+
+// hasClueCells reports whether any cell of the grid holds a mots fléchés clue.
+func hasClueCells(grid [][]domain.Cell) bool {
+	for _, row := range grid {
+		for _, cell := range row {
+			if cell.IsClue() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // convertToMotsFleches converts a traditional crossword grid to mots fléchés format.
 // In mots fléchés, clues are embedded in cells adjacent to word starts.
+// When two entries of the same direction claim one clue cell, the first keeps
+// it and the conflict is reported instead of overwriting the earlier clue.
 func (o *Orchestrator) convertToMotsFleches(
 	grid [][]domain.Cell,
 	slots []fill.Slot,
 	slotClues map[int]clueData,
-) [][]domain.Cell {
+) ([][]domain.Cell, []ClueCollision) {
 	rows := len(grid)
 	if rows == 0 {
-		return grid
+		return grid, nil
 	}
 	_ = len(grid[0]) // cols not needed but validates grid
 
+	type claimKey struct {
+		pos domain.Position
+		dir domain.Direction
+	}
+	claimed := make(map[claimKey]int)
+	var collisions []ClueCollision
+
+	// claim reserves a clue cell for a slot, reporting a collision if another
+	// slot of the same direction already holds it.
+	claim := func(pos domain.Position, slot fill.Slot) bool {
+		key := claimKey{pos: pos, dir: slot.Direction}
+		if owner, ok := claimed[key]; ok && owner != slot.ID {
+			collisions = append(collisions, ClueCollision{
+				Cell:        pos,
+				Direction:   slot.Direction,
+				KeptSlot:    owner,
+				DroppedSlot: slot.ID,
+			})
+			return false
+		}
+		claimed[key] = slot.ID
+		return true
+	}
+
 	// For each slot, find where to place the clue cell
 	for _, slot := range slots {
 		data, ok := slotClues[slot.ID]
@@ -654,7 +1398,8 @@ func (o *Orchestrator) convertToMotsFleches(
 			clueCol := startCol - 1
 			if clueCol >= 0 {
 				cell := &grid[startRow][clueCol]
-				if cell.Type == domain.CellTypeBlock || cell.Type == domain.CellTypeClue {
+				if (cell.Type == domain.CellTypeBlock || cell.Type == domain.CellTypeClue) &&
+					claim(domain.Position{Row: startRow, Col: clueCol}, slot) {
 					cell.Type = domain.CellTypeClue
 					cell.ClueAcross = data.prompt
 				}
@@ -664,7 +1409,8 @@ func (o *Orchestrator) convertToMotsFleches(
 			clueRow := startRow - 1
 			if clueRow >= 0 {
 				cell := &grid[clueRow][startCol]
-				if cell.Type == domain.CellTypeBlock || cell.Type == domain.CellTypeClue {
+				if (cell.Type == domain.CellTypeBlock || cell.Type == domain.CellTypeClue) &&
+					claim(domain.Position{Row: clueRow, Col: startCol}, slot) {
 					cell.Type = domain.CellTypeClue
 					cell.ClueDown = data.prompt
 				}
@@ -675,7 +1421,7 @@ func (o *Orchestrator) convertToMotsFleches(
 	// Trim the grid to remove excess blocks and ensure clue cells on edges
 	grid = o.trimAndPadGrid(grid, slots, slotClues)
 
-	return grid
+	return grid, collisions
 }
 
 // trimAndPadGrid trims excess blocks and ensures words have clue cells.
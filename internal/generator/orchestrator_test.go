@@ -1,12 +1,28 @@
 package generator
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/generator/clue"
 	"lesmotsdatche/internal/generator/fill"
 	"lesmotsdatche/internal/generator/languagepack"
 	"lesmotsdatche/internal/generator/llm"
+	"lesmotsdatche/internal/generator/qa"
+	"lesmotsdatche/internal/generator/theme"
 )
 
 func TestOrchestrator_CreateDefaultTemplate(t *testing.T) {
@@ -75,6 +91,38 @@ func TestOrchestrator_SymmetricBlocks(t *testing.T) {
 	}
 }
 
+func TestOrchestrator_MirrorSymmetry(t *testing.T) {
+	config := DefaultConfig()
+	config.SymmetryMode = domain.SymmetryMirror
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, config)
+
+	template := orch.createSafeTemplate(10, 10)
+	for i := range template {
+		for j := range template[i] {
+			if template[i][j].IsBlock() != template[i][9-j].IsBlock() {
+				t.Errorf("mirror symmetry broken at (%d,%d) vs (%d,%d)", i, j, i, 9-j)
+			}
+		}
+	}
+
+	structure := func(mode domain.SymmetryMode) float64 {
+		scorerConfig := qa.DefaultScorerConfig()
+		scorerConfig.SymmetryMode = mode
+		score := qa.NewScorer(languagepack.NewFrenchPack(), scorerConfig).
+			ScorePuzzle(qa.PuzzleInput{Puzzle: &domain.Puzzle{Grid: template}})
+		return score.Components["structure"]
+	}
+	mirror := structure(domain.SymmetryMirror)
+	rotational := structure(domain.SymmetryRotational)
+	if mirror < 0.9 {
+		t.Errorf("expected high mirror-mode structure score, got %.2f", mirror)
+	}
+	if rotational >= mirror {
+		t.Errorf("expected rotational-mode score (%.2f) below mirror-mode score (%.2f)", rotational, mirror)
+	}
+}
+
 func TestOrchestrator_BuildSlotInfos(t *testing.T) {
 	config := DefaultConfig()
 	mock := llm.NewMockClient()
@@ -105,6 +153,246 @@ func TestOrchestrator_BuildSlotInfos(t *testing.T) {
 	}
 }
 
+func TestOrchestrator_AssemblePuzzle_HighlightsThemeEntries(t *testing.T) {
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, DefaultConfig())
+
+	block := domain.Cell{Type: domain.CellTypeBlock}
+	letter := domain.Cell{Type: domain.CellTypeLetter}
+	template := [][]domain.Cell{
+		{block, letter, letter, letter, letter},
+		{block, letter, letter, letter, letter},
+		{block, letter, letter, letter, letter},
+	}
+	slots := []fill.Slot{
+		{ID: 0, Direction: domain.DirectionAcross, Start: domain.Position{Row: 0, Col: 1}, Length: 4},
+		{ID: 1, Direction: domain.DirectionAcross, Start: domain.Position{Row: 1, Col: 1}, Length: 4},
+		{ID: 2, Direction: domain.DirectionAcross, Start: domain.Position{Row: 2, Col: 1}, Length: 4},
+	}
+	fillResult := &fill.Result{
+		Grid:  [][]rune{[]rune("#MERS"), []rune("#TAPE"), []rune("#CAPS")},
+		Words: map[int]string{0: "MERS", 1: "TAPE", 2: "CAPS"},
+	}
+
+	// CAPS is a candidate the LLM marked thematic, not a seed word
+	thm := &theme.Theme{Title: "La mer", SeedWords: []string{"mers"}}
+	req := GenerateRequest{Date: "2026-01-15", Language: "fr"}
+	puzzle, _ := orch.assemblePuzzle(req, thm, template, fillResult, nil, slots)
+
+	highlighted := map[string]bool{}
+	for _, c := range puzzle.Clues.Across {
+		highlighted[c.Answer] = c.Highlighted
+	}
+	if !highlighted["MERS"] {
+		t.Error("expected seed word MERS to be highlighted")
+	}
+	if highlighted["TAPE"] || highlighted["CAPS"] {
+		t.Errorf("expected only seed words highlighted, got TAPE=%v CAPS=%v", highlighted["TAPE"], highlighted["CAPS"])
+	}
+}
+
+func TestOrchestrator_AssemblePuzzle_CellNumbers(t *testing.T) {
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, DefaultConfig())
+
+	block := domain.Cell{Type: domain.CellTypeBlock}
+	letter := domain.Cell{Type: domain.CellTypeLetter}
+	template := [][]domain.Cell{
+		{letter, letter, letter},
+		{letter, block, letter},
+		{letter, letter, letter},
+	}
+	slots := fill.DiscoverSlots(template)
+	fillResult := &fill.Result{
+		Grid:  [][]rune{[]rune("CAT"), []rune("A#O"), []rune("RUE")},
+		Words: map[int]string{},
+	}
+	for _, slot := range slots {
+		fillResult.Words[slot.ID] = slot.Pattern(fillResult.Grid)
+	}
+
+	req := GenerateRequest{Date: "2026-01-15", Language: "fr"}
+	puzzle, _ := orch.assemblePuzzle(req, &theme.Theme{Title: "Test"}, template, fillResult, nil, slots)
+
+	want := map[string]int{"CAT": 1, "RUE": 3, "CAR": 1, "TOE": 2}
+	starts := map[domain.Position]int{}
+	for _, c := range append(puzzle.Clues.Across, puzzle.Clues.Down...) {
+		if c.Number != want[c.Answer] {
+			t.Errorf("%s %s: number %d, want %d", c.Direction, c.Answer, c.Number, want[c.Answer])
+		}
+		if got := puzzle.Grid[c.Start.Row][c.Start.Col].Number; got != c.Number {
+			t.Errorf("%s %s: start cell number %d, want %d", c.Direction, c.Answer, got, c.Number)
+		}
+		starts[c.Start] = c.Number
+	}
+	for r, row := range puzzle.Grid {
+		for c, cell := range row {
+			if _, ok := starts[domain.Position{Row: r, Col: c}]; !ok && cell.Number != 0 {
+				t.Errorf("non-start cell (%d,%d) has number %d", r, c, cell.Number)
+			}
+		}
+	}
+	if puzzle.NumberingScheme != domain.NumberingAmerican {
+		t.Errorf("numbering scheme = %q, want %q", puzzle.NumberingScheme, domain.NumberingAmerican)
+	}
+}
+
+func TestOrchestrator_AssemblePuzzle_ClueDifficulty(t *testing.T) {
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, DefaultConfig())
+
+	block := domain.Cell{Type: domain.CellTypeBlock}
+	letter := domain.Cell{Type: domain.CellTypeLetter}
+	template := [][]domain.Cell{
+		{block, letter, letter, letter, letter},
+		{block, letter, letter, letter, letter},
+	}
+	slots := []fill.Slot{
+		{ID: 0, Direction: domain.DirectionAcross, Start: domain.Position{Row: 0, Col: 1}, Length: 4},
+		{ID: 1, Direction: domain.DirectionAcross, Start: domain.Position{Row: 1, Col: 1}, Length: 4},
+	}
+	fillResult := &fill.Result{
+		Grid:  [][]rune{[]rune("#MERS"), []rune("#TAPE")},
+		Words: map[int]string{0: "MERS", 1: "TAPE"},
+	}
+	clues := map[int]*clue.GeneratedClues{
+		0: {Answer: "MERS", Candidates: []clue.ClueCandidate{
+			{Prompt: "Étendues d'eau salée", Style: "definition", Difficulty: 1},
+			{Prompt: "Elles ont leurs vagues à l'âme", Style: "wordplay", Difficulty: 5},
+		}},
+		// No candidates for TAPE
+	}
+
+	thm := &theme.Theme{Title: "La mer"}
+	req := GenerateRequest{Date: "2026-01-15", Language: "fr"}
+	puzzle, _ := orch.assemblePuzzle(req, thm, template, fillResult, clues, slots)
+
+	best := orch.clueGen.SelectBestClue(clues[0], orch.config.TargetDifficulty, clueStylesForDifficulty(orch.config.TargetDifficulty))
+	got := map[string]domain.Clue{}
+	for _, c := range puzzle.Clues.Across {
+		got[c.Answer] = c
+	}
+	if got["MERS"].Prompt != best.Prompt || got["MERS"].Difficulty != best.Difficulty {
+		t.Errorf("expected selected clue %q (difficulty %d), got %q (difficulty %d)",
+			best.Prompt, best.Difficulty, got["MERS"].Prompt, got["MERS"].Difficulty)
+	}
+	if got["MERS"].Difficulty == orch.config.TargetDifficulty {
+		t.Errorf("difficulty should come from the candidate, not the target %d", orch.config.TargetDifficulty)
+	}
+	if got["TAPE"].Difficulty != 0 {
+		t.Errorf("expected unknown difficulty 0 without candidates, got %d", got["TAPE"].Difficulty)
+	}
+}
+
+func TestClueStylesForDifficulty(t *testing.T) {
+	tests := []struct {
+		difficulty int
+		first      string
+	}{
+		{1, "definition"},
+		{2, "definition"},
+		{3, "definition"},
+		{4, "wordplay"},
+		{5, "wordplay"},
+	}
+	for _, tt := range tests {
+		styles := clueStylesForDifficulty(tt.difficulty)
+		if len(styles) == 0 || styles[0] != tt.first {
+			t.Errorf("difficulty %d: styles = %v, want %q first", tt.difficulty, styles, tt.first)
+		}
+	}
+
+	hard := strings.Join(clueStylesForDifficulty(5), ",")
+	if !strings.Contains(hard, "cultural") {
+		t.Errorf("difficulty 5 should include cultural clues, got %s", hard)
+	}
+}
+
+func TestOrchestrator_ConvertToMotsFleches_Collision(t *testing.T) {
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, DefaultConfig())
+
+	block := domain.Cell{Type: domain.CellTypeBlock}
+	letter := domain.Cell{Type: domain.CellTypeLetter}
+	grid := [][]domain.Cell{
+		{block, letter, letter, letter, letter},
+		{letter, block, block, block, block},
+		{letter, block, block, block, block},
+	}
+
+	// Two across entries start at (0,1) and both claim the clue cell at (0,0),
+	// which also legitimately holds the clue of the down entry below it.
+	slots := []fill.Slot{
+		{ID: 0, Direction: domain.DirectionAcross, Start: domain.Position{Row: 0, Col: 1}, Length: 4},
+		{ID: 1, Direction: domain.DirectionAcross, Start: domain.Position{Row: 0, Col: 1}, Length: 2},
+		{ID: 2, Direction: domain.DirectionDown, Start: domain.Position{Row: 1, Col: 0}, Length: 2},
+	}
+	slotClues := map[int]clueData{
+		0: {prompt: "Premier", answer: "MERS"},
+		1: {prompt: "Second", answer: "ME"},
+		2: {prompt: "Vertical", answer: "OR"},
+	}
+
+	grid, collisions := orch.convertToMotsFleches(grid, slots, slotClues)
+
+	cell := grid[0][0]
+	if cell.Type != domain.CellTypeClue {
+		t.Fatalf("expected clue cell at (0,0), got %s", cell.Type)
+	}
+	if cell.ClueAcross != "Premier" {
+		t.Errorf("expected first across clue to be kept, got %q", cell.ClueAcross)
+	}
+	if cell.ClueDown != "Vertical" {
+		t.Errorf("expected down clue alongside across clue, got %q", cell.ClueDown)
+	}
+
+	if len(collisions) != 1 {
+		t.Fatalf("expected 1 collision, got %d", len(collisions))
+	}
+	c := collisions[0]
+	if c.Cell != (domain.Position{Row: 0, Col: 0}) || c.Direction != domain.DirectionAcross {
+		t.Errorf("unexpected collision location: %+v", c)
+	}
+	if c.KeptSlot != 0 || c.DroppedSlot != 1 {
+		t.Errorf("expected slot 0 kept and slot 1 dropped, got %+v", c)
+	}
+
+	report := buildDraftReport(&GenerateResult{ClueCollisions: collisions})
+	if len(report.RiskFlags) != 1 || report.RiskFlags[0] != "CLUE_CELL_COLLISION" {
+		t.Errorf("expected CLUE_CELL_COLLISION risk flag, got %v", report.RiskFlags)
+	}
+}
+
+func TestOrchestrator_AssemblePuzzle_PlaceholderClue(t *testing.T) {
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()), languagepack.NewFrenchPack(), nil, DefaultConfig())
+
+	template := [][]domain.Cell{
+		{{Type: domain.CellTypeBlock}, {Type: domain.CellTypeBlock}, {Type: domain.CellTypeBlock}},
+		{{Type: domain.CellTypeBlock}, {Type: domain.CellTypeLetter}, {Type: domain.CellTypeLetter}},
+	}
+	slots := fill.DiscoverSlots(template)
+	fillResult := &fill.Result{
+		Grid:  [][]rune{{'#', '#', '#'}, {'#', 'O', 'R'}},
+		Words: map[int]string{slots[0].ID: "OR"},
+	}
+	clueResults := map[int]*clue.GeneratedClues{
+		slots[0].ID: {Answer: "OR", Candidates: []clue.ClueCandidate{{
+			Prompt: "(définition manquante)", Style: clue.StylePlaceholder, Difficulty: 2, Notes: "needs review",
+		}}},
+	}
+
+	puzzle, _ := orch.assemblePuzzle(GenerateRequest{Language: "fr", Date: "2025-01-01"}, &theme.Theme{Title: "Test"},
+		template, fillResult, clueResults, slots)
+	if len(puzzle.Clues.Across) != 1 || puzzle.Clues.Across[0].AmbiguityNotes != "needs review" {
+		t.Errorf("expected the placeholder clue to carry its review note, got %+v", puzzle.Clues.Across)
+	}
+
+	report := buildDraftReport(&GenerateResult{PlaceholderClues: []string{"OR"}})
+	if len(report.RiskFlags) != 1 || report.RiskFlags[0] != "PLACEHOLDER_CLUE" {
+		t.Errorf("expected PLACEHOLDER_CLUE risk flag, got %v", report.RiskFlags)
+	}
+}
+
 func TestDefaultConfig(t *testing.T) {
 	config := DefaultConfig()
 
@@ -125,6 +413,728 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestOrchestrator_Generate_RejectsOversizedGrid(t *testing.T) {
+	config := DefaultConfig()
+	mock := llm.NewMockClient()
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+
+	orch := NewOrchestrator(validatingClient, languagepack.NewFrenchPack(), nil, config)
+
+	_, err := orch.Generate(context.Background(), GenerateRequest{
+		Date:     "2026-01-15",
+		Language: "fr",
+		GridRows: 20,
+		GridCols: 20,
+	})
+
+	var genErr *GenerationError
+	if !errors.As(err, &genErr) {
+		t.Fatalf("expected GenerationError, got %v", err)
+	}
+	if genErr.Phase != "validate" {
+		t.Errorf("expected phase 'validate', got %q", genErr.Phase)
+	}
+	if mock.CallCount() != 0 {
+		t.Errorf("expected no LLM calls, got %d", mock.CallCount())
+	}
+}
+
+func TestOrchestrator_Plan(t *testing.T) {
+	mock := llm.NewMockClient()
+	pack := languagepack.NewFrenchPack()
+	orch := NewOrchestrator(llm.NewValidatingClient(mock, llm.DefaultConfig()), pack, nil, DefaultConfig())
+
+	plan, err := orch.Plan(GenerateRequest{
+		Date:        "2026-01-15",
+		Language:    "fr",
+		GridRows:    13,
+		GridCols:    13,
+		Constraints: theme.ThemeConstraints{Difficulty: 3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if plan.GridRows != 13 || plan.GridCols != 13 {
+		t.Errorf("expected 13x13 grid, got %dx%d", plan.GridRows, plan.GridCols)
+	}
+	if plan.FillStrategy != FillStrategyWordFirst {
+		t.Errorf("expected word-first without a template, got %q", plan.FillStrategy)
+	}
+	wantGroups := [][]int{{2, 3, 4}, {5, 6, 7}, {8, 9, 10}}
+	if !reflect.DeepEqual(plan.LengthGroups, wantGroups) {
+		t.Errorf("expected length groups %v, got %v", wantGroups, plan.LengthGroups)
+	}
+	if plan.ThemeRequest.SystemPrompt != pack.Prompts().ThemeGeneration {
+		t.Errorf("expected theme system prompt, got %q", plan.ThemeRequest.SystemPrompt)
+	}
+	if !strings.Contains(plan.ThemeRequest.Prompt, "2026-01-15") {
+		t.Errorf("expected theme prompt to mention the date, got %q", plan.ThemeRequest.Prompt)
+	}
+	if plan.ClueBatches != 2 || plan.LLMCalls != 6 {
+		t.Errorf("expected 2 clue batches and 6 LLM calls, got %d and %d", plan.ClueBatches, plan.LLMCalls)
+	}
+	if mock.CallCount() != 0 {
+		t.Errorf("expected no LLM calls, got %d", mock.CallCount())
+	}
+
+	if _, err := orch.Plan(GenerateRequest{Date: "2026-01-15", GridRows: 20, GridCols: 20}); err == nil {
+		t.Error("expected oversized grid to be rejected")
+	}
+}
+
+func TestOrchestrator_MergeBaseLexicon_Weight(t *testing.T) {
+	config := DefaultConfig()
+	config.BaseLexiconWeight = 0.1
+	mock := llm.NewMockClient()
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+
+	base := fill.NewMemoryLexicon()
+	base.Add("CHAR", 1.0, nil)
+	base.Add("CHAT", 1.0, nil)
+
+	orch := NewOrchestrator(validatingClient, languagepack.NewFrenchPack(), base, config)
+
+	candidates := fill.NewMemoryLexicon()
+	candidates.Add("CHAT", 1.0, []string{"thematic"})
+	orch.mergeBaseLexicon(candidates)
+
+	char, _ := candidates.GetEntry("CHAR")
+	if char.Frequency != 0.1 {
+		t.Errorf("expected base word frequency 0.1, got %.2f", char.Frequency)
+	}
+	chat, _ := candidates.GetEntry("CHAT")
+	if chat.Frequency != 1.0 {
+		t.Errorf("expected candidate frequency to be kept at 1.0, got %.2f", chat.Frequency)
+	}
+	// An unset weight keeps base words at their own frequency
+	orch = NewOrchestrator(validatingClient, languagepack.NewFrenchPack(), base, Config{})
+	candidates = fill.NewMemoryLexicon()
+	orch.mergeBaseLexicon(candidates)
+	if char, _ := candidates.GetEntry("CHAR"); char.Frequency != 1.0 {
+		t.Errorf("expected base word frequency 1.0 with a zero weight, got %.2f", char.Frequency)
+	}
+}
+
+func TestOrchestrator_Generate_CorrelationID(t *testing.T) {
+	config := DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	client := &scriptedClient{}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	orch := NewOrchestrator(llm.NewValidatingClient(client, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config).WithLogger(logger)
+
+	result, err := orch.Generate(context.Background(), GenerateRequest{Date: "2026-01-15", Language: "fr"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.GenerationID == "" {
+		t.Fatal("expected a generation ID")
+	}
+	if result.Report == nil || result.Report.LLMTraceRef != result.GenerationID {
+		t.Errorf("expected draft report to reference generation ID %s, got %+v", result.GenerationID, result.Report)
+	}
+
+	records := 0
+	for _, line := range bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n")) {
+		var record map[string]interface{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("invalid log record %q: %v", line, err)
+		}
+		if record["generation_id"] != result.GenerationID {
+			t.Errorf("log record %q missing generation ID", record["msg"])
+		}
+		records++
+	}
+	if records < 2 {
+		t.Errorf("expected phase log records, got %d", records)
+	}
+}
+
+// fakeAnswerHistory serves fixed recent answers and records the requested window.
+type fakeAnswerHistory struct {
+	answers          []string
+	fromDate, toDate string
+}
+
+func (h *fakeAnswerHistory) RecentAnswers(ctx context.Context, language, fromDate, toDate string) ([]string, error) {
+	h.fromDate, h.toDate = fromDate, toDate
+	return h.answers, nil
+}
+
+func TestOrchestrator_Generate_AvoidsRecentAnswers(t *testing.T) {
+	config := DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	config.Seed = 20260115
+	req := GenerateRequest{Date: "2026-01-15", Language: "fr"}
+
+	// Same seed and script without history, to learn which answers would be used
+	baseline, err := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config).Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var recent []string
+	for _, word := range baseline.FillResult.Words {
+		recent = append(recent, strings.ToLower(word)) // History answers need not be normalized
+	}
+
+	config.AnswerRepeatWindowDays = 7
+	history := &fakeAnswerHistory{answers: recent}
+	orch := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config).WithAnswerHistory(history)
+
+	result, err := orch.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if history.fromDate != "2026-01-08" || history.toDate != "2026-01-14" {
+		t.Errorf("expected window 2026-01-08..2026-01-14, got %s..%s", history.fromDate, history.toDate)
+	}
+	banned := make(map[string]bool)
+	for _, word := range recent {
+		banned[strings.ToUpper(word)] = true
+	}
+	for _, word := range result.FillResult.Words {
+		if banned[word] {
+			t.Errorf("recent answer %s reused in new grid", word)
+		}
+	}
+}
+
+func TestOrchestrator_SoftAvoid(t *testing.T) {
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), nil, DefaultConfig())
+
+	lexicon := fill.NewMemoryLexicon()
+	lexicon.Add("CHAT", 0.8, nil)
+	lexicon.Add("CHIEN", 0.6, nil)
+	orch.softAvoid(lexicon, []string{"chat", "Chat", "LOUP"})
+
+	chat, ok := lexicon.GetEntry("CHAT")
+	if !ok {
+		t.Fatal("expected a soft-avoided word to stay in the lexicon")
+	}
+	if want := 0.8 * defaultSoftAvoidWeight; chat.Frequency != want {
+		t.Errorf("expected CHAT frequency scaled once to %v, got %v", want, chat.Frequency)
+	}
+	if chien, _ := lexicon.GetEntry("CHIEN"); chien.Frequency != 0.6 {
+		t.Errorf("expected CHIEN untouched, got %v", chien.Frequency)
+	}
+	if lexicon.Contains("LOUP") {
+		t.Error("soft-avoiding must not add words")
+	}
+}
+
+func TestOrchestrator_Generate_SoftAvoidsRecentAnswers(t *testing.T) {
+	config := DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	config.Seed = 20260115
+	req := GenerateRequest{Date: "2026-01-15", Language: "fr"}
+
+	baseline, err := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config).Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var recent []string
+	for _, word := range baseline.FillResult.Words {
+		recent = append(recent, word)
+	}
+
+	config.AnswerRepeatWindowDays = 7
+	config.AnswerRepeatSoft = true
+	orch := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config).WithAnswerHistory(&fakeAnswerHistory{answers: recent})
+
+	result, err := orch.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	avoided := make(map[string]bool)
+	for _, word := range recent {
+		avoided[word] = true
+	}
+	reused := 0
+	for _, word := range result.FillResult.Words {
+		if avoided[word] {
+			reused++
+		}
+	}
+	if reused == len(result.FillResult.Words) {
+		t.Errorf("expected soft-avoided answers to be deprioritized, all %d reused", reused)
+	}
+}
+
+func TestOrchestrator_Generate_Template(t *testing.T) {
+	template, err := fill.NamedTemplate("diagonal-7x7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.Seed = 7
+	orch := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	result, err := orch.Generate(context.Background(), GenerateRequest{
+		Date:     "2026-01-15",
+		Language: "fr",
+		Template: template,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slots := fill.DiscoverSlots(template)
+	if len(result.FillResult.Words) != len(slots) {
+		t.Errorf("expected all %d template slots filled, got %d", len(slots), len(result.FillResult.Words))
+	}
+	for i, row := range template {
+		for j, cell := range row {
+			filled := result.FillResult.Grid[i][j]
+			if cell.Type == domain.CellTypeBlock && filled != '#' {
+				t.Errorf("block at (%d,%d) was filled with %q", i, j, filled)
+			}
+			if cell.Type == domain.CellTypeLetter && (filled < 'A' || filled > 'Z') {
+				t.Errorf("letter cell at (%d,%d) left unfilled", i, j)
+			}
+		}
+	}
+}
+
+func TestOrchestrator_Generate_FillStrategy(t *testing.T) {
+	template, err := fill.NamedTemplate("diagonal-7x7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	generate := func(strategy FillStrategy, template [][]domain.Cell) (*GenerateResult, error) {
+		config := DefaultConfig()
+		config.Seed = 7
+		config.MaxAttempts = 1
+		config.FillStrategy = strategy
+		orch := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+			languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+		return orch.Generate(context.Background(), GenerateRequest{
+			Date:     "2026-01-15",
+			Language: "fr",
+			Template: template,
+		})
+	}
+
+	matchesTemplate := func(grid [][]rune, template [][]domain.Cell) bool {
+		if len(grid) != len(template) {
+			return false
+		}
+		for i, row := range template {
+			if len(grid[i]) != len(row) {
+				return false
+			}
+			for j, cell := range row {
+				if (cell.Type == domain.CellTypeBlock) != (grid[i][j] == '#') {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	result, err := generate(FillStrategyTemplateSolver, template)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !matchesTemplate(result.FillResult.Grid, template) {
+		t.Error("expected the solver to fill the template's structure")
+	}
+	if len(result.FillResult.Words) != len(fill.DiscoverSlots(template)) {
+		t.Errorf("expected every template slot filled, got %d words", len(result.FillResult.Words))
+	}
+
+	// Word-first builds its own block pattern, only sized by the template
+	// (the builder cannot seed a 7x7 grid, so use a larger one)
+	large := (&Orchestrator{config: DefaultConfig()}).createSafeTemplate(10, 10)
+	result, err = generate(FillStrategyWordFirst, large)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if matchesTemplate(result.FillResult.Grid, large) {
+		t.Error("expected the word-first builder to ignore the template's blocks")
+	}
+
+	var genErr *GenerationError
+	if _, err := generate("magic", template); !errors.As(err, &genErr) || genErr.Phase != "validate" {
+		t.Errorf("expected validate error for unknown strategy, got %v", err)
+	}
+}
+
+func TestOrchestrator_Generate_MinClues(t *testing.T) {
+	template, err := fill.NamedTemplate("diagonal-7x7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slots := fill.DiscoverSlots(template)
+
+	generate := func(minClues int) (*GenerateResult, error) {
+		config := DefaultConfig()
+		config.Seed = 7
+		config.MaxAttempts = 1
+		config.MinClues = minClues
+		orch := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+			languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+		return orch.Generate(context.Background(), GenerateRequest{
+			Date:     "2026-01-15",
+			Language: "fr",
+			Template: template,
+		})
+	}
+
+	// One clue per slot at most, so this minimum can never be met
+	_, err = generate(len(slots) + 1)
+	var genErr *GenerationError
+	if !errors.As(err, &genErr) {
+		t.Fatalf("expected GenerationError, got %v", err)
+	}
+	if genErr.Phase != "assemble" {
+		t.Errorf("expected phase 'assemble', got %q", genErr.Phase)
+	}
+
+	result, err := generate(len(slots) / 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count := len(result.Puzzle.Clues.Across) + len(result.Puzzle.Clues.Down); count < len(slots)/2 {
+		t.Errorf("expected at least %d clues, got %d", len(slots)/2, count)
+	}
+}
+
+func TestOrchestrator_Generate_FailureCarriesBestResult(t *testing.T) {
+	template, err := fill.NamedTemplate("diagonal-7x7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.Seed = 7
+	config.MaxAttempts = 2
+	config.MinComponentScores = map[string]float64{"fill": 1.1} // Unreachable, so every attempt fails QA
+	orch := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	result, err := orch.Generate(context.Background(), GenerateRequest{
+		Date:     "2026-01-15",
+		Language: "fr",
+		Template: template,
+	})
+	if result != nil {
+		t.Error("expected no result on failure")
+	}
+
+	var failure *GenerationFailure
+	if !errors.As(err, &failure) {
+		t.Fatalf("expected GenerationFailure, got %v", err)
+	}
+	if failure.Attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", failure.Attempts)
+	}
+	best := failure.BestResult
+	if best == nil || best.QAScore == nil {
+		t.Fatal("expected best result with a QA score")
+	}
+	if best.GenerationID != failure.GenerationID || best.Puzzle == nil {
+		t.Errorf("expected best result to carry the puzzle and generation ID %s, got %q",
+			failure.GenerationID, best.GenerationID)
+	}
+	if best.Stats.Attempts < 1 || best.Stats.Attempts > 2 {
+		t.Errorf("expected best attempt number in 1..2, got %d", best.Stats.Attempts)
+	}
+	if best.QAScore.Overall <= 0 || len(best.QAScore.Components) == 0 {
+		t.Errorf("expected QA breakdown on best result, got %+v", best.QAScore)
+	}
+}
+
+func TestOrchestrator_Generate_AttemptBackoff(t *testing.T) {
+	const backoff = 20 * time.Millisecond
+	config := DefaultConfig()
+	config.MaxAttempts = 20
+	config.AttemptBackoff = backoff
+	config.AttemptBackoffJitter = 5 * time.Millisecond
+	config.MaxElapsed = 150 * time.Millisecond
+	// The mock has no responses, so every attempt fails at the theme phase
+	mock := llm.NewMockClient()
+	orch := NewOrchestrator(llm.NewValidatingClient(mock, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	start := time.Now()
+	_, err := orch.Generate(context.Background(), GenerateRequest{Date: "2026-01-15", Language: "fr"})
+	elapsed := time.Since(start)
+
+	var failure *GenerationFailure
+	if !errors.As(err, &failure) {
+		t.Fatalf("expected GenerationFailure, got %v", err)
+	}
+	if failure.Attempts < 2 || failure.Attempts >= config.MaxAttempts {
+		t.Errorf("expected MaxElapsed to stop the loop after a few attempts, got %d", failure.Attempts)
+	}
+	if want := time.Duration(failure.Attempts-1) * backoff; elapsed < want {
+		t.Errorf("expected at least %v of backoff for %d attempts, took %v", want, failure.Attempts, elapsed)
+	}
+	if !strings.Contains(err.Error(), "time budget") {
+		t.Errorf("expected time budget error, got %v", err)
+	}
+
+	// A cancelled context interrupts the wait
+	config.AttemptBackoff = time.Hour
+	config.MaxElapsed = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	orch = NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+	if _, err := orch.Generate(ctx, GenerateRequest{Date: "2026-01-15", Language: "fr"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context cancellation, got %v", err)
+	}
+}
+
+var updateGolden = flag.Bool("update", false, "rewrite golden fixtures in testdata")
+
+// TestOrchestrator_Generate_Golden drives the whole pipeline (theme, candidates,
+// fill, clues, assembly, QA) with scripted LLM responses and a fixed seed, and
+// compares the puzzle to a checked-in fixture. Run with -update after an
+// intended change to regenerate it.
+func TestOrchestrator_Generate_Golden(t *testing.T) {
+	responsesPath := filepath.Join("..", "..", "testdata", "golden_10x10_llm_responses.json")
+	goldenPath := filepath.Join("..", "..", "testdata", "golden_10x10_puzzle.json")
+
+	data, err := os.ReadFile(responsesPath)
+	if err != nil {
+		t.Fatalf("failed to read scripted responses: %v", err)
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to parse scripted responses: %v", err)
+	}
+	responses := make([]string, len(raw))
+	for i, r := range raw {
+		responses[i] = string(r)
+	}
+
+	config := DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	config.Seed = 20260115
+	mock := llm.NewMockClient(responses...)
+	orch := NewOrchestrator(llm.NewValidatingClient(mock, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	result, err := orch.Generate(context.Background(), GenerateRequest{Date: "2026-01-15", Language: "fr"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.CallCount() != len(responses) {
+		t.Errorf("expected %d LLM calls, got %d", len(responses), mock.CallCount())
+	}
+
+	puzzle := *result.Puzzle
+	puzzle.CreatedAt = time.Time{} // Wall-clock time is not part of the fixture
+	got, err := json.MarshalIndent(&puzzle, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode puzzle: %v", err)
+	}
+	got = append(got, '\n')
+
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("puzzle does not match %s (run with -update if the change is intended)\ngot:\n%s", goldenPath, got)
+	}
+}
+
+func TestOrchestrator_Generate_CacheResults(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "golden_10x10_llm_responses.json"))
+	if err != nil {
+		t.Fatalf("failed to read scripted responses: %v", err)
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to parse scripted responses: %v", err)
+	}
+	responses := make([]string, len(raw))
+	for i, r := range raw {
+		responses[i] = string(r)
+	}
+
+	config := DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	config.Seed = 20260115
+	config.CacheResults = true
+	config.MaxAttempts = 1 // The uncached request below fails; no need to retry it
+	mock := llm.NewMockClient(responses...)
+	orch := NewOrchestrator(llm.NewValidatingClient(mock, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	req := GenerateRequest{Date: "2026-01-15", Language: "fr"}
+	first, err := orch.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := mock.CallCount()
+
+	second, err := orch.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error on cached request: %v", err)
+	}
+	if second != first {
+		t.Error("expected the cached result to be returned")
+	}
+	if mock.CallCount() != calls {
+		t.Errorf("expected no LLM calls on a cache hit, got %d more", mock.CallCount()-calls)
+	}
+
+	// A different request misses the cache; the exhausted mock makes it fail
+	if _, err := orch.Generate(context.Background(), GenerateRequest{Date: "2026-01-16", Language: "fr"}); err == nil {
+		t.Error("expected a different request to bypass the cache")
+	}
+}
+
+func TestOrchestrator_Generate_Author(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "golden_10x10_llm_responses.json"))
+	if err != nil {
+		t.Fatalf("failed to read scripted responses: %v", err)
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to parse scripted responses: %v", err)
+	}
+	responses := make([]string, len(raw))
+	for i, r := range raw {
+		responses[i] = string(r)
+	}
+
+	config := DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	config.Seed = 20260115
+	config.Author = "Les Mots d'Atche"
+	orch := NewOrchestrator(llm.NewValidatingClient(llm.NewMockClient(responses...), llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	result, err := orch.Generate(context.Background(), GenerateRequest{
+		Date:     "2026-01-15",
+		Language: "fr",
+		Author:   "Jeanne Martin",
+		Model:    "gpt-4o-mini",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	puzzle := result.Puzzle
+	if puzzle.Author != "Jeanne Martin" {
+		t.Errorf("expected request author, got %q", puzzle.Author)
+	}
+	if puzzle.Metadata.Model != "gpt-4o-mini" || puzzle.Metadata.Provider != "mock" {
+		t.Errorf("expected model gpt-4o-mini from provider mock, got %q from %q",
+			puzzle.Metadata.Model, puzzle.Metadata.Provider)
+	}
+
+	if got := orch.author(GenerateRequest{}); got != "Les Mots d'Atche" {
+		t.Errorf("expected configured author without a request author, got %q", got)
+	}
+	orch.config.Author = ""
+	if got := orch.author(GenerateRequest{}); got != DefaultAuthor {
+		t.Errorf("expected %q without any author, got %q", DefaultAuthor, got)
+	}
+}
+
+func TestOrchestrator_Generate_EstimatesDifficulty(t *testing.T) {
+	config := DefaultConfig()
+	config.GridSize = [2]int{10, 10}
+	config.Seed = 20260115
+	orch := NewOrchestrator(llm.NewValidatingClient(&scriptedClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	result, err := orch.Generate(context.Background(), GenerateRequest{Date: "2026-01-15", Language: "fr"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	puzzle := result.Puzzle
+	if puzzle.Difficulty < 1 || puzzle.Difficulty > 5 {
+		t.Errorf("expected an estimated difficulty of 1-5, got %d", puzzle.Difficulty)
+	}
+	if c := puzzle.Metadata.DifficultyConfidence; c <= 0 || c > 1 {
+		t.Errorf("expected a difficulty confidence in (0, 1], got %v", c)
+	}
+}
+
+func TestOrchestrator_Generate_ClueTimeout(t *testing.T) {
+	config := DefaultConfig()
+	config.Seed = 7
+	config.MaxAttempts = 1
+	config.FillTimeout = 10 * time.Second
+	config.ClueTimeout = 50 * time.Millisecond
+	orch := NewOrchestrator(llm.NewValidatingClient(&stalledClueClient{}, llm.DefaultConfig()),
+		languagepack.NewFrenchPack(), fill.SampleFrenchLexicon(), config)
+
+	template, err := fill.NamedTemplate("diagonal-7x7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	start := time.Now()
+	_, err = orch.Generate(context.Background(), GenerateRequest{
+		Date:     "2026-01-15",
+		Language: "fr",
+		Template: template,
+	})
+	if err == nil {
+		t.Fatal("expected clue phase to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("clue phase ran %s, past its budget", elapsed)
+	}
+
+	if !errors.Is(err, ErrClueTimeout) {
+		t.Errorf("expected ErrClueTimeout, got %v", err)
+	}
+	if errors.Is(err, ErrFillTimeout) {
+		t.Errorf("fill phase should have finished within its budget: %v", err)
+	}
+	var genErr *GenerationError
+	if !errors.As(err, &genErr) || genErr.Phase != "clue" {
+		t.Fatalf("expected clue phase GenerationError, got %v", err)
+	}
+	if genErr.Partial == nil || genErr.Partial.FillResult == nil {
+		t.Fatal("expected the filled grid to be kept")
+	}
+	if len(genErr.Partial.FillResult.Words) != len(fill.DiscoverSlots(template)) {
+		t.Errorf("expected all template slots filled, got %d words", len(genErr.Partial.FillResult.Words))
+	}
+}
+
+// stalledClueClient answers like scriptedClient but never returns clues,
+// blocking until the request context ends.
+type stalledClueClient struct {
+	scriptedClient
+}
+
+func (c *stalledClueClient) Complete(ctx context.Context, req llm.Request) (*llm.Response, error) {
+	if strings.Contains(req.Prompt, `"slots"`) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return c.scriptedClient.Complete(ctx, req)
+}
+
 func TestSortClues(t *testing.T) {
 	// Test is internal but we can test the sorting behavior through the result
 	// This is a placeholder for more comprehensive tests
@@ -139,3 +1149,67 @@ func TestOrchestrator_Generate_Integration(t *testing.T) {
 	ctx := context.Background()
 	_ = ctx
 }
+
+// scriptedClient answers theme, candidate and clue requests with canned JSON
+// so the full pipeline can run without an LLM. Candidates come from the
+// sample French lexicon; clues echo each requested answer.
+type scriptedClient struct {
+	mu       sync.Mutex
+	requests []llm.Request
+}
+
+var batchAnswerRe = regexp.MustCompile(`(?m)^- \d+ \S+: ([A-Z]+) \(`)
+
+func (c *scriptedClient) Complete(ctx context.Context, req llm.Request) (*llm.Response, error) {
+	c.mu.Lock()
+	c.requests = append(c.requests, req)
+	c.mu.Unlock()
+
+	var body interface{}
+	switch {
+	case strings.Contains(req.Prompt, `"slots"`):
+		type clueJSON struct {
+			Prompt     string `json:"prompt"`
+			Style      string `json:"style"`
+			Difficulty int    `json:"difficulty"`
+		}
+		type slotJSON struct {
+			Answer string     `json:"answer"`
+			Clues  []clueJSON `json:"clues"`
+		}
+		var slots []slotJSON
+		for _, m := range batchAnswerRe.FindAllStringSubmatch(req.Prompt, -1) {
+			slots = append(slots, slotJSON{
+				Answer: m[1],
+				Clues: []clueJSON{
+					{Prompt: "Définition de " + strings.ToLower(m[1]), Style: "definition", Difficulty: 3},
+					{Prompt: "Jeu sur " + strings.ToLower(m[1]), Style: "wordplay", Difficulty: 3},
+				},
+			})
+		}
+		body = map[string]interface{}{"slots": slots}
+	case strings.Contains(req.SystemPrompt+req.Prompt, "candidates"):
+		type candJSON struct {
+			Word       string  `json:"word"`
+			Score      float64 `json:"score"`
+			Difficulty int     `json:"difficulty"`
+			IsThematic bool    `json:"is_thematic"`
+		}
+		var cands []candJSON
+		for _, w := range fill.SampleFrenchLexicon().Words() {
+			cands = append(cands, candJSON{Word: w, Score: 0.5, Difficulty: 2})
+		}
+		body = map[string]interface{}{"candidates": cands}
+	default:
+		body = map[string]interface{}{
+			"title":       "La Mer",
+			"description": "Un thème marin",
+			"keywords":    []string{"MER", "OCEAN", "PLAGE"},
+			"seed_words":  []string{"MER", "EAU", "SEL", "ILE", "PORT", "NAGE"},
+			"difficulty":  3,
+		}
+	}
+
+	content, _ := json.Marshal(body)
+	return &llm.Response{Content: string(content), FinishReason: "stop", TokensUsed: 100}, nil
+}
@@ -0,0 +1,53 @@
+package qa
+
+import (
+	"math"
+
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/generator/fill"
+)
+
+// maxSignalSpread is the largest standard deviation of signals on the 1-5
+// scale, reached when they split evenly between 1 and 5.
+const maxSignalSpread = 2.0
+
+// EstimateDifficulty estimates a puzzle's difficulty (1-5) from two signals
+// per entry: its clue difficulty, when set, and its answer rarity in the
+// lexicon mapped onto the same scale. Confidence runs from 0 to 1 and is high
+// when the signals agree closely. Puzzles without entries get the puzzle's
+// own difficulty (3 if unset) with zero confidence.
+func EstimateDifficulty(p *domain.Puzzle, lexicon fill.Lexicon) (difficulty int, confidence float64) {
+	var signals []float64
+	for _, clues := range [][]domain.Clue{p.Clues.Across, p.Clues.Down} {
+		for _, c := range clues {
+			if c.Difficulty >= 1 && c.Difficulty <= 5 {
+				signals = append(signals, float64(c.Difficulty))
+			}
+			signals = append(signals, 1+4*answerRarity(lexicon, c.Answer))
+		}
+	}
+
+	if len(signals) == 0 {
+		if p.Difficulty >= 1 && p.Difficulty <= 5 {
+			return p.Difficulty, 0
+		}
+		return 3, 0
+	}
+
+	mean := 0.0
+	for _, s := range signals {
+		mean += s
+	}
+	mean /= float64(len(signals))
+
+	variance := 0.0
+	for _, s := range signals {
+		variance += (s - mean) * (s - mean)
+	}
+	spread := math.Sqrt(variance / float64(len(signals)))
+
+	difficulty = int(math.Round(mean))
+	difficulty = max(1, min(5, difficulty))
+	confidence = math.Max(0, 1-spread/maxSignalSpread)
+	return difficulty, confidence
+}
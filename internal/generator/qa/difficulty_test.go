@@ -0,0 +1,41 @@
+package qa
+
+import (
+	"testing"
+
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/generator/fill"
+)
+
+func TestEstimateDifficulty(t *testing.T) {
+	lexicon := fill.NewMemoryLexicon()
+	lexicon.Add("CHAT", 1.0, nil)
+	lexicon.Add("CHIEN", 1.0, nil)
+	lexicon.Add("XYSTE", 0.0, nil)
+
+	easy := &domain.Puzzle{Clues: domain.Clues{
+		Across: []domain.Clue{{Answer: "CHAT", Difficulty: 1}},
+		Down:   []domain.Clue{{Answer: "CHIEN", Difficulty: 1}},
+	}}
+	mixed := &domain.Puzzle{Clues: domain.Clues{
+		Across: []domain.Clue{{Answer: "CHAT", Difficulty: 5}},
+		Down:   []domain.Clue{{Answer: "XYSTE", Difficulty: 1}},
+	}}
+
+	easyLevel, easyConfidence := EstimateDifficulty(easy, lexicon)
+	if easyLevel != 1 {
+		t.Errorf("expected uniformly easy puzzle to estimate 1, got %d", easyLevel)
+	}
+	_, mixedConfidence := EstimateDifficulty(mixed, lexicon)
+	if easyConfidence <= mixedConfidence {
+		t.Errorf("expected agreeing signals (%.2f) to be more confident than mixed ones (%.2f)",
+			easyConfidence, mixedConfidence)
+	}
+	if easyConfidence != 1 {
+		t.Errorf("expected full confidence for identical signals, got %.2f", easyConfidence)
+	}
+
+	if level, confidence := EstimateDifficulty(&domain.Puzzle{Difficulty: 4}, lexicon); level != 4 || confidence != 0 {
+		t.Errorf("expected puzzle difficulty with zero confidence for no entries, got %d, %.2f", level, confidence)
+	}
+}
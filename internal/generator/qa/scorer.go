@@ -2,9 +2,14 @@
 package qa
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"lesmotsdatche/internal/domain"
 	"lesmotsdatche/internal/generator/fill"
 	"lesmotsdatche/internal/generator/languagepack"
+	"lesmotsdatche/internal/generator/theme"
 )
 
 // Score represents a quality score with breakdown.
@@ -12,6 +17,9 @@ type Score struct {
 	Overall    float64            `json:"overall"`    // 0.0-1.0
 	Components map[string]float64 `json:"components"` // Individual scores
 	Flags      []Flag             `json:"flags"`      // Warning/error flags
+	// StyleDistribution is the share of clues of each style, among clues
+	// with a style; nil when no clue has one.
+	StyleDistribution map[string]float64 `json:"style_distribution,omitempty"`
 }
 
 // Flag represents a quality or safety issue.
@@ -39,12 +47,26 @@ type Scorer struct {
 
 // ScorerConfig holds scorer configuration.
 type ScorerConfig struct {
-	MinWordLength    int     // Minimum acceptable word length
-	MaxDuplicates    int     // Maximum duplicate answers allowed
-	FreshnessWindow  int     // Days to check for freshness
-	MinFillScore     float64 // Minimum acceptable fill score
-	MinClueVariety   float64 // Minimum clue style variety
-	TabooCheckStrict bool    // Strict taboo word checking
+	MinWordLength    int                 // Minimum acceptable word length
+	MaxDuplicates    int                 // Maximum duplicate answers allowed
+	FreshnessWindow  int                 // Days to check for freshness
+	MinFillScore     float64             // Minimum acceptable fill score
+	MinClueVariety   float64             // Minimum clue style variety
+	TabooCheckStrict bool                // Strict taboo word checking
+	MinCoherence     float64             // Minimum share of answers related to the theme (0 = disabled)
+	SymmetryMode     domain.SymmetryMode // Block symmetry the structure score expects (empty = rotational)
+
+	// MaxCrossingLetterShare flags grids where one letter fills more than
+	// this share of the crossings, such as a cluster of E crossings (0 = disabled).
+	MaxCrossingLetterShare float64
+
+	// MinDistinctDifficulties flags puzzles whose clues span fewer distinct
+	// difficulties, and drops their difficulty variety to 0 (0 = disabled).
+	MinDistinctDifficulties int
+
+	// MinComponentScores gates acceptance on individual components
+	// ("fill", "clues", "freshness", "structure"); nil disables the gate.
+	MinComponentScores map[string]float64
 }
 
 // DefaultScorerConfig returns default configuration.
@@ -56,6 +78,9 @@ func DefaultScorerConfig() ScorerConfig {
 		MinFillScore:     0.7,
 		MinClueVariety:   0.3,
 		TabooCheckStrict: true,
+		MinCoherence:     0.1,
+
+		MaxCrossingLetterShare: 0.4,
 	}
 }
 
@@ -67,11 +92,17 @@ func NewScorer(langPack languagepack.LanguagePack, config ScorerConfig) *Scorer
 	}
 }
 
+// Config returns the scorer's configuration.
+func (s *Scorer) Config() ScorerConfig {
+	return s.config
+}
+
 // PuzzleInput holds puzzle data for scoring.
 type PuzzleInput struct {
 	Puzzle        *domain.Puzzle
 	FillResult    *fill.Result
-	RecentAnswers []string // Answers from recent puzzles
+	RecentAnswers []string     // Answers from recent puzzles
+	Theme         *theme.Theme // Theme used for generation (optional)
 }
 
 // ScorePuzzle evaluates a complete puzzle.
@@ -88,6 +119,9 @@ func (s *Scorer) ScorePuzzle(input PuzzleInput) *Score {
 	// Score clue quality
 	clueScore := s.scoreClues(input)
 	score.Components["clues"] = clueScore
+	if input.Puzzle != nil {
+		score.StyleDistribution = clueStyleDistribution(append(input.Puzzle.Clues.Across, input.Puzzle.Clues.Down...))
+	}
 
 	// Score freshness
 	freshnessScore := s.scoreFreshness(input)
@@ -101,6 +135,27 @@ func (s *Scorer) ScorePuzzle(input PuzzleInput) *Score {
 	safetyFlags := s.checkSafety(input)
 	score.Flags = append(score.Flags, safetyFlags...)
 
+	// Check theme coherence
+	if flag := s.checkCoherence(input); flag != nil {
+		score.Flags = append(score.Flags, *flag)
+	}
+
+	// Check for a narrow spread of clue difficulties
+	if flag := s.checkDifficultyVariety(input); flag != nil {
+		score.Flags = append(score.Flags, *flag)
+	}
+
+	// Check for awkward letter mixes
+	score.Flags = append(score.Flags, s.checkAwkwardAnswers(input)...)
+
+	// Check for a letter dominating the crossings
+	if flag := s.checkCrossingLetters(input); flag != nil {
+		score.Flags = append(score.Flags, *flag)
+	}
+
+	// Check for entries crossing no other entry
+	score.Flags = append(score.Flags, s.checkOrphanEntries(input)...)
+
 	// Calculate overall score
 	score.Overall = s.calculateOverall(score.Components, score.Flags)
 
@@ -144,11 +199,17 @@ func (s *Scorer) scoreClues(input PuzzleInput) float64 {
 		styles[clue.Difficulty]++
 	}
 
-	// Good variety = distributed difficulties
+	// Good variety = distributed difficulties, and styles when known
 	varietyScore := float64(len(styles)) / 5.0
 	if varietyScore > 1.0 {
 		varietyScore = 1.0
 	}
+	if s.lowDifficultyVariety(allClues) {
+		varietyScore = 0
+	}
+	if distribution := clueStyleDistribution(allClues); distribution != nil {
+		varietyScore = (varietyScore + styleVariety(distribution)) / 2
+	}
 	score += varietyScore * 0.3
 
 	// Check clue length (not too short, not too long)
@@ -179,6 +240,77 @@ func (s *Scorer) scoreClues(input PuzzleInput) float64 {
 	return score
 }
 
+// distinctDifficulties counts the distinct known (1-5) clue difficulties.
+func distinctDifficulties(clues []domain.Clue) int {
+	seen := make(map[int]bool)
+	for _, clue := range clues {
+		if clue.Difficulty > 0 {
+			seen[clue.Difficulty] = true
+		}
+	}
+	return len(seen)
+}
+
+// lowDifficultyVariety reports whether clues span fewer distinct difficulties
+// than MinDistinctDifficulties.
+func (s *Scorer) lowDifficultyVariety(clues []domain.Clue) bool {
+	return s.config.MinDistinctDifficulties > 0 && distinctDifficulties(clues) < s.config.MinDistinctDifficulties
+}
+
+// checkDifficultyVariety flags puzzles whose clues span fewer distinct
+// difficulties than MinDistinctDifficulties.
+func (s *Scorer) checkDifficultyVariety(input PuzzleInput) *Flag {
+	if input.Puzzle == nil {
+		return nil
+	}
+	clues := append(input.Puzzle.Clues.Across, input.Puzzle.Clues.Down...)
+	if len(clues) == 0 || !s.lowDifficultyVariety(clues) {
+		return nil
+	}
+
+	return &Flag{
+		Level:   FlagLevelWarning,
+		Code:    "LOW_DIFFICULTY_VARIETY",
+		Message: "Clues span too few difficulty levels",
+		Details: fmt.Sprintf("%d distinct difficulties, minimum %d", distinctDifficulties(clues), s.config.MinDistinctDifficulties),
+	}
+}
+
+// targetClueStyles is the number of clue styles a varied puzzle mixes evenly.
+const targetClueStyles = 3
+
+// clueStyleDistribution returns the share of each style among clues with a
+// style, or nil when no clue has one.
+func clueStyleDistribution(clues []domain.Clue) map[string]float64 {
+	counts := make(map[string]int)
+	styled := 0
+	for _, clue := range clues {
+		if clue.Style != "" {
+			counts[clue.Style]++
+			styled++
+		}
+	}
+	if styled == 0 {
+		return nil
+	}
+
+	distribution := make(map[string]float64, len(counts))
+	for style, count := range counts {
+		distribution[style] = float64(count) / float64(styled)
+	}
+	return distribution
+}
+
+// styleVariety scores a style distribution from 0 (a single style) to 1 (no
+// style above an even share of targetClueStyles).
+func styleVariety(distribution map[string]float64) float64 {
+	dominant := 0.0
+	for _, share := range distribution {
+		dominant = max(dominant, share)
+	}
+	return min(1.0, (1-dominant)/(1-1.0/targetClueStyles))
+}
+
 func (s *Scorer) scoreFreshness(input PuzzleInput) float64 {
 	if input.Puzzle == nil || len(input.RecentAnswers) == 0 {
 		return 1.0 // No recent data to compare
@@ -252,6 +384,9 @@ func (s *Scorer) scoreStructure(input PuzzleInput) float64 {
 	return score
 }
 
+// checkSymmetry returns the share of cell pairs that agree on being blocks
+// under the configured symmetry mode. Grids are always symmetric under
+// SymmetryNone.
 func (s *Scorer) checkSymmetry(grid [][]domain.Cell) float64 {
 	rows := len(grid)
 	cols := len(grid[0])
@@ -261,10 +396,12 @@ func (s *Scorer) checkSymmetry(grid [][]domain.Cell) float64 {
 
 	for i := 0; i < rows; i++ {
 		for j := 0; j < cols; j++ {
-			// Check 180-degree rotational symmetry
-			oppositeI := rows - 1 - i
-			oppositeJ := cols - 1 - j
+			oppositeI, oppositeJ, ok := s.config.SymmetryMode.Partner(i, j, rows, cols)
+			if !ok {
+				return 1.0
+			}
 
+			// Count each pair once, skipping cells on the axis
 			if i < oppositeI || (i == oppositeI && j < oppositeJ) {
 				total++
 				if grid[i][j].IsBlock() == grid[oppositeI][oppositeJ].IsBlock() {
@@ -329,6 +466,189 @@ func (s *Scorer) checkSafety(input PuzzleInput) []Flag {
 		}
 	}
 
+	// An answer both across and down stands out more than a repeat in one direction
+	across := make(map[string]bool)
+	for _, clue := range input.Puzzle.Clues.Across {
+		across[clue.Answer] = true
+	}
+	flagged := make(map[string]bool)
+	for _, clue := range input.Puzzle.Clues.Down {
+		if across[clue.Answer] && !flagged[clue.Answer] {
+			flagged[clue.Answer] = true
+			flags = append(flags, Flag{
+				Level:   FlagLevelInfo,
+				Code:    "DUPLICATE_CROSS_DIRECTION",
+				Message: "Same answer used across and down",
+				Details: clue.Answer,
+			})
+		}
+	}
+
+	return flags
+}
+
+// checkCoherence flags puzzles whose answers are mostly unrelated to the theme.
+func (s *Scorer) checkCoherence(input PuzzleInput) *Flag {
+	if input.Theme == nil || input.Puzzle == nil || s.config.MinCoherence <= 0 {
+		return nil
+	}
+
+	var answers []string
+	for _, clue := range append(input.Puzzle.Clues.Across, input.Puzzle.Clues.Down...) {
+		answers = append(answers, clue.Answer)
+	}
+	if len(answers) == 0 {
+		return nil
+	}
+
+	coherence := theme.CoherenceScore(input.Theme, answers, s.langPack)
+	if coherence >= s.config.MinCoherence {
+		return nil
+	}
+
+	return &Flag{
+		Level:   FlagLevelWarning,
+		Code:    "LOW_THEME_COHERENCE",
+		Message: "Few answers relate to the theme",
+		Details: fmt.Sprintf("%.0f%% of answers match theme keywords", coherence*100),
+	}
+}
+
+// minCrossingsForLetterShare is the fewest crossings checked for a dominant
+// letter; in smaller grids a few shared letters are already a large share.
+const minCrossingsForLetterShare = 8
+
+// checkCrossingLetters flags grids where a single letter fills more than
+// MaxCrossingLetterShare of the cells crossed by an across and a down entry.
+func (s *Scorer) checkCrossingLetters(input PuzzleInput) *Flag {
+	if input.Puzzle == nil || s.config.MaxCrossingLetterShare <= 0 {
+		return nil
+	}
+
+	acrossLetters := make(map[domain.Position]rune)
+	for _, clue := range input.Puzzle.Clues.Across {
+		for i, pos := range domain.GetCellsForClue(clue) {
+			if i < len(clue.Answer) {
+				acrossLetters[pos] = rune(clue.Answer[i])
+			}
+		}
+	}
+
+	counts := make(map[rune]int)
+	crossings := 0
+	for _, clue := range input.Puzzle.Clues.Down {
+		for _, pos := range domain.GetCellsForClue(clue) {
+			if letter, ok := acrossLetters[pos]; ok {
+				counts[letter]++
+				crossings++
+			}
+		}
+	}
+	if crossings < minCrossingsForLetterShare {
+		return nil
+	}
+
+	var dominant rune
+	for letter, count := range counts {
+		if count > counts[dominant] || (count == counts[dominant] && letter < dominant) {
+			dominant = letter
+		}
+	}
+	share := float64(counts[dominant]) / float64(crossings)
+	if share <= s.config.MaxCrossingLetterShare {
+		return nil
+	}
+
+	return &Flag{
+		Level:   FlagLevelInfo,
+		Code:    "DOMINANT_CROSSING_LETTER",
+		Message: "One letter fills many of the crossings",
+		Details: fmt.Sprintf("%c at %d of %d crossings (%.0f%%)", dominant, counts[dominant], crossings, share*100),
+	}
+}
+
+// minAwkwardLength is the shortest answer checked for an extreme vowel ratio;
+// short all-vowel words such as EAU or OUI are common and fine.
+const minAwkwardLength = 4
+
+// checkAwkwardAnswers flags answers made only of vowels or only of consonants.
+// Y counts as a vowel so words like LYNX pass.
+func (s *Scorer) checkAwkwardAnswers(input PuzzleInput) []Flag {
+	var flags []Flag
+
+	if input.Puzzle == nil {
+		return flags
+	}
+
+	for _, clue := range append(input.Puzzle.Clues.Across, input.Puzzle.Clues.Down...) {
+		if len(clue.Answer) < minAwkwardLength {
+			continue
+		}
+
+		vowels := 0
+		for _, c := range clue.Answer {
+			if strings.ContainsRune("AEIOUY", c) {
+				vowels++
+			}
+		}
+
+		if vowels == 0 || vowels == len(clue.Answer) {
+			flags = append(flags, Flag{
+				Level:   FlagLevelInfo,
+				Code:    "AWKWARD_ANSWER",
+				Message: "Answer has an extreme vowel ratio",
+				Details: clue.Answer,
+			})
+		}
+	}
+
+	return flags
+}
+
+// checkOrphanEntries flags entries that no entry of the other direction
+// crosses, such as a 2-letter word dropped into a gap next to the grid. They
+// cannot be solved from crossings and usually read as filler.
+func (s *Scorer) checkOrphanEntries(input PuzzleInput) []Flag {
+	var flags []Flag
+
+	if input.Puzzle == nil {
+		return flags
+	}
+
+	cellsOf := func(clues []domain.Clue) map[domain.Position]bool {
+		cells := make(map[domain.Position]bool)
+		for _, clue := range clues {
+			for _, pos := range domain.GetCellsForClue(clue) {
+				cells[pos] = true
+			}
+		}
+		return cells
+	}
+	acrossCells := cellsOf(input.Puzzle.Clues.Across)
+	downCells := cellsOf(input.Puzzle.Clues.Down)
+
+	check := func(clues []domain.Clue, crossing map[domain.Position]bool) {
+		for _, clue := range clues {
+			crossed := clue.Length == 0 // No cells to check
+			for _, pos := range domain.GetCellsForClue(clue) {
+				if crossing[pos] {
+					crossed = true
+					break
+				}
+			}
+			if !crossed {
+				flags = append(flags, Flag{
+					Level:   FlagLevelWarning,
+					Code:    "ORPHAN_ENTRY",
+					Message: "Entry is not crossed by any other entry",
+					Details: fmt.Sprintf("%s (%s %d)", clue.Answer, clue.Direction, clue.Number),
+				})
+			}
+		}
+	}
+	check(input.Puzzle.Clues.Across, downCells)
+	check(input.Puzzle.Clues.Down, acrossCells)
+
 	return flags
 }
 
@@ -421,6 +741,31 @@ func (s *Score) IsAcceptable() bool {
 	return true
 }
 
+// IsAcceptableStrict is IsAcceptable plus a per-component gate: any component
+// scoring below its entry in minimums rejects the puzzle. Components absent
+// from the score are not gated.
+func (s *Score) IsAcceptableStrict(minimums map[string]float64) bool {
+	return s.IsAcceptable() && len(s.FailedComponents(minimums)) == 0
+}
+
+// FailedComponents returns the names of components scoring below their
+// minimum, sorted.
+func (s *Score) FailedComponents(minimums map[string]float64) []string {
+	var failed []string
+	for name, min := range minimums {
+		if value, ok := s.Components[name]; ok && value < min {
+			failed = append(failed, name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// IsAcceptable applies the scorer's configured component minimums to a score.
+func (s *Scorer) IsAcceptable(score *Score) bool {
+	return score.IsAcceptableStrict(s.config.MinComponentScores)
+}
+
 // HasErrors returns true if there are error-level flags.
 func (s *Score) HasErrors() bool {
 	for _, flag := range s.Flags {
@@ -6,6 +6,7 @@ import (
 	"lesmotsdatche/internal/domain"
 	"lesmotsdatche/internal/generator/fill"
 	"lesmotsdatche/internal/generator/languagepack"
+	"lesmotsdatche/internal/generator/theme"
 )
 
 func TestScorer_ScorePuzzle(t *testing.T) {
@@ -93,6 +94,77 @@ func TestScorer_ScoreFreshness(t *testing.T) {
 	}
 }
 
+func TestScorer_ScoreClues_StyleVariety(t *testing.T) {
+	scorer := NewScorer(languagepack.NewFrenchPack(), DefaultScorerConfig())
+
+	withStyles := func(styles ...string) *domain.Puzzle {
+		puzzle := createTestPuzzle()
+		puzzle.Clues.Across = append(puzzle.Clues.Across,
+			domain.Clue{Number: 2, Answer: "TOIT", Prompt: "Couvre la maison", Difficulty: 1},
+			domain.Clue{Number: 3, Answer: "NID", Prompt: "Maison d'oiseau", Difficulty: 2},
+		)
+		for i := range puzzle.Clues.Across {
+			puzzle.Clues.Across[i].Style = styles[i]
+		}
+		puzzle.Clues.Down[0].Style = styles[len(puzzle.Clues.Across)]
+		return puzzle
+	}
+
+	monotonous := scorer.ScorePuzzle(PuzzleInput{Puzzle: withStyles("definition", "definition", "definition", "definition")})
+	mixed := scorer.ScorePuzzle(PuzzleInput{Puzzle: withStyles("definition", "wordplay", "cultural", "definition")})
+
+	if got := monotonous.StyleDistribution; len(got) != 1 || got["definition"] != 1.0 {
+		t.Errorf("expected all-definition distribution, got %v", got)
+	}
+	if got := mixed.StyleDistribution; got["definition"] != 0.5 || got["wordplay"] != 0.25 || got["cultural"] != 0.25 {
+		t.Errorf("expected 50/25/25 distribution, got %v", got)
+	}
+	if monotonous.Components["clues"] >= mixed.Components["clues"] {
+		t.Errorf("expected all-definition clues (%.3f) to score below mixed styles (%.3f)",
+			monotonous.Components["clues"], mixed.Components["clues"])
+	}
+
+	// Clues without styles are scored on difficulty alone
+	if unstyled := scorer.ScorePuzzle(PuzzleInput{Puzzle: createTestPuzzle()}); unstyled.StyleDistribution != nil {
+		t.Errorf("expected no style distribution without styles, got %v", unstyled.StyleDistribution)
+	}
+}
+
+func TestScorer_MinDistinctDifficulties(t *testing.T) {
+	config := DefaultScorerConfig()
+	config.MinDistinctDifficulties = 3
+	scorer := NewScorer(languagepack.NewFrenchPack(), config)
+	lenient := NewScorer(languagepack.NewFrenchPack(), DefaultScorerConfig())
+
+	withDifficulties := func(across, down int) *domain.Puzzle {
+		puzzle := createTestPuzzle()
+		puzzle.Clues.Across[0].Difficulty = across
+		puzzle.Clues.Down[0].Difficulty = down
+		puzzle.Clues.Across = append(puzzle.Clues.Across,
+			domain.Clue{Number: 2, Answer: "NID", Prompt: "Maison d'oiseau", Difficulty: 3})
+		return puzzle
+	}
+
+	flat := PuzzleInput{Puzzle: withDifficulties(3, 3)}
+	if got, want := scorer.ScorePuzzle(flat).Components["clues"], lenient.ScorePuzzle(flat).Components["clues"]; got >= want {
+		t.Errorf("expected the minimum to lower the clue score of all-3 clues, got %.3f (%.3f without)", got, want)
+	}
+	flag := scorer.checkDifficultyVariety(flat)
+	if flag == nil {
+		t.Fatal("expected a flag for clues all of difficulty 3")
+	}
+	if flag.Code != "LOW_DIFFICULTY_VARIETY" || flag.Level != FlagLevelWarning || flag.Details != "1 distinct difficulties, minimum 3" {
+		t.Errorf("expected warning LOW_DIFFICULTY_VARIETY, got %+v", flag)
+	}
+
+	if flag := scorer.checkDifficultyVariety(PuzzleInput{Puzzle: withDifficulties(1, 5)}); flag != nil {
+		t.Errorf("expected no flag for difficulties 1, 3 and 5, got %+v", flag)
+	}
+	if flag := lenient.checkDifficultyVariety(flat); flag != nil {
+		t.Errorf("expected no flag with the check disabled, got %+v", flag)
+	}
+}
+
 func TestScorer_CheckSafety_TabooWord(t *testing.T) {
 	langPack := languagepack.NewFrenchPack()
 	scorer := NewScorer(langPack, DefaultScorerConfig())
@@ -121,6 +193,46 @@ func TestScorer_CheckSafety_TabooWord(t *testing.T) {
 	}
 }
 
+func TestScorer_CheckSafety_DuplicateCrossDirection(t *testing.T) {
+	scorer := NewScorer(languagepack.NewFrenchPack(), DefaultScorerConfig())
+
+	countCode := func(flags []Flag, code string) int {
+		n := 0
+		for _, flag := range flags {
+			if flag.Code == code {
+				n++
+			}
+		}
+		return n
+	}
+
+	crossed := &domain.Puzzle{
+		Clues: domain.Clues{
+			Across: []domain.Clue{{Answer: "CHAT", Prompt: "Animal"}, {Answer: "MER", Prompt: "Océan"}},
+			Down:   []domain.Clue{{Answer: "CHAT", Prompt: "Félin"}},
+		},
+	}
+	flags := scorer.checkSafety(PuzzleInput{Puzzle: crossed})
+	if countCode(flags, "DUPLICATE_CROSS_DIRECTION") != 1 {
+		t.Errorf("expected one DUPLICATE_CROSS_DIRECTION flag, got %v", flags)
+	}
+	for _, flag := range flags {
+		if flag.Code == "DUPLICATE_CROSS_DIRECTION" && (flag.Level != FlagLevelInfo || flag.Details != "CHAT") {
+			t.Errorf("expected info flag for CHAT, got %+v", flag)
+		}
+	}
+
+	// A repeat within one direction is only a plain duplicate
+	sameDirection := &domain.Puzzle{
+		Clues: domain.Clues{
+			Across: []domain.Clue{{Answer: "CHAT", Prompt: "Animal"}, {Answer: "CHAT", Prompt: "Félin"}},
+		},
+	}
+	if n := countCode(scorer.checkSafety(PuzzleInput{Puzzle: sameDirection}), "DUPLICATE_CROSS_DIRECTION"); n != 0 {
+		t.Errorf("expected no cross-direction flag for a same-direction repeat, got %d", n)
+	}
+}
+
 func TestScorer_CheckSafety_Duplicate(t *testing.T) {
 	langPack := languagepack.NewFrenchPack()
 	scorer := NewScorer(langPack, DefaultScorerConfig())
@@ -150,6 +262,111 @@ func TestScorer_CheckSafety_Duplicate(t *testing.T) {
 	}
 }
 
+func TestScorer_CheckAwkwardAnswers(t *testing.T) {
+	scorer := NewScorer(languagepack.NewFrenchPack(), DefaultScorerConfig())
+
+	puzzle := &domain.Puzzle{
+		Clues: domain.Clues{
+			Across: []domain.Clue{
+				{Answer: "AIEEU"},  // All vowels
+				{Answer: "MAISON"}, // Normal
+			},
+			Down: []domain.Clue{
+				{Answer: "EAU"},  // Short all-vowel words are fine
+				{Answer: "LYNX"}, // Y counts as a vowel
+			},
+		},
+	}
+
+	flags := scorer.checkAwkwardAnswers(PuzzleInput{Puzzle: puzzle})
+	if len(flags) != 1 {
+		t.Fatalf("expected 1 flag, got %d: %+v", len(flags), flags)
+	}
+	if flags[0].Code != "AWKWARD_ANSWER" || flags[0].Level != FlagLevelInfo || flags[0].Details != "AIEEU" {
+		t.Errorf("expected AWKWARD_ANSWER info flag for AIEEU, got %+v", flags[0])
+	}
+
+	puzzle.Clues.Across[0].Answer = "STRS" // No vowels at all
+	flags = scorer.checkAwkwardAnswers(PuzzleInput{Puzzle: puzzle})
+	if len(flags) != 1 || flags[0].Details != "STRS" {
+		t.Errorf("expected AWKWARD_ANSWER flag for STRS, got %+v", flags)
+	}
+}
+
+func TestScorer_CheckOrphanEntries(t *testing.T) {
+	scorer := NewScorer(languagepack.NewFrenchPack(), DefaultScorerConfig())
+
+	// CHAT and CHIEN cross at (0,0); the gap fill NE at (4,2) crosses nothing
+	puzzle := &domain.Puzzle{
+		Clues: domain.Clues{
+			Across: []domain.Clue{
+				{Number: 1, Direction: domain.DirectionAcross, Answer: "CHAT", Start: domain.Position{Row: 0, Col: 0}, Length: 4},
+				{Number: 2, Direction: domain.DirectionAcross, Answer: "NE", Start: domain.Position{Row: 4, Col: 2}, Length: 2},
+			},
+			Down: []domain.Clue{
+				{Number: 1, Direction: domain.DirectionDown, Answer: "CHIEN", Start: domain.Position{Row: 0, Col: 0}, Length: 5},
+			},
+		},
+	}
+
+	flags := scorer.checkOrphanEntries(PuzzleInput{Puzzle: puzzle})
+	if len(flags) != 1 {
+		t.Fatalf("expected 1 orphan entry, got %v", flags)
+	}
+	if flags[0].Code != "ORPHAN_ENTRY" || flags[0].Level != FlagLevelWarning || flags[0].Details != "NE (across 2)" {
+		t.Errorf("expected warning ORPHAN_ENTRY for NE, got %+v", flags[0])
+	}
+
+	// Crossing it with a down entry clears the flag
+	puzzle.Clues.Down = append(puzzle.Clues.Down,
+		domain.Clue{Number: 3, Direction: domain.DirectionDown, Answer: "TE", Start: domain.Position{Row: 3, Col: 3}, Length: 2})
+	if flags := scorer.checkOrphanEntries(PuzzleInput{Puzzle: puzzle}); len(flags) != 0 {
+		t.Errorf("expected no orphan entry once crossed, got %v", flags)
+	}
+}
+
+func TestScorer_CheckCrossingLetters(t *testing.T) {
+	scorer := NewScorer(languagepack.NewFrenchPack(), DefaultScorerConfig())
+
+	// lattice builds a 5x5 puzzle of across entries on rows 0, 2, 4 and down
+	// entries on columns 0, 2, 4, crossing at 9 cells
+	lattice := func(across, down []string) *domain.Puzzle {
+		puzzle := &domain.Puzzle{}
+		for i, answer := range across {
+			puzzle.Clues.Across = append(puzzle.Clues.Across, domain.Clue{
+				Number: i + 1, Direction: domain.DirectionAcross, Answer: answer,
+				Start: domain.Position{Row: 2 * i, Col: 0}, Length: len(answer),
+			})
+		}
+		for i, answer := range down {
+			puzzle.Clues.Down = append(puzzle.Clues.Down, domain.Clue{
+				Number: i + 1, Direction: domain.DirectionDown, Answer: answer,
+				Start: domain.Position{Row: 0, Col: 2 * i}, Length: len(answer),
+			})
+		}
+		return puzzle
+	}
+
+	eHeavy := lattice([]string{"ETEME", "ELENE", "ESERE"}, []string{"EAEIE", "EOEUE", "ERETE"})
+	flag := scorer.checkCrossingLetters(PuzzleInput{Puzzle: eHeavy})
+	if flag == nil || flag.Code != "DOMINANT_CROSSING_LETTER" {
+		t.Fatalf("expected DOMINANT_CROSSING_LETTER flag, got %+v", flag)
+	}
+	if flag.Details != "E at 9 of 9 crossings (100%)" {
+		t.Errorf("unexpected details %q", flag.Details)
+	}
+
+	balanced := lattice([]string{"ABCDE", "FGHIJ", "KLMNO"}, []string{"AXFXK", "CXHXM", "EXJXO"})
+	if flag := scorer.checkCrossingLetters(PuzzleInput{Puzzle: balanced}); flag != nil {
+		t.Errorf("expected no flag for balanced crossings, got %+v", flag)
+	}
+
+	disabled := NewScorer(languagepack.NewFrenchPack(), ScorerConfig{})
+	if flag := disabled.checkCrossingLetters(PuzzleInput{Puzzle: eHeavy}); flag != nil {
+		t.Errorf("expected no flag when disabled, got %+v", flag)
+	}
+}
+
 func TestScorer_ScoreStructure_Symmetry(t *testing.T) {
 	langPack := languagepack.NewFrenchPack()
 	scorer := NewScorer(langPack, DefaultScorerConfig())
@@ -279,3 +496,66 @@ func createTestPuzzle() *domain.Puzzle {
 		},
 	}
 }
+
+func TestScorer_LowThemeCoherence(t *testing.T) {
+	scorer := NewScorer(languagepack.NewFrenchPack(), DefaultScorerConfig())
+
+	hasFlag := func(score *Score) bool {
+		for _, flag := range score.Flags {
+			if flag.Code == "LOW_THEME_COHERENCE" {
+				return true
+			}
+		}
+		return false
+	}
+
+	offTheme := scorer.ScorePuzzle(PuzzleInput{
+		Puzzle: createTestPuzzle(),
+		Theme:  &theme.Theme{Title: "Espace", Keywords: []string{"PLANETE", "ETOILE", "FUSEE"}},
+	})
+	if !hasFlag(offTheme) {
+		t.Error("expected LOW_THEME_COHERENCE flag for off-theme answers")
+	}
+
+	onTheme := scorer.ScorePuzzle(PuzzleInput{
+		Puzzle: createTestPuzzle(),
+		Theme:  &theme.Theme{Title: "Animaux", Keywords: []string{"CHAT", "CHIEN", "OISEAU"}},
+	})
+	if hasFlag(onTheme) {
+		t.Error("unexpected LOW_THEME_COHERENCE flag for on-theme answers")
+	}
+}
+
+func TestScore_IsAcceptableStrict(t *testing.T) {
+	score := &Score{
+		Overall: 0.85,
+		Components: map[string]float64{
+			"fill":      0.1,
+			"clues":     0.95,
+			"freshness": 1.0,
+			"structure": 0.9,
+		},
+		Flags: []Flag{},
+	}
+	minimums := map[string]float64{"fill": 0.5, "clues": 0.5}
+
+	if !score.IsAcceptable() {
+		t.Fatal("expected strong overall score to pass IsAcceptable")
+	}
+	if score.IsAcceptableStrict(minimums) {
+		t.Error("expected fill below its minimum to be rejected")
+	}
+	if failed := score.FailedComponents(minimums); len(failed) != 1 || failed[0] != "fill" {
+		t.Errorf("expected [fill] to fail, got %v", failed)
+	}
+	if !score.IsAcceptableStrict(nil) {
+		t.Error("expected no gate without minimums")
+	}
+
+	config := DefaultScorerConfig()
+	config.MinComponentScores = minimums
+	scorer := NewScorer(languagepack.NewFrenchPack(), config)
+	if scorer.IsAcceptable(score) {
+		t.Error("expected scorer with component minimums to reject")
+	}
+}
@@ -0,0 +1,75 @@
+package qa
+
+import (
+	"time"
+
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/generator/fill"
+)
+
+const (
+	secondsPerEntry  = 20.0 // Reading and solving one clue of average difficulty and rarity
+	secondsPerLetter = 1.5  // Typing an answer
+	secondsPerCell   = 0.5  // Scanning the grid for the next entry
+	unknownRarity    = 0.5  // Rarity of answers without frequency information
+)
+
+// frequencyLexicon is a lexicon that knows how common its words are;
+// fill.MemoryLexicon satisfies it.
+type frequencyLexicon interface {
+	GetEntry(word string) (fill.WordEntry, bool)
+}
+
+// EstimateSolveTime returns a rough time for an average solver to finish a
+// puzzle. Each entry costs more as its clue gets harder and its answer rarer
+// in the lexicon, plus a per-letter typing cost and a per-cell scanning cost.
+// Clues without a difficulty use the puzzle's; lexicon may be nil.
+func EstimateSolveTime(p *domain.Puzzle, lexicon fill.Lexicon) time.Duration {
+	seconds := 0.0
+	for _, row := range p.Grid {
+		seconds += float64(len(row)) * secondsPerCell
+	}
+
+	for _, clues := range [][]domain.Clue{p.Clues.Across, p.Clues.Down} {
+		for _, c := range clues {
+			difficulty := c.Difficulty
+			if difficulty < 1 || difficulty > 5 {
+				difficulty = p.Difficulty
+			}
+			if difficulty < 1 || difficulty > 5 {
+				difficulty = 3
+			}
+			// Difficulty 3 and unknown rarity cost exactly secondsPerEntry
+			factor := float64(difficulty) / 3 * (1 + answerRarity(lexicon, c.Answer)) / (1 + unknownRarity)
+			seconds += secondsPerEntry*factor + secondsPerLetter*float64(len(c.Answer))
+		}
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// answerRarity returns how rare an answer is, from 0 (most common) to 1.
+// Answers missing from the lexicon count as rarest.
+func answerRarity(lexicon fill.Lexicon, answer string) float64 {
+	if lexicon == nil {
+		return unknownRarity
+	}
+	if fl, ok := lexicon.(frequencyLexicon); ok {
+		entry, ok := fl.GetEntry(answer)
+		if !ok {
+			return 1
+		}
+		switch {
+		case entry.Frequency >= 1:
+			return 0
+		case entry.Frequency <= 0:
+			return 1
+		default:
+			return 1 - entry.Frequency
+		}
+	}
+	if lexicon.Contains(answer) {
+		return unknownRarity
+	}
+	return 1
+}
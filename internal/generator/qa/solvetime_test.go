@@ -0,0 +1,59 @@
+package qa
+
+import (
+	"testing"
+
+	"lesmotsdatche/internal/domain"
+	"lesmotsdatche/internal/generator/fill"
+)
+
+func TestEstimateSolveTime(t *testing.T) {
+	lexicon := fill.NewMemoryLexicon()
+	lexicon.Add("CHAT", 1.0, nil)
+	lexicon.Add("CHIEN", 0.9, nil)
+	lexicon.Add("XYSTE", 0.05, nil)
+
+	grid := func(size int) [][]domain.Cell {
+		rows := make([][]domain.Cell, size)
+		for i := range rows {
+			rows[i] = make([]domain.Cell, size)
+			for j := range rows[i] {
+				rows[i][j] = domain.Cell{Type: domain.CellTypeLetter}
+			}
+		}
+		return rows
+	}
+
+	small := &domain.Puzzle{
+		Difficulty: 1,
+		Grid:       grid(5),
+		Clues: domain.Clues{
+			Across: []domain.Clue{{Answer: "CHAT", Difficulty: 1}},
+			Down:   []domain.Clue{{Answer: "CHIEN", Difficulty: 1}},
+		},
+	}
+
+	large := &domain.Puzzle{Difficulty: 5, Grid: grid(13)}
+	for i := 0; i < 20; i++ {
+		c := domain.Clue{Number: i + 1, Answer: "XYSTE"} // Difficulty taken from the puzzle
+		if i%2 == 0 {
+			large.Clues.Across = append(large.Clues.Across, c)
+		} else {
+			large.Clues.Down = append(large.Clues.Down, c)
+		}
+	}
+
+	smallTime := EstimateSolveTime(small, lexicon)
+	largeTime := EstimateSolveTime(large, lexicon)
+	if smallTime <= 0 {
+		t.Errorf("expected a positive estimate, got %v", smallTime)
+	}
+	if smallTime >= largeTime {
+		t.Errorf("expected small easy puzzle (%v) to be faster than large hard one (%v)", smallTime, largeTime)
+	}
+
+	// Without a lexicon every answer has the same rarity
+	if got := EstimateSolveTime(small, nil); got <= 0 {
+		t.Errorf("expected a positive estimate without lexicon, got %v", got)
+	}
+}
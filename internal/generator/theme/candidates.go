@@ -2,8 +2,10 @@ package theme
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"lesmotsdatche/internal/generator/fill"
 	"lesmotsdatche/internal/generator/languagepack"
@@ -22,8 +24,29 @@ type SlotCandidate struct {
 type CandidateGeneratorConfig struct {
 	MinCandidatesPerLength int     // Minimum candidates per word length
 	MaxCandidatesPerLength int     // Maximum candidates per word length
-	ThematicBoost          float64 // Score boost for thematic words
+	ThematicBoost          float64 // Score boost for thematic words, flagged by the LLM or sharing a stem with the theme
 	Temperature            float64
+	// RetryTemperatures sets the temperature of each successive request for a
+	// length group (first request included), retrying while the group yields
+	// fewer than MinCandidatesPerLength words per length. Empty means a single
+	// request at Temperature.
+	RetryTemperatures []float64
+	// AllowAbbreviations keeps abbreviations and acronyms (SNCF, ONG); when
+	// false they are dropped and the prompt asks the LLM to avoid them.
+	AllowAbbreviations bool
+	// VowelWeight sets how strongly the prompt asks for vowel-rich words,
+	// matching fill.BuilderConfig.VowelWeight: 1 or more insists, from 0.5
+	// suggests, below 0.5 says nothing (0 = 1.0).
+	VowelWeight float64
+	// MaxConcurrentGroups is how many length groups are requested in
+	// parallel; the first failure cancels the others (0 or 1 = sequential).
+	MaxConcurrentGroups int
+	// ToolCalls asks for candidates through a call to the submit_candidates
+	// tool, on providers with tool calling, instead of JSON in the reply text.
+	ToolCalls bool
+	// MinScore drops candidates scoring below it once the thematic boost is
+	// applied (0 = keep all). Seed words are always kept.
+	MinScore float64
 }
 
 // DefaultCandidateConfig returns default configuration.
@@ -33,6 +56,9 @@ func DefaultCandidateConfig() CandidateGeneratorConfig {
 		MaxCandidatesPerLength: 50, // Balance between coverage and speed
 		ThematicBoost:          0.3,
 		Temperature:            0.6,
+		AllowAbbreviations:     true,
+		VowelWeight:            1.0,
+		MaxConcurrentGroups:    1,
 	}
 }
 
@@ -58,59 +84,164 @@ func (g *CandidateGenerator) GenerateCandidates(ctx context.Context, theme *Them
 
 	// Add seed words from theme first
 	for _, word := range theme.SeedWords {
+		if !g.config.AllowAbbreviations && g.langPack.IsAbbreviation(word) {
+			continue
+		}
 		lexicon.Add(word, 1.0+g.config.ThematicBoost, []string{"thematic"})
 	}
 
 	// Group lengths for batch requests
-	lengthGroups := groupLengths(lengths)
+	lengthGroups := GroupLengths(lengths)
+	themeWords := themeStems(theme, g.langPack)
 
-	for _, group := range lengthGroups {
-		candidates, err := g.generateForLengths(ctx, theme, group)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate candidates for lengths %v: %w", group, err)
-		}
+	concurrency := g.config.MaxConcurrentGroups
+	if concurrency < 1 {
+		concurrency = 1
+	}
 
-		for _, candidate := range candidates {
-			normalized := g.langPack.Normalize(candidate.Word)
-			if normalized == "" || g.langPack.IsTaboo(normalized) {
-				continue
-			}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-			// Only add words with correct lengths
-			wordLen := len(normalized)
-			isValidLength := false
-			for _, l := range group {
-				if wordLen == l {
-					isValidLength = true
-					break
+	var (
+		mu       sync.Mutex // Guards lexicon and firstErr
+		firstErr error
+		wg       sync.WaitGroup
+		stopped  bool // Groups were skipped because ctx ended
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, group := range lengthGroups {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			stopped = true
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := g.generateGroup(ctx, theme, group, lexicon, &mu, themeWords); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
 				}
+				mu.Unlock()
 			}
-			if !isValidLength {
-				continue
-			}
+		}()
+	}
+	wg.Wait()
 
-			score := candidate.Score
-			if candidate.IsThematic {
-				score += g.config.ThematicBoost
-			}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if stopped {
+		return nil, ctx.Err()
+	}
+	return lexicon, nil
+}
 
-			tags := []string{}
-			if candidate.IsThematic {
-				tags = append(tags, "thematic")
-			}
-			if candidate.Difficulty > 0 {
-				tags = append(tags, fmt.Sprintf("diff:%d", candidate.Difficulty))
+// generateGroup requests candidates for one length group, retrying at the
+// configured temperatures while the group is short of candidates, and adds
+// them to the lexicon while holding mu.
+func (g *CandidateGenerator) generateGroup(ctx context.Context, theme *Theme, group []int, lexicon *fill.MemoryLexicon, mu *sync.Mutex, themeWords []string) error {
+	attempts := len(g.config.RetryTemperatures)
+	if attempts == 0 {
+		attempts = 1
+	}
+
+	added := make(map[int]int, len(group)) // New words by length
+	for attempt := 0; attempt < attempts; attempt++ {
+		temperature := g.config.Temperature
+		if attempt < len(g.config.RetryTemperatures) {
+			temperature = g.config.RetryTemperatures[attempt]
+		}
+
+		candidates, err := g.generateForLengths(ctx, theme, group, temperature)
+		if err != nil {
+			if attempt == 0 {
+				return fmt.Errorf("failed to generate candidates for lengths %v: %w", group, err)
 			}
+			break // Keep what earlier attempts produced
+		}
 
-			lexicon.Add(normalized, score, tags)
+		mu.Lock()
+		for length, n := range g.addCandidates(lexicon, candidates, group, themeWords) {
+			added[length] += n
+		}
+		mu.Unlock()
+		if !shortLength(added, group, g.config.MinCandidatesPerLength) {
+			break
 		}
 	}
+	return nil
+}
 
-	return lexicon, nil
+// shortLength reports whether any length of the group has fewer than minWords
+// new words, so a plentiful length cannot hide an empty one.
+func shortLength(added map[int]int, group []int, minWords int) bool {
+	for _, length := range group {
+		if added[length] < minWords {
+			return true
+		}
+	}
+	return false
+}
+
+// addCandidates adds valid candidates of the group's lengths to the lexicon
+// and returns how many new words were added for each length. Candidates
+// related to a theme word are boosted even when the LLM did not flag them as
+// thematic.
+func (g *CandidateGenerator) addCandidates(lexicon *fill.MemoryLexicon, candidates []SlotCandidate, group []int, themeWords []string) map[int]int {
+	added := make(map[int]int, len(group))
+	for _, candidate := range candidates {
+		normalized := g.langPack.Normalize(candidate.Word)
+		if normalized == "" || g.langPack.IsTaboo(normalized) {
+			continue
+		}
+		if !g.config.AllowAbbreviations && g.langPack.IsAbbreviation(normalized) {
+			continue
+		}
+
+		// Only add words with correct lengths
+		wordLen := len(normalized)
+		isValidLength := false
+		for _, l := range group {
+			if wordLen == l {
+				isValidLength = true
+				break
+			}
+		}
+		if !isValidLength {
+			continue
+		}
+
+		score := candidate.Score
+		if candidate.IsThematic || relatedToTheme(normalized, themeWords) {
+			score += g.config.ThematicBoost
+		}
+		if score < g.config.MinScore {
+			continue
+		}
+
+		tags := []string{}
+		if candidate.IsThematic {
+			tags = append(tags, "thematic")
+		}
+		if candidate.Difficulty > 0 {
+			tags = append(tags, fmt.Sprintf("diff:%d", candidate.Difficulty))
+		}
+
+		if !lexicon.Contains(normalized) {
+			added[wordLen]++
+		}
+		lexicon.Add(normalized, score, tags)
+	}
+	return added
 }
 
 // generateForLengths generates candidates for a group of word lengths.
-func (g *CandidateGenerator) generateForLengths(ctx context.Context, theme *Theme, lengths []int) ([]SlotCandidate, error) {
+func (g *CandidateGenerator) generateForLengths(ctx context.Context, theme *Theme, lengths []int, temperature float64) ([]SlotCandidate, error) {
 	prompts := g.langPack.Prompts()
 
 	systemPrompt := prompts.SlotCandidates
@@ -118,14 +249,21 @@ func (g *CandidateGenerator) generateForLengths(ctx context.Context, theme *Them
 		systemPrompt = defaultCandidateSystemPrompt(g.langPack.Code())
 	}
 
-	userPrompt := buildCandidatePrompt(theme, lengths, g.config.MaxCandidatesPerLength, g.langPack.Code())
+	userPrompt := buildCandidatePrompt(theme, lengths, g.config.MaxCandidatesPerLength, g.config.VowelWeight, g.langPack.Code())
+	if !g.config.AllowAbbreviations {
+		userPrompt += noAbbreviationsRule(g.langPack.Code())
+	}
 
 	req := llm.Request{
 		SystemPrompt: systemPrompt,
 		Prompt:       userPrompt,
-		Temperature:  g.config.Temperature,
+		Temperature:  temperature,
 		MaxTokens:    4096, // More tokens for 100 candidates per length
 	}
+	if g.config.ToolCalls {
+		req.ToolName = candidateToolName
+		req.ToolSchema = json.RawMessage(candidateToolSchema)
+	}
 
 	var result candidateResponse
 	if err := g.client.CompleteWithValidation(ctx, req, &result); err != nil {
@@ -140,6 +278,32 @@ type candidateResponse struct {
 	Candidates []SlotCandidate `json:"candidates"`
 }
 
+// candidateToolName is the tool candidates are submitted through when
+// CandidateGeneratorConfig.ToolCalls is set.
+const candidateToolName = "submit_candidates"
+
+// candidateToolSchema is the JSON schema of the candidateToolName arguments,
+// matching candidateResponse.
+const candidateToolSchema = `{
+	"type": "object",
+	"properties": {
+		"candidates": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"word": {"type": "string"},
+					"score": {"type": "number"},
+					"difficulty": {"type": "integer", "minimum": 1, "maximum": 5},
+					"is_thematic": {"type": "boolean"}
+				},
+				"required": ["word", "score", "difficulty", "is_thematic"]
+			}
+		}
+	},
+	"required": ["candidates"]
+}`
+
 func defaultCandidateSystemPrompt(langCode string) string {
 	if langCode == "fr" {
 		return `Tu génères des mots pour mots croisés français.
@@ -172,7 +336,7 @@ STRICT word rules:
 score: 0.0-1.0 (relevance), difficulty: 1-5, is_thematic: true/false`
 }
 
-func buildCandidatePrompt(theme *Theme, lengths []int, maxPerLength int, langCode string) string {
+func buildCandidatePrompt(theme *Theme, lengths []int, maxPerLength int, vowelWeight float64, langCode string) string {
 	var sb strings.Builder
 
 	if langCode == "fr" {
@@ -182,7 +346,7 @@ func buildCandidatePrompt(theme *Theme, lengths []int, maxPerLength int, langCod
 		sb.WriteString("RÈGLES CRITIQUES:\n")
 		sb.WriteString("- CHAQUE mot doit avoir EXACTEMENT le nombre de lettres demandé\n")
 		sb.WriteString("- MAJUSCULES, SANS accents, SANS espaces\n")
-		sb.WriteString("- PRIORITÉ aux mots avec VOYELLES (A,E,I,O,U) - ils se croisent mieux\n")
+		sb.WriteString(vowelRule(vowelWeight, langCode))
 		sb.WriteString("- Mix de mots thématiques ET mots communs très courants\n")
 		sb.WriteString("- Inclure: noms, verbes, adjectifs, mots du quotidien\n")
 		sb.WriteString("- Exemples de bons mots: ARBRE, SOLEIL, MAISON, ROUTE, AVION, ETOILE")
@@ -193,7 +357,7 @@ func buildCandidatePrompt(theme *Theme, lengths []int, maxPerLength int, langCod
 		sb.WriteString("CRITICAL RULES:\n")
 		sb.WriteString("- Each word must have EXACTLY the requested letter count\n")
 		sb.WriteString("- UPPERCASE, NO accents, NO spaces\n")
-		sb.WriteString("- PRIORITIZE words with VOWELS (A,E,I,O,U) - they cross better\n")
+		sb.WriteString(vowelRule(vowelWeight, langCode))
 		sb.WriteString("- Mix of thematic AND common everyday words\n")
 		sb.WriteString("- Include: nouns, verbs, adjectives, everyday words")
 	}
@@ -201,8 +365,39 @@ func buildCandidatePrompt(theme *Theme, lengths []int, maxPerLength int, langCod
 	return sb.String()
 }
 
-// groupLengths groups word lengths for batch processing.
-func groupLengths(lengths []int) [][]int {
+// vowelRule returns the prompt line asking for vowel-rich words, softened or
+// dropped as the vowel weight decreases.
+func vowelRule(vowelWeight float64, langCode string) string {
+	if vowelWeight <= 0 {
+		vowelWeight = 1.0
+	}
+	switch {
+	case vowelWeight >= 1:
+		if langCode == "fr" {
+			return "- PRIORITÉ aux mots avec VOYELLES (A,E,I,O,U) - ils se croisent mieux\n"
+		}
+		return "- PRIORITIZE words with VOWELS (A,E,I,O,U) - they cross better\n"
+	case vowelWeight >= 0.5:
+		if langCode == "fr" {
+			return "- Préférer les mots avec des voyelles, sans sacrifier le thème\n"
+		}
+		return "- Prefer words with vowels, without sacrificing the theme\n"
+	default:
+		return ""
+	}
+}
+
+// noAbbreviationsRule returns the prompt line forbidding abbreviations.
+func noAbbreviationsRule(langCode string) string {
+	if langCode == "fr" {
+		return "\n- AUCUNE abréviation ni sigle (pas de SNCF, ONG, TVA)"
+	}
+	return "\n- NO abbreviations or acronyms (no FBI, NATO, ASAP)"
+}
+
+// GroupLengths groups word lengths for batch processing: distinct lengths of
+// at least 2, sorted, three per group. Each group is one candidate request.
+func GroupLengths(lengths []int) [][]int {
 	// Deduplicate and sort
 	seen := make(map[int]bool)
 	unique := []int{}
@@ -2,7 +2,12 @@ package theme
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"lesmotsdatche/internal/generator/fill"
 	"lesmotsdatche/internal/generator/languagepack"
@@ -97,6 +102,43 @@ func TestCandidateGenerator_ThematicBoost(t *testing.T) {
 	}
 }
 
+func TestCandidateGenerator_KeywordBoost(t *testing.T) {
+	// MARINIER shares a stem with the keyword MARIN but is not flagged
+	mockResponse := `{
+		"candidates": [
+			{"word": "MARINIER", "score": 0.5, "difficulty": 2, "is_thematic": false},
+			{"word": "FROMAGES", "score": 0.5, "difficulty": 2, "is_thematic": false}
+		]
+	}`
+
+	mock := llm.NewMockClient(mockResponse)
+	config := DefaultCandidateConfig()
+	config.ThematicBoost = 0.3
+	gen := NewCandidateGenerator(llm.NewValidatingClient(mock, llm.DefaultConfig()), languagepack.NewFrenchPack(), config)
+
+	theme := &Theme{Title: "La mer", Keywords: []string{"MARIN", "OCEAN"}}
+	lexicon, err := gen.GenerateCandidates(context.Background(), theme, []int{8})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	related, ok := lexicon.GetEntry("MARINIER")
+	if !ok {
+		t.Fatal("expected MARINIER in lexicon")
+	}
+	unrelated, ok := lexicon.GetEntry("FROMAGES")
+	if !ok {
+		t.Fatal("expected FROMAGES in lexicon")
+	}
+	if related.Frequency <= unrelated.Frequency {
+		t.Errorf("keyword-related word should be boosted: related=%f, unrelated=%f",
+			related.Frequency, unrelated.Frequency)
+	}
+	if related.HasTag("thematic") {
+		t.Error("keyword boost should not mark the word as LLM-flagged thematic")
+	}
+}
+
 func TestCandidateGenerator_FilterTaboo(t *testing.T) {
 	mockResponse := `{
 		"candidates": [
@@ -127,6 +169,70 @@ func TestCandidateGenerator_FilterTaboo(t *testing.T) {
 	}
 }
 
+func TestCandidateGenerator_FilterAbbreviations(t *testing.T) {
+	mockResponse := `{
+		"candidates": [
+			{"word": "GARE", "score": 0.8, "difficulty": 2, "is_thematic": true},
+			{"word": "SNCF", "score": 0.8, "difficulty": 2, "is_thematic": true}
+		]
+	}`
+
+	mock := llm.NewMockClient(mockResponse)
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+
+	config := DefaultCandidateConfig()
+	config.AllowAbbreviations = false
+	gen := NewCandidateGenerator(validatingClient, languagepack.NewFrenchPack(), config)
+
+	theme := &Theme{Title: "Trains", Keywords: []string{"TRAIN"}}
+	lexicon, err := gen.GenerateCandidates(context.Background(), theme, []int{4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lexicon.Contains("SNCF") {
+		t.Error("abbreviation SNCF should have been filtered")
+	}
+	if !lexicon.Contains("GARE") {
+		t.Error("expected GARE in lexicon")
+	}
+	if !strings.Contains(mock.Calls[0].Prompt, "abréviation") {
+		t.Error("prompt should ask the LLM to avoid abbreviations")
+	}
+}
+
+func TestCandidateGenerator_MinScore(t *testing.T) {
+	mockResponse := `{
+		"candidates": [
+			{"word": "BOUEE", "score": 0.1, "difficulty": 2, "is_thematic": false},
+			{"word": "SABLE", "score": 0.9, "difficulty": 2, "is_thematic": false}
+		]
+	}`
+
+	mock := llm.NewMockClient(mockResponse)
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+
+	config := DefaultCandidateConfig()
+	config.MinScore = 0.5
+	gen := NewCandidateGenerator(validatingClient, languagepack.NewFrenchPack(), config)
+
+	theme := &Theme{Title: "La plage", Keywords: []string{"PLAGE"}, SeedWords: []string{"VAGUE"}}
+	lexicon, err := gen.GenerateCandidates(context.Background(), theme, []int{5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lexicon.Contains("BOUEE") {
+		t.Error("BOUEE scores below MinScore and should have been dropped")
+	}
+	if !lexicon.Contains("SABLE") {
+		t.Error("expected SABLE in lexicon")
+	}
+	if !lexicon.Contains("VAGUE") {
+		t.Error("seed word VAGUE should be kept regardless of MinScore")
+	}
+}
+
 func TestGroupLengths(t *testing.T) {
 	tests := []struct {
 		input    []int
@@ -151,14 +257,14 @@ func TestGroupLengths(t *testing.T) {
 	}
 
 	for _, tc := range tests {
-		result := groupLengths(tc.input)
+		result := GroupLengths(tc.input)
 		if len(result) != len(tc.expected) {
-			t.Errorf("groupLengths(%v) = %v, want %v", tc.input, result, tc.expected)
+			t.Errorf("GroupLengths(%v) = %v, want %v", tc.input, result, tc.expected)
 			continue
 		}
 		for i := range result {
 			if len(result[i]) != len(tc.expected[i]) {
-				t.Errorf("groupLengths(%v)[%d] = %v, want %v", tc.input, i, result[i], tc.expected[i])
+				t.Errorf("GroupLengths(%v)[%d] = %v, want %v", tc.input, i, result[i], tc.expected[i])
 			}
 		}
 	}
@@ -216,7 +322,7 @@ func TestBuildCandidatePrompt(t *testing.T) {
 		Keywords:    []string{"OCEAN", "MER"},
 	}
 
-	prompt := buildCandidatePrompt(theme, []int{3, 4, 5}, 20, "fr")
+	prompt := buildCandidatePrompt(theme, []int{3, 4, 5}, 20, 1.0, "fr")
 
 	if prompt == "" {
 		t.Error("prompt should not be empty")
@@ -230,3 +336,258 @@ func TestBuildCandidatePrompt(t *testing.T) {
 		t.Error("prompt should contain length requirements")
 	}
 }
+
+func TestBuildCandidatePrompt_VowelWeight(t *testing.T) {
+	theme := &Theme{Title: "Le sport"}
+
+	strong := buildCandidatePrompt(theme, []int{5}, 20, 1.0, "fr")
+	if !containsSubstring(strong, "PRIORITÉ aux mots avec VOYELLES") {
+		t.Error("default vowel weight should insist on vowels")
+	}
+	mild := buildCandidatePrompt(theme, []int{5}, 20, 0.5, "fr")
+	if containsSubstring(mild, "PRIORITÉ aux mots avec VOYELLES") || !containsSubstring(mild, "voyelles") {
+		t.Error("medium vowel weight should only suggest vowels")
+	}
+	if none := buildCandidatePrompt(theme, []int{5}, 20, 0.2, "fr"); containsSubstring(none, "oyelles") {
+		t.Error("low vowel weight should not mention vowels")
+	}
+}
+
+func TestCandidateGenerator_RetryTemperatures(t *testing.T) {
+	sparse := `{"candidates": [{"word": "OCEAN", "score": 0.9, "difficulty": 2, "is_thematic": true}]}`
+	enough := `{
+		"candidates": [
+			{"word": "VAGUE", "score": 0.8, "difficulty": 2, "is_thematic": true},
+			{"word": "SABLE", "score": 0.7, "difficulty": 1, "is_thematic": true},
+			{"word": "PLAGE", "score": 0.7, "difficulty": 1, "is_thematic": true}
+		]
+	}`
+
+	mock := llm.NewMockClient(sparse, sparse, enough)
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+	langPack := languagepack.NewFrenchPack()
+
+	config := DefaultCandidateConfig()
+	config.MinCandidatesPerLength = 3
+	config.RetryTemperatures = []float64{0.6, 0.9, 1.1}
+	gen := NewCandidateGenerator(validatingClient, langPack, config)
+
+	lexicon, err := gen.GenerateCandidates(context.Background(), &Theme{Title: "La Mer"}, []int{5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mock.CallCount() != 3 {
+		t.Fatalf("expected 3 calls, got %d", mock.CallCount())
+	}
+	for i, want := range config.RetryTemperatures {
+		if got := mock.Calls[i].Temperature; got != want {
+			t.Errorf("call %d: expected temperature %.1f, got %.1f", i+1, want, got)
+		}
+	}
+
+	// Words from sparse attempts are kept alongside the final batch
+	for _, word := range []string{"OCEAN", "VAGUE", "SABLE", "PLAGE"} {
+		if !lexicon.Contains(word) {
+			t.Errorf("expected %s in lexicon", word)
+		}
+	}
+}
+
+func TestCandidateGenerator_RetryTemperatures_PerLength(t *testing.T) {
+	// Plenty of 5-letter words but no 4-letter one: the group total would
+	// meet the minimum, yet length 4 is still short
+	fives := `{"candidates": [
+		{"word": "VAGUE", "score": 0.8}, {"word": "SABLE", "score": 0.7},
+		{"word": "PLAGE", "score": 0.7}, {"word": "OCEAN", "score": 0.9}
+	]}`
+	fours := `{"candidates": [{"word": "PORT", "score": 0.8}, {"word": "QUAI", "score": 0.7}]}`
+
+	mock := llm.NewMockClient(fives, fours)
+	config := DefaultCandidateConfig()
+	config.MinCandidatesPerLength = 2
+	config.RetryTemperatures = []float64{0.6, 0.9}
+	gen := NewCandidateGenerator(llm.NewValidatingClient(mock, llm.DefaultConfig()), languagepack.NewFrenchPack(), config)
+
+	lexicon, err := gen.GenerateCandidates(context.Background(), &Theme{Title: "La Mer"}, []int{4, 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.CallCount() != 2 {
+		t.Fatalf("expected a retry for the empty length, got %d calls", mock.CallCount())
+	}
+	if !lexicon.Contains("PORT") {
+		t.Error("expected the retry's 4-letter words in the lexicon")
+	}
+}
+
+func TestCandidateGenerator_ToolCalls(t *testing.T) {
+	response := `{"candidates": [{"word": "OCEAN", "score": 0.9, "difficulty": 2, "is_thematic": true}]}`
+
+	for _, toolCalls := range []bool{false, true} {
+		mock := llm.NewMockClient(response)
+		config := DefaultCandidateConfig()
+		config.MinCandidatesPerLength = 1
+		config.ToolCalls = toolCalls
+		gen := NewCandidateGenerator(llm.NewValidatingClient(mock, llm.DefaultConfig()), languagepack.NewFrenchPack(), config)
+
+		if _, err := gen.GenerateCandidates(context.Background(), &Theme{Title: "La Mer"}, []int{5}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		req := mock.Calls[0]
+		if !toolCalls {
+			if req.ToolName != "" || req.ToolSchema != nil {
+				t.Errorf("expected no tool by default, got %q", req.ToolName)
+			}
+			continue
+		}
+		if req.ToolName != candidateToolName {
+			t.Errorf("expected tool %q, got %q", candidateToolName, req.ToolName)
+		}
+		if !json.Valid(req.ToolSchema) {
+			t.Errorf("expected a valid JSON tool schema, got %s", req.ToolSchema)
+		}
+	}
+}
+
+// concurrencyClient answers every request with the same content after a short
+// delay, recording the requests and the peak number of concurrent calls.
+type concurrencyClient struct {
+	content string
+
+	mu       sync.Mutex
+	prompts  []string
+	inFlight int
+	peak     int
+}
+
+func (c *concurrencyClient) Complete(ctx context.Context, req llm.Request) (*llm.Response, error) {
+	c.mu.Lock()
+	c.prompts = append(c.prompts, req.Prompt)
+	c.inFlight++
+	c.peak = max(c.peak, c.inFlight)
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(20 * time.Millisecond):
+		return &llm.Response{Content: c.content, FinishReason: "stop"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestCandidateGenerator_MaxConcurrentGroups(t *testing.T) {
+	// Lengths 3-11 make three groups: 3-5, 6-8 and 9-11
+	content := `{"candidates": [
+		{"word": "ARC", "score": 0.5}, {"word": "CHAT", "score": 0.5}, {"word": "PLAGE", "score": 0.5},
+		{"word": "MARINE", "score": 0.5}, {"word": "BATEAUX", "score": 0.5}, {"word": "NAVIGUER", "score": 0.5},
+		{"word": "CAPITAINE", "score": 0.5}, {"word": "NAVIGATEUR", "score": 0.5}, {"word": "EMBARCATION", "score": 0.5}
+	]}`
+	lengths := []int{3, 4, 5, 6, 7, 8, 9, 10, 11}
+
+	for _, concurrency := range []int{1, 3} {
+		client := &concurrencyClient{content: content}
+		config := DefaultCandidateConfig()
+		config.MaxConcurrentGroups = concurrency
+		gen := NewCandidateGenerator(llm.NewValidatingClient(client, llm.DefaultConfig()), languagepack.NewFrenchPack(), config)
+
+		lexicon, err := gen.GenerateCandidates(context.Background(), &Theme{Title: "La Mer"}, lengths)
+		if err != nil {
+			t.Fatalf("concurrency %d: unexpected error: %v", concurrency, err)
+		}
+
+		if len(client.prompts) != 3 {
+			t.Errorf("concurrency %d: expected 3 group requests, got %d", concurrency, len(client.prompts))
+		}
+		for _, group := range []string{"[3 4 5]", "[6 7 8]", "[9 10 11]"} {
+			requested := false
+			for _, prompt := range client.prompts {
+				requested = requested || strings.Contains(prompt, group)
+			}
+			if !requested {
+				t.Errorf("concurrency %d: lengths %s were not requested", concurrency, group)
+			}
+		}
+		for _, l := range lengths {
+			if len(lexicon.Match(strings.Repeat(".", l))) == 0 {
+				t.Errorf("concurrency %d: no candidate of length %d in merged lexicon", concurrency, l)
+			}
+		}
+
+		if concurrency == 1 && client.peak != 1 {
+			t.Errorf("expected sequential requests, got %d concurrent", client.peak)
+		}
+		if concurrency > 1 && client.peak < 2 {
+			t.Errorf("expected concurrent requests, peak was %d", client.peak)
+		}
+	}
+}
+
+// failFirstClient fails requests for the first length group once another
+// group is in flight, and holds the others until their context ends,
+// recording the requests it saw and how many were cancelled.
+type failFirstClient struct {
+	inFlight chan struct{}
+	once     sync.Once
+
+	mu        sync.Mutex
+	prompts   []string
+	cancelled int
+}
+
+func (c *failFirstClient) Complete(ctx context.Context, req llm.Request) (*llm.Response, error) {
+	c.mu.Lock()
+	c.prompts = append(c.prompts, req.Prompt)
+	c.mu.Unlock()
+
+	if strings.Contains(req.Prompt, "[3 4 5]") {
+		select {
+		case <-c.inFlight:
+		case <-time.After(time.Second):
+		}
+		return nil, errors.New("provider unavailable")
+	}
+	c.once.Do(func() { close(c.inFlight) })
+	select {
+	case <-ctx.Done():
+		c.mu.Lock()
+		c.cancelled++
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	case <-time.After(5 * time.Second):
+		return &llm.Response{Content: `{"candidates": []}`, FinishReason: "stop"}, nil
+	}
+}
+
+func TestCandidateGenerator_MaxConcurrentGroups_CancelOnError(t *testing.T) {
+	// Three groups, two at a time: the first fails, the second is in flight
+	// and must be cancelled, and the third must never be requested
+	client := &failFirstClient{inFlight: make(chan struct{})}
+	config := DefaultCandidateConfig()
+	config.MaxConcurrentGroups = 2
+	gen := NewCandidateGenerator(llm.NewValidatingClient(client, llm.DefaultConfig()), languagepack.NewFrenchPack(), config)
+
+	start := time.Now()
+	if _, err := gen.GenerateCandidates(context.Background(), &Theme{Title: "La Mer"}, []int{3, 4, 5, 6, 7, 8, 9, 10, 11}); err == nil {
+		t.Error("expected an error when a group fails")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the failure to stop sibling groups, took %v", elapsed)
+	}
+
+	if client.cancelled != 1 {
+		t.Errorf("expected the in-flight sibling group to be cancelled, %d were", client.cancelled)
+	}
+	for _, prompt := range client.prompts {
+		if strings.Contains(prompt, "[9 10 11]") {
+			t.Error("expected no further groups to be requested after the failure")
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package theme
+
+import (
+	"strings"
+
+	"lesmotsdatche/internal/generator/languagepack"
+)
+
+// minStemLength is the shortest shared prefix treated as a common stem.
+const minStemLength = 4
+
+// CoherenceScore estimates the fraction of answers related to the theme.
+// An answer counts as related when it shares a stem with, or contains, one of
+// the theme's keywords, seed words or title words. Returns 0-1 (0 if no answers).
+func CoherenceScore(thm *Theme, answers []string, langPack languagepack.LanguagePack) float64 {
+	if thm == nil || len(answers) == 0 {
+		return 0
+	}
+
+	themeWords := themeStems(thm, langPack)
+	related := 0
+	for _, answer := range answers {
+		if relatedToTheme(langPack.Normalize(answer), themeWords) {
+			related++
+		}
+	}
+
+	return float64(related) / float64(len(answers))
+}
+
+// themeStems returns the normalized title words, keywords and seed words of a
+// theme that are long enough to act as stems.
+func themeStems(thm *Theme, langPack languagepack.LanguagePack) []string {
+	var themeWords []string
+	for _, w := range append(append(strings.Fields(thm.Title), thm.Keywords...), thm.SeedWords...) {
+		if n := langPack.Normalize(w); len(n) >= minStemLength {
+			themeWords = append(themeWords, n)
+		}
+	}
+	return themeWords
+}
+
+// relatedToTheme reports whether a normalized word is related to any theme stem.
+func relatedToTheme(word string, themeWords []string) bool {
+	for _, tw := range themeWords {
+		if relatedWords(word, tw) {
+			return true
+		}
+	}
+	return false
+}
+
+// relatedWords reports whether two normalized words share a stem or one contains the other.
+func relatedWords(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) >= minStemLength && len(b) >= minStemLength &&
+		(strings.Contains(a, b) || strings.Contains(b, a)) {
+		return true
+	}
+
+	shorter := len(a)
+	if len(b) < shorter {
+		shorter = len(b)
+	}
+	prefix := 0
+	for prefix < shorter && a[prefix] == b[prefix] {
+		prefix++
+	}
+	// Require a meaningful stem: at least 4 letters and most of the shorter word
+	return prefix >= minStemLength && prefix*4 >= shorter*3
+}
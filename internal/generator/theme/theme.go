@@ -3,13 +3,25 @@ package theme
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"lesmotsdatche/internal/generator/languagepack"
 	"lesmotsdatche/internal/generator/llm"
 )
 
+// ErrTabooTheme is returned when a generated theme title or description
+// contains a taboo word. Generation attempts fail with it, so the
+// orchestrator retries with a new theme.
+var ErrTabooTheme = errors.New("theme contains a taboo word")
+
+// ErrTitleTooLong is returned when a generated theme title exceeds
+// GeneratorConfig.MaxTitleLength.
+var ErrTitleTooLong = errors.New("theme title too long")
+
 // Theme represents a crossword puzzle theme.
 type Theme struct {
 	Title       string   `json:"title"`
@@ -21,17 +33,19 @@ type Theme struct {
 
 // GeneratorConfig holds theme generator configuration.
 type GeneratorConfig struct {
-	MinKeywords  int
-	MinSeedWords int
-	Temperature  float64
+	MinKeywords    int
+	MinSeedWords   int
+	Temperature    float64
+	MaxTitleLength int // Longest accepted title, in characters (0 = unlimited)
 }
 
 // DefaultGeneratorConfig returns default configuration.
 func DefaultGeneratorConfig() GeneratorConfig {
 	return GeneratorConfig{
-		MinKeywords:  3,
-		MinSeedWords: 5,
-		Temperature:  0.8,
+		MinKeywords:    3,
+		MinSeedWords:   5,
+		Temperature:    0.8,
+		MaxTitleLength: 60,
 	}
 }
 
@@ -53,24 +67,8 @@ func NewGenerator(client *llm.ValidatingClient, langPack languagepack.LanguagePa
 
 // GenerateTheme generates a theme for the given date and constraints.
 func (g *Generator) GenerateTheme(ctx context.Context, date string, constraints ThemeConstraints) (*Theme, error) {
-	prompts := g.langPack.Prompts()
-
-	systemPrompt := prompts.ThemeGeneration
-	if systemPrompt == "" {
-		systemPrompt = defaultThemeSystemPrompt(g.langPack.Code())
-	}
-
-	userPrompt := buildThemePrompt(date, constraints, g.langPack.Code())
-
-	req := llm.Request{
-		SystemPrompt: systemPrompt,
-		Prompt:       userPrompt,
-		Temperature:  g.config.Temperature,
-		MaxTokens:    1024,
-	}
-
 	var result themeResponse
-	if err := g.client.CompleteWithValidation(ctx, req, &result); err != nil {
+	if err := g.client.CompleteWithValidation(ctx, g.BuildRequest(date, constraints), &result); err != nil {
 		return nil, fmt.Errorf("theme generation failed: %w", err)
 	}
 
@@ -83,6 +81,10 @@ func (g *Generator) GenerateTheme(ctx context.Context, date string, constraints
 		Difficulty:  result.Difficulty,
 	}
 
+	if err := g.validateText(theme); err != nil {
+		return nil, err
+	}
+
 	// Filter taboo words
 	theme.Keywords = g.filterTaboo(theme.Keywords)
 	theme.SeedWords = g.filterTaboo(theme.SeedWords)
@@ -103,6 +105,28 @@ type ThemeConstraints struct {
 	PreferTopics   []string // Preferred topics
 	Difficulty     int      // Target difficulty (1-5)
 	SeasonalEvents []string // Relevant seasonal events for the date
+
+	// SeedWordMinLength and SeedWordMaxLength ask for seed words in a length
+	// range, such as the lengths the grid builder places best (0 = 3 and 10,
+	// the range of the system prompt; both 0 = no preference).
+	SeedWordMinLength int
+	SeedWordMaxLength int
+}
+
+// seedWordLengths returns the preferred seed-word length range, and false
+// when none is set.
+func (c ThemeConstraints) seedWordLengths() (int, int, bool) {
+	if c.SeedWordMinLength <= 0 && c.SeedWordMaxLength <= 0 {
+		return 0, 0, false
+	}
+	minLen, maxLen := c.SeedWordMinLength, c.SeedWordMaxLength
+	if minLen <= 0 {
+		minLen = 3
+	}
+	if maxLen <= 0 {
+		maxLen = 10
+	}
+	return minLen, maxLen, true
 }
 
 // themeResponse is the expected JSON response from the LLM.
@@ -114,6 +138,22 @@ type themeResponse struct {
 	Difficulty  int      `json:"difficulty"`
 }
 
+// BuildRequest returns the LLM request GenerateTheme sends for a date and
+// constraints.
+func (g *Generator) BuildRequest(date string, constraints ThemeConstraints) llm.Request {
+	systemPrompt := g.langPack.Prompts().ThemeGeneration
+	if systemPrompt == "" {
+		systemPrompt = defaultThemeSystemPrompt(g.langPack.Code())
+	}
+
+	return llm.Request{
+		SystemPrompt: systemPrompt,
+		Prompt:       buildThemePrompt(date, constraints, g.langPack.Code()),
+		Temperature:  g.config.Temperature,
+		MaxTokens:    1024,
+	}
+}
+
 func (g *Generator) normalizeWords(words []string) []string {
 	normalized := make([]string, 0, len(words))
 	seen := make(map[string]bool)
@@ -128,6 +168,32 @@ func (g *Generator) normalizeWords(words []string) []string {
 	return normalized
 }
 
+// validateText rejects a theme whose title is too long, or whose title or
+// description contains a taboo word.
+func (g *Generator) validateText(theme *Theme) error {
+	if n := utf8.RuneCountInString(strings.TrimSpace(theme.Title)); g.config.MaxTitleLength > 0 && n > g.config.MaxTitleLength {
+		return fmt.Errorf("%w: %d characters, maximum %d", ErrTitleTooLong, n, g.config.MaxTitleLength)
+	}
+	fields := []struct{ name, text string }{{"title", theme.Title}, {"description", theme.Description}}
+	for _, field := range fields {
+		if word := g.tabooWord(field.text); word != "" {
+			return fmt.Errorf("%w: %s contains %q", ErrTabooTheme, field.name, word)
+		}
+	}
+	return nil
+}
+
+// tabooWord returns the first taboo word of text, or "" if there is none.
+func (g *Generator) tabooWord(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, word := range words {
+		if g.langPack.IsTaboo(word) {
+			return word
+		}
+	}
+	return ""
+}
+
 func (g *Generator) filterTaboo(words []string) []string {
 	filtered := make([]string, 0, len(words))
 	for _, word := range words {
@@ -189,6 +255,9 @@ func buildThemePrompt(date string, constraints ThemeConstraints, langCode string
 		if constraints.Difficulty > 0 {
 			sb.WriteString(fmt.Sprintf("Difficulté cible: %d/5\n", constraints.Difficulty))
 		}
+		if minLen, maxLen, ok := constraints.seedWordLengths(); ok {
+			sb.WriteString(fmt.Sprintf("Privilégier des seed_words de %d à %d lettres\n", minLen, maxLen))
+		}
 		sb.WriteString("\nFournis au moins 5 keywords et 8 seed_words liés au thème.")
 	} else {
 		sb.WriteString(fmt.Sprintf("Generate a crossword puzzle theme for %s.\n", date))
@@ -205,6 +274,9 @@ func buildThemePrompt(date string, constraints ThemeConstraints, langCode string
 		if constraints.Difficulty > 0 {
 			sb.WriteString(fmt.Sprintf("Target difficulty: %d/5\n", constraints.Difficulty))
 		}
+		if minLen, maxLen, ok := constraints.seedWordLengths(); ok {
+			sb.WriteString(fmt.Sprintf("Prefer %d-%d letter seed words\n", minLen, maxLen))
+		}
 		sb.WriteString("\nProvide at least 5 keywords and 8 seed_words related to the theme.")
 	}
 
@@ -2,6 +2,8 @@ package theme
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"lesmotsdatche/internal/generator/languagepack"
@@ -123,6 +125,54 @@ func TestGenerator_FilterTaboo(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateTheme_RejectsTitle(t *testing.T) {
+	themeJSON := func(title, description string) string {
+		return `{
+			"title": "` + title + `",
+			"description": "` + description + `",
+			"keywords": ["océan", "vagues", "plage"],
+			"seed_words": ["OCEAN", "VAGUE", "PLAGE", "SABLE", "POISSON", "BATEAU"],
+			"difficulty": 3
+		}`
+	}
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		want        error
+	}{
+		{"taboo title", "Quel bordel !", "Un thème sur le désordre", ErrTabooTheme},
+		{"taboo word before punctuation", "Nazi, jamais", "Un thème historique", ErrTabooTheme},
+		{"taboo description", "La Mer", "Une mer de merde", ErrTabooTheme},
+		{"long title", strings.Repeat("Océan ", 12), "Un thème marin", ErrTitleTooLong},
+		{"valid", "La Mer", "Un thème sur l'océan", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := llm.NewMockClient(themeJSON(tt.title, tt.description))
+			gen := NewGenerator(llm.NewValidatingClient(mock, llm.DefaultConfig()), languagepack.NewFrenchPack(), DefaultGeneratorConfig())
+
+			theme, err := gen.GenerateTheme(context.Background(), "2026-01-15", ThemeConstraints{})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if tt.want != nil && theme != nil {
+				t.Errorf("expected the theme to be rejected, got %+v", theme)
+			}
+		})
+	}
+
+	config := DefaultGeneratorConfig()
+	config.MaxTitleLength = 0
+	mock := llm.NewMockClient(themeJSON(strings.Repeat("Océan ", 12), "Un thème marin"))
+	gen := NewGenerator(llm.NewValidatingClient(mock, llm.DefaultConfig()), languagepack.NewFrenchPack(), config)
+	if _, err := gen.GenerateTheme(context.Background(), "2026-01-15", ThemeConstraints{}); err != nil {
+		t.Errorf("expected no title limit with MaxTitleLength 0, got %v", err)
+	}
+}
+
 func TestGenerator_InsufficientKeywords(t *testing.T) {
 	mockResponse := `{
 		"title": "Test",
@@ -163,6 +213,38 @@ func TestDefaultThemeSystemPrompt(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateTheme_SeedWordLengths(t *testing.T) {
+	mockResponse := `{
+		"title": "The Sea",
+		"description": "Waves and shores",
+		"keywords": ["ocean", "waves", "beach", "sand", "tide"],
+		"seed_words": ["OCEAN", "WAVES", "BEACH", "SHORE", "ANCHOR", "SAILOR", "HARBOR", "DOLPHIN"],
+		"difficulty": 3
+	}`
+
+	mock := llm.NewMockClient(mockResponse)
+	validatingClient := llm.NewValidatingClient(mock, llm.DefaultConfig())
+	gen := NewGenerator(validatingClient, languagepack.NewEnglishPack(), DefaultGeneratorConfig())
+
+	_, err := gen.GenerateTheme(context.Background(), "2026-01-15", ThemeConstraints{
+		SeedWordMinLength: 5,
+		SeedWordMaxLength: 7,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(mock.Calls[0].Prompt, "Prefer 5-7 letter seed words") {
+		t.Errorf("expected seed word lengths in prompt, got %q", mock.Calls[0].Prompt)
+	}
+	if prompt := buildThemePrompt("2026-01-15", ThemeConstraints{SeedWordMinLength: 5}, "fr"); !strings.Contains(prompt, "de 5 à 10 lettres") {
+		t.Errorf("expected open upper bound to default to 10, got %q", prompt)
+	}
+	if prompt := buildThemePrompt("2026-01-15", ThemeConstraints{}, "en"); strings.Contains(prompt, "letter seed words") {
+		t.Errorf("expected no length preference without constraint, got %q", prompt)
+	}
+}
+
 func TestBuildThemePrompt(t *testing.T) {
 	constraints := ThemeConstraints{
 		AvoidThemes:    []string{"old theme"},
@@ -197,3 +279,26 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCoherenceScore(t *testing.T) {
+	langPack := languagepack.NewFrenchPack()
+	thm := &Theme{
+		Title:     "La mer",
+		Keywords:  []string{"OCEAN", "PLAGE", "VAGUE"},
+		SeedWords: []string{"MARIN", "BATEAU", "POISSON", "CORAIL"},
+	}
+
+	onTheme := CoherenceScore(thm, []string{"OCEANS", "PLAGES", "marins", "BATEAUX", "POISSON"}, langPack)
+	if onTheme < 0.8 {
+		t.Errorf("expected high coherence for on-theme answers, got %.2f", onTheme)
+	}
+
+	offTheme := CoherenceScore(thm, []string{"TRAIN", "ROUTE", "MONTAGNE", "NEIGE", "LIVRE"}, langPack)
+	if offTheme > 0.2 {
+		t.Errorf("expected low coherence for off-theme answers, got %.2f", offTheme)
+	}
+
+	if CoherenceScore(thm, nil, langPack) != 0 {
+		t.Error("expected 0 coherence with no answers")
+	}
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -10,8 +11,9 @@ import (
 
 // MemoryStore is an in-memory store implementation for testing.
 type MemoryStore struct {
-	puzzles *MemoryPuzzleRepository
-	drafts  *MemoryDraftRepository
+	puzzles     *MemoryPuzzleRepository
+	drafts      *MemoryDraftRepository
+	idempotency *MemoryIdempotencyRepository
 }
 
 // NewMemoryStore creates a new in-memory store.
@@ -23,13 +25,17 @@ func NewMemoryStore() *MemoryStore {
 		drafts: &MemoryDraftRepository{
 			drafts: make(map[string]*Draft),
 		},
+		idempotency: &MemoryIdempotencyRepository{
+			records: make(map[string]*IdempotencyRecord),
+		},
 	}
 }
 
-func (s *MemoryStore) Puzzles() PuzzleRepository { return s.puzzles }
-func (s *MemoryStore) Drafts() DraftRepository   { return s.drafts }
-func (s *MemoryStore) Migrate(ctx context.Context) error { return nil }
-func (s *MemoryStore) Close() error { return nil }
+func (s *MemoryStore) Puzzles() PuzzleRepository          { return s.puzzles }
+func (s *MemoryStore) Drafts() DraftRepository            { return s.drafts }
+func (s *MemoryStore) Idempotency() IdempotencyRepository { return s.idempotency }
+func (s *MemoryStore) Migrate(ctx context.Context) error  { return nil }
+func (s *MemoryStore) Close() error                       { return nil }
 
 // MemoryPuzzleRepository is an in-memory puzzle repository.
 type MemoryPuzzleRepository struct {
@@ -41,6 +47,38 @@ func (r *MemoryPuzzleRepository) Store(ctx context.Context, p *domain.Puzzle) er
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.storeLocked(p)
+}
+
+func (r *MemoryPuzzleRepository) StoreBatch(ctx context.Context, puzzles []*domain.Puzzle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Restore the previous contents if any puzzle fails
+	snapshot := make(map[string]*domain.Puzzle, len(r.puzzles))
+	for id, p := range r.puzzles {
+		snapshot[id] = p
+	}
+	for _, p := range puzzles {
+		if err := r.storeLocked(p); err != nil {
+			r.puzzles = snapshot
+			return err
+		}
+	}
+	return nil
+}
+
+// storeLocked saves a puzzle; the caller must hold the write lock.
+func (r *MemoryPuzzleRepository) storeLocked(p *domain.Puzzle) error {
+	// Mirror the SQLite UNIQUE(language, date) constraint for dated puzzles
+	if p.Date != "" {
+		for id, existing := range r.puzzles {
+			if id != p.ID && existing.Language == p.Language && existing.Date == p.Date {
+				return ErrDuplicateDate
+			}
+		}
+	}
+
 	// Clone to prevent mutation
 	clone := *p
 	if clone.CreatedAt.IsZero() {
@@ -77,6 +115,46 @@ func (r *MemoryPuzzleRepository) GetByDate(ctx context.Context, language, date s
 	return nil, ErrNotFound
 }
 
+func (r *MemoryPuzzleRepository) Latest(ctx context.Context, language string) (*domain.Puzzle, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *domain.Puzzle
+	for _, p := range r.puzzles {
+		if p.Language != language || p.Status != domain.StatusPublished {
+			continue
+		}
+		if latest == nil || p.Date > latest.Date {
+			latest = p
+		}
+	}
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+	clone := *latest
+	return &clone, nil
+}
+
+func (r *MemoryPuzzleRepository) PublishedDates(ctx context.Context, language, fromDate, toDate string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var dates []string
+	for _, p := range r.puzzles {
+		if p.Language != language || p.Status != domain.StatusPublished || seen[p.Date] {
+			continue
+		}
+		if (fromDate != "" && p.Date < fromDate) || (toDate != "" && p.Date > toDate) {
+			continue
+		}
+		seen[p.Date] = true
+		dates = append(dates, p.Date)
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
 func (r *MemoryPuzzleRepository) List(ctx context.Context, filter PuzzleFilter) ([]*PuzzleSummary, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -109,10 +187,24 @@ func (r *MemoryPuzzleRepository) List(ctx context.Context, filter PuzzleFilter)
 			Difficulty: p.Difficulty,
 			Status:     p.Status,
 		})
+	}
 
-		if filter.Limit > 0 && len(result) >= filter.Limit {
-			break
+	// Match the SQLite ORDER BY date DESC, id so Offset pages are stable
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Date != result[j].Date {
+			return result[i].Date > result[j].Date
 		}
+		return result[i].ID < result[j].ID
+	})
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(result) {
+			return nil, nil
+		}
+		result = result[filter.Offset:]
+	}
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
 	}
 
 	return result, nil
@@ -146,6 +238,38 @@ func (r *MemoryPuzzleRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *MemoryPuzzleRepository) Stats(ctx context.Context) (*PuzzleStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &PuzzleStats{
+		ByStatus:     make(map[string]int),
+		ByDifficulty: make(map[int]int),
+		ByLanguage:   make(map[string]int),
+	}
+	for _, p := range r.puzzles {
+		stats.Total++
+		stats.ByStatus[string(p.Status)]++
+		stats.ByDifficulty[p.Difficulty]++
+		stats.ByLanguage[p.Language]++
+	}
+	return stats, nil
+}
+
+func (r *MemoryPuzzleRepository) RecentAnswers(ctx context.Context, language, fromDate, toDate string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var answers []string
+	for _, p := range r.puzzles {
+		if p.Language != language || p.Date < fromDate || p.Date > toDate {
+			continue
+		}
+		answers = append(answers, puzzleAnswers(p)...)
+	}
+	return answers, nil
+}
+
 // MemoryDraftRepository is an in-memory draft repository.
 type MemoryDraftRepository struct {
 	mu     sync.RWMutex
@@ -220,3 +344,58 @@ func (r *MemoryDraftRepository) Delete(ctx context.Context, id string) error {
 	delete(r.drafts, id)
 	return nil
 }
+
+func (r *MemoryDraftRepository) Stats(ctx context.Context) (*DraftStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &DraftStats{ByStatus: make(map[string]int)}
+	total, reports := 0.0, 0
+	for _, d := range r.drafts {
+		stats.Total++
+		stats.ByStatus[d.Status]++
+		if d.Report != nil {
+			total += float64(d.Report.FillScore+d.Report.ClueScore+d.Report.FreshnessScore) / 3.0
+			reports++
+		}
+	}
+	if reports > 0 {
+		stats.AverageQAScore = total / float64(reports)
+	}
+	return stats, nil
+}
+
+// MemoryIdempotencyRepository is an in-memory idempotency key repository.
+type MemoryIdempotencyRepository struct {
+	mu      sync.RWMutex
+	records map[string]*IdempotencyRecord
+}
+
+func (r *MemoryIdempotencyRepository) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rec, ok := r.records[key]
+	if !ok || !time.Now().Before(rec.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+
+	clone := *rec
+	return &clone, nil
+}
+
+func (r *MemoryIdempotencyRepository) Store(ctx context.Context, rec *IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for key, existing := range r.records {
+		if !now.Before(existing.ExpiresAt) {
+			delete(r.records, key)
+		}
+	}
+
+	clone := *rec
+	r.records[rec.Key] = &clone
+	return nil
+}
@@ -0,0 +1,26 @@
+-- Allow the 'scheduled' puzzle status.
+-- SQLite cannot alter CHECK constraints, so the puzzles table is rebuilt.
+
+CREATE TABLE puzzles_new (
+    id TEXT PRIMARY KEY,
+    date TEXT NOT NULL,
+    language TEXT NOT NULL CHECK (language IN ('fr', 'en')),
+    title TEXT NOT NULL,
+    author TEXT NOT NULL,
+    difficulty INTEGER NOT NULL CHECK (difficulty BETWEEN 1 AND 5),
+    status TEXT NOT NULL CHECK (status IN ('draft', 'published', 'archived', 'scheduled')),
+    payload JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    UNIQUE(language, date)
+);
+
+INSERT INTO puzzles_new (id, date, language, title, author, difficulty, status, payload, created_at, published_at)
+SELECT id, date, language, title, author, difficulty, status, payload, created_at, published_at FROM puzzles;
+
+DROP TABLE puzzles;
+ALTER TABLE puzzles_new RENAME TO puzzles;
+
+CREATE INDEX IF NOT EXISTS idx_puzzles_language_date ON puzzles(language, date);
+CREATE INDEX IF NOT EXISTS idx_puzzles_status ON puzzles(status);
+CREATE INDEX IF NOT EXISTS idx_puzzles_language_status ON puzzles(language, status);
@@ -0,0 +1,10 @@
+-- Responses replayed for repeated requests with the same Idempotency-Key.
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    response BLOB NOT NULL,
+    expires_at INTEGER NOT NULL -- Unix seconds
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
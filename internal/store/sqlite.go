@@ -7,6 +7,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,11 +25,15 @@ var migrationsFS embed.FS
 // ErrNotFound is returned when a record is not found.
 var ErrNotFound = errors.New("record not found")
 
+// ErrDuplicateDate is returned when a puzzle already exists for the same language and date.
+var ErrDuplicateDate = errors.New("puzzle already exists for this language and date")
+
 // SQLiteStore implements Store using SQLite.
 type SQLiteStore struct {
-	db      *sql.DB
-	puzzles *sqlitePuzzleRepo
-	drafts  *sqliteDraftRepo
+	db          *sql.DB
+	puzzles     *sqlitePuzzleRepo
+	drafts      *sqliteDraftRepo
+	idempotency *sqliteIdempotencyRepo
 }
 
 // NewSQLiteStore creates a new SQLite store.
@@ -53,6 +60,7 @@ func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
 	store := &SQLiteStore{db: db}
 	store.puzzles = &sqlitePuzzleRepo{db: db}
 	store.drafts = &sqliteDraftRepo{db: db}
+	store.idempotency = &sqliteIdempotencyRepo{db: db}
 
 	return store, nil
 }
@@ -67,18 +75,98 @@ func (s *SQLiteStore) Drafts() DraftRepository {
 	return s.drafts
 }
 
-// Migrate runs database migrations.
+// Idempotency returns the idempotency key repository.
+func (s *SQLiteStore) Idempotency() IdempotencyRepository {
+	return s.idempotency
+}
+
+// Migrate runs pending database migrations in version order.
+// Applied versions are recorded in schema_migrations so each runs once.
 func (s *SQLiteStore) Migrate(ctx context.Context) error {
-	upSQL, err := migrationsFS.ReadFile("migrations/001_initial.up.sql")
+	_, err := s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version TEXT PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
 	if err != nil {
-		return fmt.Errorf("failed to read migration: %w", err)
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	if err := s.adoptUntrackedSchema(ctx); err != nil {
+		return err
 	}
 
-	_, err = s.db.ExecContext(ctx, string(upSQL))
+	files, err := fs.Glob(migrationsFS, "migrations/*.up.sql")
 	if err != nil {
-		return fmt.Errorf("failed to run migration: %w", err)
+		return fmt.Errorf("failed to list migrations: %w", err)
 	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version := strings.TrimSuffix(path.Base(file), ".up.sql")
 
+		var applied int
+		err := s.db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, version).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("failed to check migration %s: %w", version, err)
+		}
+		if applied > 0 {
+			continue
+		}
+
+		upSQL, err := migrationsFS.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", version, err)
+		}
+
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %s: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, string(upSQL)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to run migration %s: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %s: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// adoptUntrackedSchema records 001_initial as applied on databases created
+// before migrations were tracked: they have the puzzles table but no recorded
+// version, and must not run 001 again.
+func (s *SQLiteStore) adoptUntrackedSchema(ctx context.Context) error {
+	var tracked, tables int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&tracked); err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+	if tracked > 0 {
+		return nil
+	}
+
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'puzzles'`).Scan(&tables)
+	if err != nil {
+		return fmt.Errorf("failed to detect existing schema: %w", err)
+	}
+	if tables == 0 {
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version) VALUES ('001_initial')`); err != nil {
+		return fmt.Errorf("failed to record existing schema: %w", err)
+	}
 	return nil
 }
 
@@ -92,7 +180,34 @@ type sqlitePuzzleRepo struct {
 	db *sql.DB
 }
 
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 func (r *sqlitePuzzleRepo) Store(ctx context.Context, p *domain.Puzzle) error {
+	return storePuzzle(ctx, r.db, p)
+}
+
+func (r *sqlitePuzzleRepo) StoreBatch(ctx context.Context, puzzles []*domain.Puzzle) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin batch: %w", err)
+	}
+	for _, p := range puzzles {
+		if err := storePuzzle(ctx, tx, p); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	return nil
+}
+
+// storePuzzle upserts a puzzle through db, which may be a transaction.
+func storePuzzle(ctx context.Context, db execer, p *domain.Puzzle) error {
 	if p.ID == "" {
 		p.ID = uuid.New().String()
 	}
@@ -112,7 +227,7 @@ func (r *sqlitePuzzleRepo) Store(ctx context.Context, p *domain.Puzzle) error {
 
 	// Use INSERT with ON CONFLICT DO UPDATE to handle updates by ID
 	// but still fail on duplicate (language, date) for different IDs
-	_, err = r.db.ExecContext(ctx, `
+	_, err = db.ExecContext(ctx, `
 		INSERT INTO puzzles (id, date, language, title, author, difficulty, status, payload, created_at, published_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
@@ -127,12 +242,24 @@ func (r *sqlitePuzzleRepo) Store(ctx context.Context, p *domain.Puzzle) error {
 	`, p.ID, p.Date, p.Language, p.Title, p.Author, p.Difficulty, p.Status, payload, p.CreatedAt, publishedAt)
 
 	if err != nil {
+		if isUniqueDateViolation(err) {
+			return fmt.Errorf("%w: %s/%s", ErrDuplicateDate, p.Language, p.Date)
+		}
 		return fmt.Errorf("failed to store puzzle: %w", err)
 	}
 
 	return nil
 }
 
+// isUniqueDateViolation reports whether err is the UNIQUE(language, date) constraint failure.
+// The driver only exposes this through its message, so match on the text.
+func isUniqueDateViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") &&
+		strings.Contains(msg, "puzzles.language") &&
+		strings.Contains(msg, "puzzles.date")
+}
+
 func (r *sqlitePuzzleRepo) Get(ctx context.Context, id string) (*domain.Puzzle, error) {
 	var payload []byte
 	err := r.db.QueryRowContext(ctx, `
@@ -175,6 +302,60 @@ func (r *sqlitePuzzleRepo) GetByDate(ctx context.Context, language, date string)
 	return &puzzle, nil
 }
 
+func (r *sqlitePuzzleRepo) Latest(ctx context.Context, language string) (*domain.Puzzle, error) {
+	var payload []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT payload FROM puzzles WHERE language = ? AND status = ?
+		ORDER BY date DESC LIMIT 1
+	`, language, domain.StatusPublished).Scan(&payload)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest puzzle: %w", err)
+	}
+
+	var puzzle domain.Puzzle
+	if err := json.Unmarshal(payload, &puzzle); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal puzzle: %w", err)
+	}
+
+	return &puzzle, nil
+}
+
+func (r *sqlitePuzzleRepo) PublishedDates(ctx context.Context, language, fromDate, toDate string) ([]string, error) {
+	query := `SELECT DISTINCT date FROM puzzles WHERE language = ? AND status = ?`
+	args := []interface{}{language, domain.StatusPublished}
+
+	if fromDate != "" {
+		query += " AND date >= ?"
+		args = append(args, fromDate)
+	}
+	if toDate != "" {
+		query += " AND date <= ?"
+		args = append(args, toDate)
+	}
+	query += " ORDER BY date"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query puzzle dates: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []string
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("failed to scan puzzle date: %w", err)
+		}
+		dates = append(dates, date)
+	}
+
+	return dates, rows.Err()
+}
+
 func (r *sqlitePuzzleRepo) List(ctx context.Context, filter PuzzleFilter) ([]*PuzzleSummary, error) {
 	query := `SELECT id, date, language, title, author, difficulty, status FROM puzzles WHERE 1=1`
 	args := []interface{}{}
@@ -200,7 +381,7 @@ func (r *sqlitePuzzleRepo) List(ctx context.Context, filter PuzzleFilter) ([]*Pu
 		args = append(args, filter.Difficulty)
 	}
 
-	query += " ORDER BY date DESC"
+	query += " ORDER BY date DESC, id"
 
 	if filter.Limit > 0 {
 		query += " LIMIT ?"
@@ -285,6 +466,76 @@ func (r *sqlitePuzzleRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *sqlitePuzzleRepo) Stats(ctx context.Context) (*PuzzleStats, error) {
+	stats := &PuzzleStats{
+		ByStatus:     make(map[string]int),
+		ByDifficulty: make(map[int]int),
+		ByLanguage:   make(map[string]int),
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT status, difficulty, language, COUNT(*)
+		FROM puzzles GROUP BY status, difficulty, language
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query puzzle stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status, language string
+		var difficulty, count int
+		if err := rows.Scan(&status, &difficulty, &language, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan puzzle stats: %w", err)
+		}
+		stats.Total += count
+		stats.ByStatus[status] += count
+		stats.ByDifficulty[difficulty] += count
+		stats.ByLanguage[language] += count
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *sqlitePuzzleRepo) RecentAnswers(ctx context.Context, language, fromDate, toDate string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT payload FROM puzzles
+		WHERE language = ? AND date >= ? AND date <= ?
+		ORDER BY date
+	`, language, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent puzzles: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []string
+	for rows.Next() {
+		var payload []byte
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("failed to scan puzzle: %w", err)
+		}
+		var puzzle domain.Puzzle
+		if err := json.Unmarshal(payload, &puzzle); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal puzzle: %w", err)
+		}
+		answers = append(answers, puzzleAnswers(&puzzle)...)
+	}
+
+	return answers, rows.Err()
+}
+
+// puzzleAnswers returns the answers of all clues in a puzzle.
+func puzzleAnswers(p *domain.Puzzle) []string {
+	answers := make([]string, 0, len(p.Clues.Across)+len(p.Clues.Down))
+	for _, c := range p.Clues.Across {
+		answers = append(answers, c.Answer)
+	}
+	for _, c := range p.Clues.Down {
+		answers = append(answers, c.Answer)
+	}
+	return answers
+}
+
 // sqliteDraftRepo implements DraftRepository for SQLite.
 type sqliteDraftRepo struct {
 	db *sql.DB
@@ -429,3 +680,88 @@ func (r *sqliteDraftRepo) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (r *sqliteDraftRepo) Stats(ctx context.Context) (*DraftStats, error) {
+	stats := &DraftStats{ByStatus: make(map[string]int)}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM drafts GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query draft stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan draft stats: %w", err)
+		}
+		stats.Total += count
+		stats.ByStatus[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var avg sql.NullFloat64
+	err = r.db.QueryRowContext(ctx, `
+		SELECT AVG((COALESCE(json_extract(CAST(report AS TEXT), '$.fill_score'), 0)
+			+ COALESCE(json_extract(CAST(report AS TEXT), '$.clue_score'), 0)
+			+ COALESCE(json_extract(CAST(report AS TEXT), '$.freshness_score'), 0)) / 3.0)
+		FROM drafts WHERE report IS NOT NULL
+	`).Scan(&avg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query draft QA average: %w", err)
+	}
+	stats.AverageQAScore = avg.Float64
+
+	return stats, nil
+}
+
+// sqliteIdempotencyRepo implements IdempotencyRepository for SQLite.
+// Expiry times are stored as Unix seconds.
+type sqliteIdempotencyRepo struct {
+	db *sql.DB
+}
+
+func (r *sqliteIdempotencyRepo) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	rec := IdempotencyRecord{Key: key}
+	var expiresAt int64
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT request_hash, response, expires_at
+		FROM idempotency_keys WHERE key = ? AND expires_at > ?
+	`, key, time.Now().Unix()).Scan(&rec.RequestHash, &rec.Response, &expiresAt)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	rec.ExpiresAt = time.Unix(expiresAt, 0).UTC()
+	return &rec, nil
+}
+
+func (r *sqliteIdempotencyRepo) Store(ctx context.Context, rec *IdempotencyRecord) error {
+	if _, err := r.db.ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE expires_at <= ?`, time.Now().Unix()); err != nil {
+		return fmt.Errorf("failed to remove expired idempotency keys: %w", err)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (key, request_hash, response, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			request_hash = excluded.request_hash,
+			response = excluded.response,
+			expires_at = excluded.expires_at
+	`, rec.Key, rec.RequestHash, rec.Response, rec.ExpiresAt.Unix())
+
+	if err != nil {
+		return fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+
+	return nil
+}
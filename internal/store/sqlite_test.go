@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -112,6 +114,119 @@ func TestPuzzleRepository_GetByDate_NotFound(t *testing.T) {
 	}
 }
 
+func TestPuzzleRepository_Latest(t *testing.T) {
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	if _, err := store.Puzzles().Latest(ctx, "fr"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound on empty store, got: %v", err)
+	}
+
+	puzzles := []struct {
+		id, date, language string
+		status             domain.PuzzleStatus
+	}{
+		{"old", "2024-01-10", "fr", domain.StatusPublished},
+		{"recent", "2024-01-15", "fr", domain.StatusPublished},
+		{"draft", "2024-01-20", "fr", domain.StatusDraft},
+		{"scheduled", "2099-01-01", "fr", domain.StatusScheduled},
+		{"english", "2024-02-01", "en", domain.StatusPublished},
+	}
+	for _, tc := range puzzles {
+		p := createTestPuzzle()
+		p.ID, p.Date, p.Language, p.Status = tc.id, tc.date, tc.language, tc.status
+		if err := store.Puzzles().Store(ctx, p); err != nil {
+			t.Fatalf("failed to store puzzle %s: %v", tc.id, err)
+		}
+	}
+
+	latest, err := store.Puzzles().Latest(ctx, "fr")
+	if err != nil {
+		t.Fatalf("failed to get latest puzzle: %v", err)
+	}
+	if latest.ID != "recent" {
+		t.Errorf("expected latest published puzzle 'recent', got %s", latest.ID)
+	}
+
+	if _, err := store.Puzzles().Latest(ctx, "de"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound for another language, got: %v", err)
+	}
+}
+
+func TestPuzzleRepository_PublishedDates(t *testing.T) {
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	puzzles := []struct {
+		id, date, language string
+		status             domain.PuzzleStatus
+	}{
+		{"jan", "2025-01-10", "fr", domain.StatusPublished},
+		{"mar", "2025-03-05", "fr", domain.StatusPublished},
+		{"feb", "2025-02-20", "fr", domain.StatusPublished},
+		{"last-year", "2024-12-31", "fr", domain.StatusPublished},
+		{"draft", "2025-04-01", "fr", domain.StatusDraft},
+		{"english", "2025-05-01", "en", domain.StatusPublished},
+	}
+	for _, tc := range puzzles {
+		p := createTestPuzzle()
+		p.ID, p.Date, p.Language, p.Status = tc.id, tc.date, tc.language, tc.status
+		if err := store.Puzzles().Store(ctx, p); err != nil {
+			t.Fatalf("failed to store puzzle %s: %v", tc.id, err)
+		}
+	}
+
+	dates, err := store.Puzzles().PublishedDates(ctx, "fr", "2025-01-01", "2025-12-31")
+	if err != nil {
+		t.Fatalf("failed to list dates: %v", err)
+	}
+	want := []string{"2025-01-10", "2025-02-20", "2025-03-05"}
+	if strings.Join(dates, ",") != strings.Join(want, ",") {
+		t.Errorf("expected dates %v, got %v", want, dates)
+	}
+
+	all, err := store.Puzzles().PublishedDates(ctx, "fr", "", "")
+	if err != nil {
+		t.Fatalf("failed to list dates: %v", err)
+	}
+	if len(all) != 4 || all[0] != "2024-12-31" {
+		t.Errorf("expected 4 dates from 2024-12-31 without bounds, got %v", all)
+	}
+}
+
+func TestPuzzleRepository_RecentAnswers(t *testing.T) {
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	inWindow := createTestPuzzle()
+	if err := store.Puzzles().Store(ctx, inWindow); err != nil {
+		t.Fatalf("failed to store puzzle: %v", err)
+	}
+	outOfWindow := createTestPuzzle()
+	outOfWindow.ID = "test-puzzle-old"
+	outOfWindow.Date = "2024-01-01"
+	outOfWindow.Clues.Across[0].Answer = "OLD"
+	if err := store.Puzzles().Store(ctx, outOfWindow); err != nil {
+		t.Fatalf("failed to store puzzle: %v", err)
+	}
+
+	answers, err := store.Puzzles().RecentAnswers(ctx, "fr", "2024-01-10", "2024-01-16")
+	if err != nil {
+		t.Fatalf("failed to get recent answers: %v", err)
+	}
+	if len(answers) != 2 || answers[0] != "AB" || answers[1] != "AC" {
+		t.Errorf("expected [AB AC], got %v", answers)
+	}
+
+	answers, err = store.Puzzles().RecentAnswers(ctx, "en", "2024-01-01", "2024-01-31")
+	if err != nil {
+		t.Fatalf("failed to get recent answers: %v", err)
+	}
+	if len(answers) != 0 {
+		t.Errorf("expected no answers for another language, got %v", answers)
+	}
+}
+
 func TestPuzzleRepository_List(t *testing.T) {
 	store := setupTestStore(t)
 	ctx := context.Background()
@@ -145,6 +260,36 @@ func TestPuzzleRepository_List(t *testing.T) {
 	}
 }
 
+func TestPuzzleRepository_List_SameDatePaging(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range map[string]Store{"sqlite": setupTestStore(t), "memory": NewMemoryStore()} {
+		// One puzzle per language on the same date
+		for _, p := range []struct{ id, language string }{{"z-puzzle", "fr"}, {"a-puzzle", "en"}} {
+			puzzle := createTestPuzzle()
+			puzzle.ID = p.id
+			puzzle.Language = p.language
+			if err := s.Puzzles().Store(ctx, puzzle); err != nil {
+				t.Fatalf("%s: failed to store puzzle: %v", name, err)
+			}
+		}
+
+		var got []string
+		for offset := 0; offset < 2; offset++ {
+			page, err := s.Puzzles().List(ctx, PuzzleFilter{Limit: 1, Offset: offset})
+			if err != nil {
+				t.Fatalf("%s: failed to list puzzles: %v", name, err)
+			}
+			for _, p := range page {
+				got = append(got, p.ID)
+			}
+		}
+		if strings.Join(got, ",") != "a-puzzle,z-puzzle" {
+			t.Errorf("%s: expected pages ordered by ID within a date, got %v", name, got)
+		}
+	}
+}
+
 func TestPuzzleRepository_List_WithFilters(t *testing.T) {
 	store := setupTestStore(t)
 	ctx := context.Background()
@@ -381,6 +526,110 @@ func TestSQLiteStore_UniqueConstraint(t *testing.T) {
 	if err == nil {
 		t.Error("expected error for duplicate language/date, got none")
 	}
+	if !errors.Is(err, ErrDuplicateDate) {
+		t.Errorf("expected ErrDuplicateDate, got %v", err)
+	}
+}
+
+func TestSQLiteStore_MigrateIdempotent(t *testing.T) {
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	// Running migrations again must be a no-op
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("second migration failed: %v", err)
+	}
+}
+
+func TestSQLiteStore_MigrateUntrackedSchema(t *testing.T) {
+	store, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	// A database created before migrations were tracked
+	upSQL, _ := migrationsFS.ReadFile("migrations/001_initial.up.sql")
+	if _, err := store.db.ExecContext(ctx, string(upSQL)); err != nil {
+		t.Fatalf("failed to create legacy schema: %v", err)
+	}
+	if err := store.Puzzles().Store(ctx, createTestPuzzle()); err != nil {
+		t.Fatalf("failed to store puzzle: %v", err)
+	}
+
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("migration failed: %v", err)
+	}
+
+	var initial int
+	store.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations WHERE version = '001_initial'`).Scan(&initial)
+	if initial != 1 {
+		t.Errorf("expected the existing schema recorded as 001_initial, got %d rows", initial)
+	}
+	if _, err := store.Puzzles().Get(ctx, "test-puzzle-1"); err != nil {
+		t.Errorf("expected existing puzzle kept, got %v", err)
+	}
+	if err := store.Puzzles().UpdateStatus(ctx, "test-puzzle-1", domain.StatusScheduled); err != nil {
+		t.Errorf("expected later migrations applied, got %v", err)
+	}
+}
+
+func TestSQLiteStore_ScheduledStatus(t *testing.T) {
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	puzzle := createTestPuzzle()
+	puzzle.Status = domain.StatusScheduled
+	if err := store.Puzzles().Store(ctx, puzzle); err != nil {
+		t.Fatalf("failed to store scheduled puzzle: %v", err)
+	}
+
+	list, err := store.Puzzles().List(ctx, PuzzleFilter{Status: domain.StatusScheduled})
+	if err != nil {
+		t.Fatalf("failed to list puzzles: %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("expected 1 scheduled puzzle, got %d", len(list))
+	}
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	for name, s := range map[string]Store{"sqlite": setupTestStore(t), "memory": NewMemoryStore()} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := s.Idempotency()
+
+			if _, err := repo.Get(ctx, "missing"); err != ErrNotFound {
+				t.Errorf("expected ErrNotFound for an unknown key, got %v", err)
+			}
+
+			rec := &IdempotencyRecord{
+				Key:         "key-1",
+				RequestHash: "abc",
+				Response:    []byte(`{"generation_id":"gen-1"}`),
+				ExpiresAt:   time.Now().Add(time.Hour),
+			}
+			if err := repo.Store(ctx, rec); err != nil {
+				t.Fatalf("failed to store record: %v", err)
+			}
+			got, err := repo.Get(ctx, "key-1")
+			if err != nil {
+				t.Fatalf("failed to get record: %v", err)
+			}
+			if got.RequestHash != "abc" || string(got.Response) != string(rec.Response) {
+				t.Errorf("unexpected record %+v", got)
+			}
+
+			expired := &IdempotencyRecord{Key: "key-2", RequestHash: "def", Response: []byte(`{}`), ExpiresAt: time.Now().Add(-time.Second)}
+			if err := repo.Store(ctx, expired); err != nil {
+				t.Fatalf("failed to store record: %v", err)
+			}
+			if _, err := repo.Get(ctx, "key-2"); err != ErrNotFound {
+				t.Errorf("expected ErrNotFound for an expired key, got %v", err)
+			}
+		})
+	}
 }
 
 func TestSQLiteStore_Timestamps(t *testing.T) {
@@ -406,3 +655,103 @@ func TestSQLiteStore_Timestamps(t *testing.T) {
 		t.Errorf("UpdatedAt out of expected range: %v", retrieved.UpdatedAt)
 	}
 }
+
+func TestSQLiteStore_Stats(t *testing.T) {
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	puzzles := []struct {
+		id         string
+		date       string
+		language   string
+		difficulty int
+		status     domain.PuzzleStatus
+	}{
+		{"p1", "2024-01-01", "fr", 2, domain.StatusPublished},
+		{"p2", "2024-01-02", "fr", 3, domain.StatusPublished},
+		{"p3", "2024-01-03", "fr", 3, domain.StatusDraft},
+		{"p4", "2024-01-01", "en", 3, domain.StatusArchived},
+	}
+	for _, tc := range puzzles {
+		p := createTestPuzzle()
+		p.ID, p.Date, p.Language, p.Difficulty, p.Status = tc.id, tc.date, tc.language, tc.difficulty, tc.status
+		if err := store.Puzzles().Store(ctx, p); err != nil {
+			t.Fatalf("failed to store puzzle %s: %v", tc.id, err)
+		}
+	}
+
+	drafts := []*Draft{
+		{ID: "d1", Language: "fr", Puzzle: *createTestPuzzle(), Status: "draft",
+			Report: &domain.DraftReport{FillScore: 90, ClueScore: 60, FreshnessScore: 90}},
+		{ID: "d2", Language: "fr", Puzzle: *createTestPuzzle(), Status: "rejected",
+			Report: &domain.DraftReport{FillScore: 30, ClueScore: 60, FreshnessScore: 30}},
+		{ID: "d3", Language: "fr", Puzzle: *createTestPuzzle(), Status: "draft"},
+	}
+	for _, d := range drafts {
+		if err := store.Drafts().Store(ctx, d); err != nil {
+			t.Fatalf("failed to store draft %s: %v", d.ID, err)
+		}
+	}
+
+	ps, err := store.Puzzles().Stats(ctx)
+	if err != nil {
+		t.Fatalf("puzzle stats failed: %v", err)
+	}
+	if ps.Total != 4 {
+		t.Errorf("expected 4 puzzles, got %d", ps.Total)
+	}
+	if ps.ByStatus["published"] != 2 || ps.ByStatus["draft"] != 1 || ps.ByStatus["archived"] != 1 {
+		t.Errorf("unexpected counts by status: %v", ps.ByStatus)
+	}
+	if ps.ByDifficulty[2] != 1 || ps.ByDifficulty[3] != 3 {
+		t.Errorf("unexpected counts by difficulty: %v", ps.ByDifficulty)
+	}
+	if ps.ByLanguage["fr"] != 3 || ps.ByLanguage["en"] != 1 {
+		t.Errorf("unexpected counts by language: %v", ps.ByLanguage)
+	}
+
+	ds, err := store.Drafts().Stats(ctx)
+	if err != nil {
+		t.Fatalf("draft stats failed: %v", err)
+	}
+	if ds.Total != 3 || ds.ByStatus["draft"] != 2 || ds.ByStatus["rejected"] != 1 {
+		t.Errorf("unexpected draft counts: %+v", ds)
+	}
+	if ds.AverageQAScore != 60 {
+		t.Errorf("expected average QA score 60, got %.2f", ds.AverageQAScore)
+	}
+}
+
+func TestPuzzleRepository_StoreBatch(t *testing.T) {
+	store := setupTestStore(t)
+	ctx := context.Background()
+
+	first := createTestPuzzle()
+	second := createTestPuzzle()
+	second.ID = "test-puzzle-2"
+	second.Date = "2024-01-16"
+
+	if err := store.Puzzles().StoreBatch(ctx, []*domain.Puzzle{first, second}); err != nil {
+		t.Fatalf("failed to store batch: %v", err)
+	}
+	for _, id := range []string{first.ID, second.ID} {
+		if _, err := store.Puzzles().Get(ctx, id); err != nil {
+			t.Errorf("puzzle %s not stored: %v", id, err)
+		}
+	}
+
+	// A duplicate date rolls back the whole batch
+	third := createTestPuzzle()
+	third.ID = "test-puzzle-3"
+	third.Date = "2024-01-17"
+	clash := createTestPuzzle()
+	clash.ID = "test-puzzle-4"
+
+	err := store.Puzzles().StoreBatch(ctx, []*domain.Puzzle{third, clash})
+	if !errors.Is(err, ErrDuplicateDate) {
+		t.Fatalf("expected ErrDuplicateDate, got %v", err)
+	}
+	if _, err := store.Puzzles().Get(ctx, third.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected batch rollback, got %v", err)
+	}
+}
@@ -40,6 +40,21 @@ type DraftSummary struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// PuzzleStats contains aggregate puzzle counts.
+type PuzzleStats struct {
+	Total        int            `json:"total"`
+	ByStatus     map[string]int `json:"by_status"`
+	ByDifficulty map[int]int    `json:"by_difficulty"`
+	ByLanguage   map[string]int `json:"by_language"`
+}
+
+// DraftStats contains aggregate draft counts and QA averages.
+type DraftStats struct {
+	Total          int            `json:"total"`
+	ByStatus       map[string]int `json:"by_status"`
+	AverageQAScore float64        `json:"average_qa_score"` // Mean of fill/clue/freshness scores (0-100) over drafts with a report
+}
+
 // Draft represents a puzzle draft with its QA report.
 type Draft struct {
 	ID        string              `json:"id"`
@@ -56,20 +71,39 @@ type PuzzleRepository interface {
 	// Store saves a puzzle to the database.
 	Store(ctx context.Context, p *domain.Puzzle) error
 
+	// StoreBatch saves several puzzles atomically: either all are stored or none.
+	StoreBatch(ctx context.Context, puzzles []*domain.Puzzle) error
+
 	// Get retrieves a puzzle by ID.
 	Get(ctx context.Context, id string) (*domain.Puzzle, error)
 
 	// GetByDate retrieves a puzzle by language and date.
 	GetByDate(ctx context.Context, language, date string) (*domain.Puzzle, error)
 
+	// Latest retrieves the published puzzle with the most recent date in a
+	// language, or ErrNotFound if none is published.
+	Latest(ctx context.Context, language string) (*domain.Puzzle, error)
+
 	// List returns puzzles matching the filter criteria.
 	List(ctx context.Context, filter PuzzleFilter) ([]*PuzzleSummary, error)
 
+	// PublishedDates returns the sorted, distinct dates of published puzzles
+	// in a language between fromDate and toDate (inclusive, YYYY-MM-DD; empty
+	// means unbounded).
+	PublishedDates(ctx context.Context, language, fromDate, toDate string) ([]string, error)
+
 	// UpdateStatus changes the status of a puzzle.
 	UpdateStatus(ctx context.Context, id string, status domain.PuzzleStatus) error
 
 	// Delete removes a puzzle by ID.
 	Delete(ctx context.Context, id string) error
+
+	// Stats returns aggregate counts by status, difficulty and language.
+	Stats(ctx context.Context) (*PuzzleStats, error)
+
+	// RecentAnswers returns the answers of puzzles in a language dated
+	// between fromDate and toDate (inclusive, YYYY-MM-DD).
+	RecentAnswers(ctx context.Context, language, fromDate, toDate string) ([]string, error)
 }
 
 // DraftRepository defines the interface for draft storage operations.
@@ -88,12 +122,35 @@ type DraftRepository interface {
 
 	// Delete removes a draft by ID.
 	Delete(ctx context.Context, id string) error
+
+	// Stats returns aggregate counts by status and the average QA score.
+	Stats(ctx context.Context) (*DraftStats, error)
+}
+
+// IdempotencyRecord is a response saved under an idempotency key, replayed
+// when a request is repeated with the same key.
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string // Identifies the request, so a key reused for another request is detected
+	Response    []byte // JSON response body
+	ExpiresAt   time.Time
+}
+
+// IdempotencyRepository defines the interface for idempotency key storage.
+type IdempotencyRepository interface {
+	// Get retrieves the unexpired record for a key, or ErrNotFound.
+	Get(ctx context.Context, key string) (*IdempotencyRecord, error)
+
+	// Store saves a record, replacing any previous one for its key, and
+	// removes expired records.
+	Store(ctx context.Context, rec *IdempotencyRecord) error
 }
 
 // Store combines all repository interfaces.
 type Store interface {
 	Puzzles() PuzzleRepository
 	Drafts() DraftRepository
+	Idempotency() IdempotencyRepository
 
 	// Migrate runs database migrations.
 	Migrate(ctx context.Context) error
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
 
@@ -15,6 +16,10 @@ import (
 //go:embed schemas/*.json
 var schemasFS embed.FS
 
+// MaxCluePromptLength is the longest clue prompt accepted, in characters. It
+// mirrors the prompt maxLength of the puzzle schema.
+const MaxCluePromptLength = 120
+
 var (
 	puzzleSchema     *jsonschema.Schema
 	draftBundleSchema *jsonschema.Schema
@@ -145,8 +150,51 @@ func extractValidationErrors(ve *jsonschema.ValidationError) ValidationErrors {
 }
 
 // ValidatePuzzleSemantic performs semantic validation on a parsed puzzle.
-// This catches errors that JSON Schema cannot express.
+// This catches errors that JSON Schema cannot express. Solutions must be A-Z;
+// use ValidatePuzzleSemanticWithAlphabet for languages with other letters.
 func ValidatePuzzleSemantic(p *domain.Puzzle) ValidationErrors {
+	return ValidatePuzzleSemanticWithAlphabet(p, nil)
+}
+
+// SemanticOptions holds optional semantic checks, such as print layout
+// constraints, and the letters solutions may use. The zero value adds no
+// check and accepts A-Z.
+type SemanticOptions struct {
+	MinAspectRatio float64 // Smallest accepted cols/rows ratio (0 = unbounded)
+	MaxAspectRatio float64 // Largest accepted cols/rows ratio (0 = unbounded)
+	Alphabet       []rune  // Letters allowed in solutions, e.g. LanguagePack.Alphabet() (empty = A-Z)
+}
+
+// ValidatePuzzleSemanticWithOptions is ValidatePuzzleSemanticWithAlphabet
+// with opts.Alphabet, plus the optional checks enabled in opts.
+func ValidatePuzzleSemanticWithOptions(p *domain.Puzzle, opts SemanticOptions) ValidationErrors {
+	errors := ValidatePuzzleSemanticWithAlphabet(p, opts.Alphabet)
+
+	// Check the grid is not too elongated for the layout
+	rows, cols := p.GridDimensions()
+	if rows > 0 && cols > 0 {
+		ratio := float64(cols) / float64(rows)
+		if opts.MinAspectRatio > 0 && ratio < opts.MinAspectRatio {
+			errors = append(errors, ValidationError{
+				Path:    "/grid",
+				Message: fmt.Sprintf("grid %dx%d has aspect ratio %.2f, below minimum of %.2f", rows, cols, ratio, opts.MinAspectRatio),
+			})
+		}
+		if opts.MaxAspectRatio > 0 && ratio > opts.MaxAspectRatio {
+			errors = append(errors, ValidationError{
+				Path:    "/grid",
+				Message: fmt.Sprintf("grid %dx%d has aspect ratio %.2f, exceeds maximum of %.2f", rows, cols, ratio, opts.MaxAspectRatio),
+			})
+		}
+	}
+
+	return errors
+}
+
+// ValidatePuzzleSemanticWithAlphabet is ValidatePuzzleSemantic with the
+// letters allowed in solutions, such as a language pack's Alphabet(). An empty
+// alphabet allows A-Z.
+func ValidatePuzzleSemanticWithAlphabet(p *domain.Puzzle, letters []rune) ValidationErrors {
 	var errors ValidationErrors
 
 	// Check grid is rectangular
@@ -176,15 +224,22 @@ func ValidatePuzzleSemantic(p *domain.Puzzle) ValidationErrors {
 	}
 
 	// Check all letter cells have valid solutions
+	errors = append(errors, ValidateAlphabet(p, letters)...)
+
+	// Check non-letter cells carry no solution, and only clue cells carry clue text
 	for r, row := range p.Grid {
 		for c, cell := range row {
-			if cell.IsLetter() {
-				if len(cell.Solution) != 1 || cell.Solution[0] < 'A' || cell.Solution[0] > 'Z' {
-					errors = append(errors, ValidationError{
-						Path:    fmt.Sprintf("/grid/%d/%d/solution", r, c),
-						Message: fmt.Sprintf("letter cell must have A-Z solution, got %q", cell.Solution),
-					})
-				}
+			if !cell.IsLetter() && cell.Solution != "" {
+				errors = append(errors, ValidationError{
+					Path:    fmt.Sprintf("/grid/%d/%d/solution", r, c),
+					Message: fmt.Sprintf("%s cell must not have a solution, got %q", cell.Type, cell.Solution),
+				})
+			}
+			if !cell.IsClue() && (cell.ClueAcross != "" || cell.ClueDown != "") {
+				errors = append(errors, ValidationError{
+					Path:    fmt.Sprintf("/grid/%d/%d", r, c),
+					Message: fmt.Sprintf("%s cell must not carry clue text", cell.Type),
+				})
 			}
 		}
 	}
@@ -210,25 +265,49 @@ func ValidatePuzzleSemantic(p *domain.Puzzle) ValidationErrors {
 		}
 	}
 
+	// Check clue prompts fit in a clue cell, as the schema does
+	for _, list := range []struct {
+		dir   string
+		clues []domain.Clue
+	}{{"across", p.Clues.Across}, {"down", p.Clues.Down}} {
+		for i, clue := range list.clues {
+			if n := utf8.RuneCountInString(clue.Prompt); n > MaxCluePromptLength {
+				errors = append(errors, ValidationError{
+					Path:    fmt.Sprintf("/clues/%s/%d/prompt", list.dir, i),
+					Message: fmt.Sprintf("prompt has %d characters, exceeds maximum of %d", n, MaxCluePromptLength),
+				})
+			}
+		}
+	}
+
 	// Check clue lengths match answer lengths
 	for i, clue := range p.Clues.Across {
-		if clue.Length != len(clue.Answer) {
+		if clue.Length != utf8.RuneCountInString(clue.Answer) {
 			errors = append(errors, ValidationError{
 				Path:    fmt.Sprintf("/clues/across/%d/length", i),
-				Message: fmt.Sprintf("length %d doesn't match answer length %d", clue.Length, len(clue.Answer)),
+				Message: fmt.Sprintf("length %d doesn't match answer length %d", clue.Length, utf8.RuneCountInString(clue.Answer)),
 			})
 		}
 	}
 
 	for i, clue := range p.Clues.Down {
-		if clue.Length != len(clue.Answer) {
+		if clue.Length != utf8.RuneCountInString(clue.Answer) {
 			errors = append(errors, ValidationError{
 				Path:    fmt.Sprintf("/clues/down/%d/length", i),
-				Message: fmt.Sprintf("length %d doesn't match answer length %d", clue.Length, len(clue.Answer)),
+				Message: fmt.Sprintf("length %d doesn't match answer length %d", clue.Length, utf8.RuneCountInString(clue.Answer)),
 			})
 		}
 	}
 
+	// American numbering ties each clue to the number on its start cell;
+	// mots fléchés clues live in arrow cells, so numbers are not checked.
+	// Puzzles stored before the scheme existed leave it empty and may be mots
+	// fléchés keyed by slot ID, so they are not checked either.
+	if p.NumberingScheme == domain.NumberingAmerican {
+		errors = append(errors, validateNumbering(p.Grid, p.Clues.Across, "across")...)
+		errors = append(errors, validateNumbering(p.Grid, p.Clues.Down, "down")...)
+	}
+
 	// Check every non-block cell belongs to at least one entry
 	cellCoverage := make(map[string]bool)
 	for _, clue := range p.Clues.Across {
@@ -261,6 +340,64 @@ func ValidatePuzzleSemantic(p *domain.Puzzle) ValidationErrors {
 	return errors
 }
 
+// ValidateAlphabet checks that every letter cell holds one letter of the
+// alphabet and that clue answers use only its letters, such as a language
+// pack's Alphabet(). An empty alphabet allows A-Z. Unlike the other semantic
+// checks it holds for any puzzle, so stores and imports can run it alone.
+func ValidateAlphabet(p *domain.Puzzle, letters []rune) ValidationErrors {
+	var errors ValidationErrors
+	alphabet, alphabetName := solutionAlphabet(letters)
+	for r, row := range p.Grid {
+		for c, cell := range row {
+			if cell.IsLetter() {
+				letter, size := utf8.DecodeRuneInString(cell.Solution)
+				if size == 0 || size != len(cell.Solution) || !alphabet[letter] {
+					errors = append(errors, ValidationError{
+						Path:    fmt.Sprintf("/grid/%d/%d/solution", r, c),
+						Message: fmt.Sprintf("letter cell must have %s solution, got %q", alphabetName, cell.Solution),
+					})
+				}
+			}
+		}
+	}
+
+	for _, list := range []struct {
+		name  string
+		clues []domain.Clue
+	}{{"across", p.Clues.Across}, {"down", p.Clues.Down}} {
+		for i, clue := range list.clues {
+			for _, letter := range clue.Answer {
+				if !alphabet[letter] {
+					errors = append(errors, ValidationError{
+						Path:    fmt.Sprintf("/clues/%s/%d/answer", list.name, i),
+						Message: fmt.Sprintf("answer letters must each be %s, got %q", alphabetName, clue.Answer),
+					})
+					break
+				}
+			}
+		}
+	}
+	return errors
+}
+
+// solutionAlphabet returns the set of allowed solution letters, with a
+// description for error messages. An empty list allows A-Z.
+func solutionAlphabet(letters []rune) (map[rune]bool, string) {
+	if len(letters) == 0 {
+		alphabet := make(map[rune]bool, 26)
+		for r := 'A'; r <= 'Z'; r++ {
+			alphabet[r] = true
+		}
+		return alphabet, "A-Z"
+	}
+
+	alphabet := make(map[rune]bool, len(letters))
+	for _, r := range letters {
+		alphabet[r] = true
+	}
+	return alphabet, fmt.Sprintf("a letter of %s", string(letters))
+}
+
 func extractAnswer(grid [][]Cell, start domain.Position, length int, dir domain.Direction) string {
 	var answer strings.Builder
 	for i := 0; i < length; i++ {
@@ -277,11 +414,45 @@ func extractAnswer(grid [][]Cell, start domain.Position, length int, dir domain.
 	return answer.String()
 }
 
+// validateNumbering checks that each clue's number is positive and matches the
+// number on its start cell.
+func validateNumbering(grid [][]domain.Cell, clues []domain.Clue, direction string) ValidationErrors {
+	var errors ValidationErrors
+	for i, clue := range clues {
+		path := fmt.Sprintf("/clues/%s/%d/number", direction, i)
+		if clue.Number < 1 {
+			errors = append(errors, ValidationError{
+				Path:    path,
+				Message: "american numbering requires a positive clue number",
+			})
+			continue
+		}
+		r, c := clue.Start.Row, clue.Start.Col
+		if r < 0 || r >= len(grid) || c < 0 || c >= len(grid[r]) {
+			continue
+		}
+		if grid[r][c].Number != clue.Number {
+			errors = append(errors, ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("clue number %d doesn't match start cell number %d", clue.Number, grid[r][c].Number),
+			})
+		}
+	}
+	return errors
+}
+
 // Cell is a local type alias for embedding compatibility
 type Cell = domain.Cell
 
-// ValidatePuzzle performs both schema and semantic validation.
+// ValidatePuzzle performs both schema and semantic validation, with A-Z
+// solutions.
 func ValidatePuzzle(data []byte) ValidationErrors {
+	return ValidatePuzzleWithAlphabet(data, nil)
+}
+
+// ValidatePuzzleWithAlphabet is ValidatePuzzle with the letters allowed in
+// solutions; see ValidatePuzzleSemanticWithAlphabet.
+func ValidatePuzzleWithAlphabet(data []byte, letters []rune) ValidationErrors {
 	// First validate schema
 	schemaErrors := ValidatePuzzleJSON(data)
 	if len(schemaErrors) > 0 {
@@ -294,5 +465,5 @@ func ValidatePuzzle(data []byte) ValidationErrors {
 		return ValidationErrors{{Path: "", Message: fmt.Sprintf("failed to parse puzzle: %v", err)}}
 	}
 
-	return ValidatePuzzleSemantic(&puzzle)
+	return ValidatePuzzleSemanticWithAlphabet(&puzzle, letters)
 }
@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -87,6 +88,141 @@ func TestValidatePuzzleJSON_InvalidDifficultyRange(t *testing.T) {
 	}
 }
 
+func TestValidatePuzzleJSON_CluePromptTooLong(t *testing.T) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(loadFixture(t, "valid_7x7.json"), &doc); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+	down := doc["clues"].(map[string]interface{})["down"].([]interface{})
+	down[1].(map[string]interface{})["prompt"] = strings.Repeat("très ", 30)
+	data, _ := json.Marshal(doc)
+
+	errs := ValidatePuzzleJSON(data)
+	found := false
+	for _, e := range errs {
+		if e.Path == "/clues/down/1/prompt" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected schema error at /clues/down/1/prompt, got: %v", errs)
+	}
+
+	// The schema bound and the semantic check agree
+	var schema struct {
+		Defs struct {
+			Clue struct {
+				Properties struct {
+					Prompt struct {
+						MaxLength int `json:"maxLength"`
+					} `json:"prompt"`
+				} `json:"properties"`
+			} `json:"clue"`
+		} `json:"$defs"`
+	}
+	raw, _ := schemasFS.ReadFile("schemas/puzzle.schema.json")
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if schema.Defs.Clue.Properties.Prompt.MaxLength != MaxCluePromptLength {
+		t.Errorf("schema prompt maxLength %d differs from MaxCluePromptLength %d",
+			schema.Defs.Clue.Properties.Prompt.MaxLength, MaxCluePromptLength)
+	}
+}
+
+func TestValidatePuzzleSemantic_CluePromptTooLong(t *testing.T) {
+	puzzle := &domain.Puzzle{
+		Clues: domain.Clues{
+			Across: []domain.Clue{{Prompt: strings.Repeat("é", MaxCluePromptLength)}}, // At the limit in characters, not bytes
+			Down:   []domain.Clue{{Prompt: strings.Repeat("a", MaxCluePromptLength+1)}},
+		},
+	}
+
+	var paths []string
+	for _, e := range ValidatePuzzleSemantic(puzzle) {
+		if strings.HasSuffix(e.Path, "/prompt") {
+			paths = append(paths, e.Path)
+		}
+	}
+	if len(paths) != 1 || paths[0] != "/clues/down/0/prompt" {
+		t.Errorf("expected one prompt error at /clues/down/0/prompt, got %v", paths)
+	}
+}
+
+func TestValidatePuzzleSemanticWithOptions_AspectRatio(t *testing.T) {
+	grid := func(rows, cols int) *domain.Puzzle {
+		p := &domain.Puzzle{Language: "fr", Grid: make([][]domain.Cell, rows)}
+		for i := range p.Grid {
+			p.Grid[i] = make([]domain.Cell, cols)
+			for j := range p.Grid[i] {
+				p.Grid[i][j] = domain.Cell{Type: domain.CellTypeBlock}
+			}
+		}
+		return p
+	}
+	aspectErrors := func(errs ValidationErrors) []string {
+		var msgs []string
+		for _, e := range errs {
+			if strings.Contains(e.Message, "aspect ratio") {
+				msgs = append(msgs, e.Message)
+			}
+		}
+		return msgs
+	}
+
+	opts := SemanticOptions{MaxAspectRatio: 2.0}
+	if msgs := aspectErrors(ValidatePuzzleSemanticWithOptions(grid(5, 15), opts)); len(msgs) != 1 ||
+		msgs[0] != "grid 5x15 has aspect ratio 3.00, exceeds maximum of 2.00" {
+		t.Errorf("expected an aspect ratio error for 5x15, got %v", msgs)
+	}
+	if msgs := aspectErrors(ValidatePuzzleSemanticWithOptions(grid(13, 13), opts)); len(msgs) != 0 {
+		t.Errorf("expected 13x13 to pass, got %v", msgs)
+	}
+	if msgs := aspectErrors(ValidatePuzzleSemanticWithOptions(grid(15, 5), SemanticOptions{MinAspectRatio: 0.5})); len(msgs) != 1 {
+		t.Errorf("expected an aspect ratio error for 15x5 under a 0.5 minimum, got %v", msgs)
+	}
+	if msgs := aspectErrors(ValidatePuzzleSemanticWithOptions(grid(5, 15), SemanticOptions{})); len(msgs) != 0 {
+		t.Errorf("expected no aspect ratio bound by default, got %v", msgs)
+	}
+}
+
+func TestValidatePuzzleSemanticWithOptions_Alphabet(t *testing.T) {
+	grid := make([][]domain.Cell, 10)
+	for i := range grid {
+		grid[i] = make([]domain.Cell, 30)
+		for j := range grid[i] {
+			grid[i][j] = domain.Cell{Type: domain.CellTypeLetter, Solution: "A"}
+		}
+	}
+	grid[0][0].Solution = "Ñ"
+	puzzle := &domain.Puzzle{Grid: grid}
+
+	// Both the aspect ratio bound and the pack alphabet apply in one call
+	opts := SemanticOptions{MaxAspectRatio: 2.0, Alphabet: []rune("ABCDEFGHIJKLMNÑOPQRSTUVWXYZ")}
+	paths := map[string]bool{}
+	aspect := false
+	for _, e := range ValidatePuzzleSemanticWithOptions(puzzle, opts) {
+		paths[e.Path] = true
+		aspect = aspect || strings.Contains(e.Message, "aspect ratio")
+	}
+	if paths["/grid/0/0/solution"] {
+		t.Error("expected Ñ to pass with the alphabet option")
+	}
+	if !aspect {
+		t.Error("expected an aspect ratio error for 10x30")
+	}
+
+	// Without an alphabet, solutions are A-Z only
+	paths = map[string]bool{}
+	for _, e := range ValidatePuzzleSemanticWithOptions(puzzle, SemanticOptions{}) {
+		paths[e.Path] = true
+	}
+	if !paths["/grid/0/0/solution"] {
+		t.Error("expected Ñ to fail without an alphabet")
+	}
+}
+
 func TestValidatePuzzleSemantic_GridNotRectangular(t *testing.T) {
 	puzzle := &domain.Puzzle{
 		Grid: [][]domain.Cell{
@@ -168,6 +304,138 @@ func TestValidatePuzzleSemantic_InvalidSolution(t *testing.T) {
 	}
 }
 
+func TestValidatePuzzleSemanticWithAlphabet(t *testing.T) {
+	grid := make([][]domain.Cell, 10)
+	for i := range grid {
+		grid[i] = make([]domain.Cell, 10)
+		for j := range grid[i] {
+			grid[i][j] = domain.Cell{Type: domain.CellTypeLetter, Solution: "A"}
+		}
+	}
+	grid[0][0].Solution = "Ñ"
+	grid[0][1].Solution = "Ç"
+
+	// A pack alphabet with Ñ, as passed from LanguagePack.Alphabet()
+	alphabet := []rune("ABCDEFGHIJKLMNÑOPQRSTUVWXYZ")
+	errs := ValidatePuzzleSemanticWithAlphabet(&domain.Puzzle{Grid: grid}, alphabet)
+	paths := map[string]bool{}
+	for _, e := range errs {
+		paths[e.Path] = true
+	}
+	if paths["/grid/0/0/solution"] {
+		t.Errorf("expected Ñ to pass with the alphabet, got: %v", errs)
+	}
+	if !paths["/grid/0/1/solution"] {
+		t.Errorf("expected Ç outside the alphabet to fail, got: %v", errs)
+	}
+
+	// Without an alphabet, solutions are A-Z only
+	errs = ValidatePuzzleSemanticWithAlphabet(&domain.Puzzle{Grid: grid}, nil)
+	paths = map[string]bool{}
+	for _, e := range errs {
+		paths[e.Path] = true
+	}
+	if !paths["/grid/0/0/solution"] {
+		t.Errorf("expected Ñ to fail with the default alphabet, got: %v", errs)
+	}
+}
+
+func TestValidatePuzzleWithAlphabet(t *testing.T) {
+	grid := make([][]domain.Cell, 10)
+	var across []domain.Clue
+	for r := range grid {
+		grid[r] = make([]domain.Cell, 10)
+		for c := range grid[r] {
+			grid[r][c] = domain.Cell{Type: domain.CellTypeLetter, Solution: "A"}
+		}
+	}
+	grid[0][0].Solution = "Ñ"
+	for r := range grid {
+		start := domain.Position{Row: r, Col: 0}
+		across = append(across, domain.Clue{
+			Direction: domain.DirectionAcross,
+			Answer:    extractAnswer(grid, start, 10, domain.DirectionAcross),
+			Start:     start,
+			Length:    10,
+		})
+	}
+	puzzle := &domain.Puzzle{
+		ID: "es-1", Date: "2026-01-15", Language: "fr", Title: "Eñe", Author: "Test",
+		Difficulty: 3, Status: domain.StatusDraft, Grid: grid,
+		Clues:           domain.Clues{Across: across, Down: []domain.Clue{}},
+		NumberingScheme: domain.NumberingNone,
+	}
+	data, _ := json.Marshal(puzzle)
+
+	// The schema accepts any uppercase letter and leaves the alphabet to the
+	// semantic check
+	if errs := ValidatePuzzleWithAlphabet(data, []rune("ABCDEFGHIJKLMNÑOPQRSTUVWXYZ")); len(errs) > 0 {
+		t.Errorf("expected Ñ to validate with the alphabet, got: %v", errs)
+	}
+	if errs := ValidatePuzzle(data); len(errs) == 0 {
+		t.Error("expected Ñ to fail with the default alphabet")
+	}
+}
+
+func TestValidateAlphabet(t *testing.T) {
+	puzzle := &domain.Puzzle{
+		Grid: [][]domain.Cell{{
+			{Type: domain.CellTypeLetter, Solution: "Ñ"},
+			{Type: domain.CellTypeLetter, Solution: "A"},
+		}},
+		Clues: domain.Clues{Across: []domain.Clue{{Answer: "ÑA", Length: 2}}},
+	}
+
+	// The default alphabet rejects the cell and the answer
+	if errs := ValidateAlphabet(puzzle, nil); len(errs) != 2 {
+		t.Errorf("expected 2 errors with A-Z, got %v", errs)
+	}
+	if errs := ValidateAlphabet(puzzle, []rune("ABCDEFGHIJKLMNÑOPQRSTUVWXYZ")); len(errs) > 0 {
+		t.Errorf("expected Ñ to validate with the alphabet, got: %v", errs)
+	}
+}
+
+func TestValidatePuzzleSemantic_DirtyBlockCell(t *testing.T) {
+	grid := make([][]domain.Cell, 10)
+	for i := range grid {
+		grid[i] = make([]domain.Cell, 10)
+		for j := range grid[i] {
+			grid[i][j] = domain.Cell{Type: domain.CellTypeLetter, Solution: "A"}
+		}
+	}
+	grid[0][0] = domain.Cell{Type: domain.CellTypeBlock}
+	grid[9][9] = domain.Cell{Type: domain.CellTypeBlock}
+
+	hasCellError := func(errs ValidationErrors) bool {
+		for _, e := range errs {
+			if strings.Contains(e.Message, "must not") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if errs := ValidatePuzzleSemantic(&domain.Puzzle{Grid: grid}); hasCellError(errs) {
+		t.Errorf("expected clean blocks to pass, got: %v", errs)
+	}
+
+	grid[0][0].Solution = "X" // Left behind by a transform
+	grid[9][9].ClueDown = "Définition"
+	errs := ValidatePuzzleSemantic(&domain.Puzzle{Grid: grid})
+	paths := map[string]bool{}
+	for _, e := range errs {
+		if strings.Contains(e.Message, "must not") {
+			paths[e.Path] = true
+		}
+	}
+	if !paths["/grid/0/0/solution"] {
+		t.Errorf("expected error for block with a solution, got: %v", errs)
+	}
+	if !paths["/grid/9/9"] {
+		t.Errorf("expected error for block with clue text, got: %v", errs)
+	}
+}
+
 func TestValidatePuzzleSemantic_ClueAnswerMismatch(t *testing.T) {
 	// Create a 10x10 grid
 	grid := make([][]domain.Cell, 10)
@@ -335,3 +603,87 @@ func TestValidatePuzzle_Integration(t *testing.T) {
 		t.Logf("semantic validation notes: %v", errs)
 	}
 }
+
+func TestValidatePuzzleSemantic_NumberingNone(t *testing.T) {
+	// Mots fléchés layout: clue cells along the top row and left column,
+	// a 9x9 block of letters, and clues carrying no numbers at all
+	grid := make([][]domain.Cell, 10)
+	for r := range grid {
+		grid[r] = make([]domain.Cell, 10)
+		for c := range grid[r] {
+			if r == 0 || c == 0 {
+				grid[r][c] = domain.Cell{Type: domain.CellTypeClue, ClueAcross: "Définition"}
+				continue
+			}
+			grid[r][c] = domain.Cell{Type: domain.CellTypeLetter, Solution: string(rune('A' + (r+c)%26))}
+		}
+	}
+
+	var across, down []domain.Clue
+	for i := 1; i < 10; i++ {
+		across = append(across, domain.Clue{
+			Direction: domain.DirectionAcross,
+			Answer:    extractAnswer(grid, domain.Position{Row: i, Col: 1}, 9, domain.DirectionAcross),
+			Start:     domain.Position{Row: i, Col: 1},
+			Length:    9,
+		})
+		down = append(down, domain.Clue{
+			Direction: domain.DirectionDown,
+			Answer:    extractAnswer(grid, domain.Position{Row: 1, Col: i}, 9, domain.DirectionDown),
+			Start:     domain.Position{Row: 1, Col: i},
+			Length:    9,
+		})
+	}
+
+	puzzle := &domain.Puzzle{
+		ID:              "mf-1",
+		Date:            "2026-01-15",
+		Language:        "fr",
+		Title:           "Mots fléchés",
+		Author:          "Test",
+		Difficulty:      3,
+		Status:          domain.StatusDraft,
+		Grid:            grid,
+		Clues:           domain.Clues{Across: across, Down: down},
+		NumberingScheme: domain.NumberingNone,
+	}
+
+	data, err := json.Marshal(puzzle)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if errs := ValidatePuzzle(data); len(errs) > 0 {
+		t.Errorf("expected mots fléchés puzzle without numbers to validate, got: %v", errs)
+	}
+
+	// The same puzzle under american numbering must number its entries
+	puzzle.NumberingScheme = domain.NumberingAmerican
+	errs := ValidatePuzzleSemantic(puzzle)
+	found := false
+	for _, e := range errs {
+		if strings.Contains(e.Path, "/number") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected numbering errors under american scheme, got: %v", errs)
+	}
+
+	// Unnumbered clues are a schema error outside the none scheme
+	data, _ = json.Marshal(puzzle)
+	if errs := ValidatePuzzleJSON(data); len(errs) == 0 {
+		t.Error("expected schema errors for clue number 0 under american numbering")
+	}
+
+	// Legacy puzzles have no scheme and may key mots fléchés clues by slot ID
+	puzzle.NumberingScheme = ""
+	for i := range puzzle.Clues.Across {
+		puzzle.Clues.Across[i].Number = i + 1
+		puzzle.Clues.Down[i].Number = 10 + i
+	}
+	data, _ = json.Marshal(puzzle)
+	if errs := ValidatePuzzle(data); len(errs) > 0 {
+		t.Errorf("expected legacy puzzle without a scheme to validate, got: %v", errs)
+	}
+}